@@ -0,0 +1,129 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/bezmoradi/t2/internal/audio"
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// handleAudio lists, plays and deletes session audio saved with save_audio
+func handleAudio(args []string) {
+	if len(args) == 0 {
+		terminal.Println("Usage: t2 audio list")
+		terminal.Println("       t2 audio play [name]")
+		terminal.Println("       t2 audio purge")
+		terminal.Println("       t2 audio devices")
+		os.Exit(1)
+	}
+
+	if args[0] == "devices" {
+		listInputDevices()
+		return
+	}
+
+	archive := openArchive()
+
+	switch args[0] {
+	case "list":
+		recordings, err := archive.List()
+		if err != nil {
+			terminal.Printf("❌ Error reading recordings: %v\n", err)
+			os.Exit(1)
+		}
+		if len(recordings) == 0 {
+			terminal.Println("No saved recordings")
+			terminal.Println("💡 Set \"save_audio\": true in your config to keep each session's audio")
+			return
+		}
+		for _, recording := range recordings {
+			terminal.Printf("%s  %5.1fs  %6.1f KB\n", recording.Name, recording.Duration.Seconds(), float64(recording.Size)/1024)
+		}
+
+	case "play":
+		recordings, err := archive.List()
+		if err != nil {
+			terminal.Printf("❌ Error reading recordings: %v\n", err)
+			os.Exit(1)
+		}
+		if len(recordings) == 0 {
+			terminal.Println("❌ No saved recordings")
+			os.Exit(1)
+		}
+
+		// Default to the most recent session
+		recording := recordings[len(recordings)-1]
+		if len(args) > 1 {
+			found := false
+			for _, r := range recordings {
+				if r.Name == args[1] || r.Name == args[1]+".wav" {
+					recording, found = r, true
+					break
+				}
+			}
+			if !found {
+				terminal.Printf("❌ Recording not found: %s\n", args[1])
+				os.Exit(1)
+			}
+		}
+
+		terminal.Printf("▶️  Playing %s\n", recording.Name)
+		if err := exec.Command("afplay", recording.Path).Run(); err != nil {
+			terminal.Printf("❌ Error playing recording: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "purge":
+		removed, err := archive.Purge()
+		if err != nil {
+			terminal.Printf("❌ Error deleting recordings: %v\n", err)
+			os.Exit(1)
+		}
+		terminal.Printf("✅ Deleted %d recordings\n", removed)
+
+	default:
+		terminal.Printf("❌ Unknown audio command: %s (expected list, play, purge or devices)\n", args[0])
+		os.Exit(1)
+	}
+}
+
+// listInputDevices prints every input device with its channel count, marking the default
+func listInputDevices() {
+	if err := audio.Initialize(); err != nil {
+		terminal.Printf("❌ Error initializing PortAudio: %v\n", err)
+		os.Exit(1)
+	}
+	defer audio.Terminate()
+
+	devices, err := audio.InputDevices()
+	if err != nil {
+		terminal.Printf("❌ Error listing input devices: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, device := range devices {
+		marker := " "
+		if device.Default {
+			marker = "*"
+		}
+		terminal.Printf("%s %-40s %2d channels\n", marker, device.Name, device.Channels)
+	}
+	terminal.Println("💡 * is the default input; set input_channels to record another channel")
+}
+
+// openArchive returns the recordings archive with the configured limits
+func openArchive() *audio.Archive {
+	dir, err := config.GetRecordingsDir()
+	if err != nil {
+		terminal.Printf("❌ Error getting recordings directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+	return audio.NewArchive(dir, cfg.AudioRetentionDays, cfg.AudioMaxMB)
+}
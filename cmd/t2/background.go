@@ -0,0 +1,224 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/instance"
+	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/remote"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// statusReport is the `t2 status --json` payload
+type statusReport struct {
+	Running       bool   `json:"running"`
+	State         string `json:"state"` // See the state* constants
+	PID           int    `json:"pid,omitempty"`
+	LogPath       string `json:"log_path"`
+	WordsToday    int    `json:"words_today"`
+	SessionsToday int    `json:"sessions_today"`
+
+	Daemon *remote.Status `json:"daemon,omitempty"` // Only when remote control is on
+}
+
+// splitBoolFlag removes --name from args and reports whether it was present.
+// Used by subcommands that pass the remaining args through to the daemon.
+func splitBoolFlag(args []string, name string) ([]string, bool) {
+	var rest []string
+	found := false
+	for _, arg := range args {
+		if arg == "--"+name || arg == "-"+name {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, found
+}
+
+// splitStringFlag removes --name value / --name=value from args and returns the value
+func splitStringFlag(args []string, name string) ([]string, string) {
+	var rest []string
+	value := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--"+name || arg == "-"+name:
+			if i+1 < len(args) {
+				value = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(arg, "--"+name+"="):
+			value = strings.TrimPrefix(arg, "--"+name+"=")
+		case strings.HasPrefix(arg, "-"+name+"="):
+			value = strings.TrimPrefix(arg, "-"+name+"=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, value
+}
+
+// handleStartBackground re-executes t2 detached from the terminal with output
+// going to the log file, then waits briefly to confirm it took the lock
+func handleStartBackground(args []string) {
+	lockPath, err := config.GetLockPath()
+	if err != nil {
+		terminal.Printf("❌ Error getting lock file path: %v\n", err)
+		os.Exit(1)
+	}
+
+	if pid, running := instance.Running(lockPath); running {
+		terminal.Printf("❌ T2 is already running (PID %d)\n", pid)
+		os.Exit(1)
+	}
+
+	logPath, err := config.GetLogPath()
+	if err != nil {
+		terminal.Printf("❌ Error getting log file path: %v\n", err)
+		os.Exit(1)
+	}
+
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		terminal.Printf("❌ Error opening log file: %v\n", err)
+		os.Exit(1)
+	}
+	defer logFile.Close()
+
+	executable, err := os.Executable()
+	if err != nil {
+		terminal.Printf("❌ Error locating t2 executable: %v\n", err)
+		os.Exit(1)
+	}
+
+	cmd := exec.Command(executable, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		terminal.Printf("❌ Error starting daemon: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Give the daemon time to initialize and take the lock (or fail trying)
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case <-exited:
+			terminal.Printf("❌ Daemon exited during startup - see %s\n", logPath)
+			terminal.Println("💡 Run t2 in the foreground once to complete API key and permission setup")
+			os.Exit(1)
+		case <-deadline:
+			terminal.Printf("⚠️  Daemon (PID %d) hasn't finished starting yet - see %s\n", cmd.Process.Pid, logPath)
+			return
+		case <-time.After(100 * time.Millisecond):
+			if pid, running := instance.Running(lockPath); running {
+				terminal.Printf("🎤 T2 running in the background (PID %d)\n", pid)
+				terminal.Printf("📄 Logging to %s\n", logPath)
+				return
+			}
+		}
+	}
+}
+
+func handleStop() {
+	lockPath, err := config.GetLockPath()
+	if err != nil {
+		terminal.Printf("❌ Error getting lock file path: %v\n", err)
+		os.Exit(1)
+	}
+
+	pid, err := instance.Stop(lockPath, 5*time.Second)
+	if err != nil {
+		terminal.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	terminal.Printf("🛑 Stopped T2 (PID %d)\n", pid)
+}
+
+func handleStatus(jsonOutput bool) {
+	lockPath, err := config.GetLockPath()
+	if err != nil {
+		terminal.Printf("❌ Error getting lock file path: %v\n", err)
+		os.Exit(1)
+	}
+	logPath, _ := config.GetLogPath()
+
+	pid, running := instance.Running(lockPath)
+	report := statusReport{Running: running, State: stateStopped, PID: pid, LogPath: logPath}
+	if running {
+		if cfg, err := config.LoadConfig(); err == nil {
+			report.Daemon = daemonStatus(cfg)
+		}
+		report.State = daemonState(report.Daemon)
+	}
+	if today := todayMetrics(); today != nil {
+		report.WordsToday = today.TotalWords
+		report.SessionsToday = today.SessionCount
+	}
+
+	if jsonOutput {
+		printJSON(report)
+	} else if !running {
+		terminal.Println("💤 T2 is not running")
+	} else {
+		printStatus(report)
+	}
+
+	// Launchers tell a stopped daemon from a failure by the exit code
+	if !running {
+		os.Exit(exitNotRunning)
+	}
+}
+
+// printStatus shows what a running daemon is doing, and what went wrong last
+func printStatus(report statusReport) {
+	if report.State == stateRecording {
+		terminal.Printf("🔴 T2 is recording (PID %d)\n", report.PID)
+	} else {
+		terminal.Printf("🎤 T2 is running (PID %d)\n", report.PID)
+	}
+
+	if status := report.Daemon; status != nil {
+		uptime := time.Duration(status.UptimeSeconds * float64(time.Second)).Round(time.Second)
+		terminal.Printf("⏱️  Uptime: %v\n", uptime)
+		terminal.Printf("🔌 Connection: %s (%s)\n", status.Connection, status.Provider)
+		if status.LastError != "" {
+			terminal.Printf("⚠️  Last error: %s (%s)\n", status.LastError, status.LastErrorAt.Format("Jan 2 15:04"))
+		} else {
+			terminal.Println("✅ No errors since it started")
+		}
+	} else {
+		terminal.Println("💡 Set remote_listen_addr in your config to see its connection and last error here")
+	}
+
+	terminal.Printf("📊 Today: %d words in %d sessions\n", report.WordsToday, report.SessionsToday)
+	terminal.Printf("📄 Log file: %s\n", report.LogPath)
+}
+
+// todayMetrics returns today's statistics, nil if they can't be read
+func todayMetrics() *metrics.DailyMetrics {
+	metricsDir, err := config.GetMetricsDir()
+	if err != nil {
+		return nil
+	}
+	metricsManager, err := metrics.NewMetricsManager(metricsDir)
+	if err != nil {
+		return nil
+	}
+	today, err := metricsManager.GetTodayMetrics()
+	if err != nil {
+		return nil
+	}
+	return today
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/instance"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// Names inside a backup archive
+const (
+	backupConfig     = "config.json"
+	backupHistory    = "history.json"
+	backupMetricsDir = "metrics"
+)
+
+// isBackupPath reports whether a t2 export argument names a backup archive
+// rather than a session export
+func isBackupPath(arg string) bool {
+	return strings.HasSuffix(arg, ".tar.gz") || strings.HasSuffix(arg, ".tgz")
+}
+
+// handleBackupExport bundles config, settings, statistics and transcript history
+// of the active profile into a .tar.gz for moving to another Mac
+func handleBackupExport(outputPath string, args []string) {
+	backupFlags := flag.NewFlagSet("export", flag.ExitOnError)
+	noKey := backupFlags.Bool("no-key", false, "Leave the API keys and remote token out of the backup")
+	backupFlags.Parse(args)
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		terminal.Printf("❌ Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	if *noKey {
+		cfg.AssemblyAIKey = ""
+		cfg.RemoteToken = ""
+		cfg.LLMAPIKey = ""
+		cfg.RescueTimeKey = ""
+	}
+	configData, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		terminal.Printf("❌ Error encoding config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// The backup can hold the API key and dictated text
+	file, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		terminal.Printf("❌ Error creating backup: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	archive := tar.NewWriter(gz)
+
+	if err := addBackupFile(archive, backupConfig, configData); err != nil {
+		terminal.Printf("❌ Error writing backup: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsDir, err := config.GetMetricsDir()
+	if err != nil {
+		terminal.Printf("❌ Error getting metrics directory: %v\n", err)
+		os.Exit(1)
+	}
+	files, err := addBackupDir(archive, backupMetricsDir, metricsDir)
+	if err != nil {
+		terminal.Printf("❌ Error writing backup: %v\n", err)
+		os.Exit(1)
+	}
+
+	historyPath, err := config.GetHistoryPath()
+	if err != nil {
+		terminal.Printf("❌ Error getting history path: %v\n", err)
+		os.Exit(1)
+	}
+	if data, err := os.ReadFile(historyPath); err == nil {
+		// Encrypted history stays encrypted - bring its key along separately
+		if err := addBackupFile(archive, backupHistory, data); err != nil {
+			terminal.Printf("❌ Error writing backup: %v\n", err)
+			os.Exit(1)
+		}
+		files++
+	} else if !os.IsNotExist(err) {
+		terminal.Printf("⚠️  Warning: Skipping transcript history: %v\n", err)
+	}
+
+	if err := archive.Close(); err != nil {
+		terminal.Printf("❌ Error writing backup: %v\n", err)
+		os.Exit(1)
+	}
+	if err := gz.Close(); err != nil {
+		terminal.Printf("❌ Error writing backup: %v\n", err)
+		os.Exit(1)
+	}
+
+	terminal.Printf("✅ Backed up config and %d data files to %s\n", files, outputPath)
+	if *noKey {
+		terminal.Println("💡 The API key was left out - the Mac you import on keeps its own")
+	} else {
+		terminal.Println("💡 The backup contains your API key - keep it private, or use --no-key")
+	}
+}
+
+func addBackupFile(archive *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := archive.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := archive.Write(data)
+	return err
+}
+
+// addBackupDir adds every file under dir as prefix/<relative path> and returns how many
+func addBackupDir(archive *tar.Writer, prefix string, dir string) (int, error) {
+	files := 0
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		relative, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		files++
+		return addBackupFile(archive, path.Join(prefix, filepath.ToSlash(relative)), data)
+	})
+	return files, err
+}
+
+// handleImport restores a backup made with t2 export <file>.tar.gz into the
+// active profile, replacing files that exist in both
+func handleImport(args []string) {
+	if len(args) != 1 {
+		terminal.Println("Usage: t2 import backup.tar.gz")
+		os.Exit(1)
+	}
+
+	// A running daemon would overwrite the imported config, history and statistics
+	if lockPath, err := config.GetLockPath(); err == nil {
+		if pid, running := instance.Running(lockPath); running {
+			terminal.Printf("❌ T2 is running (PID %d)\n", pid)
+			terminal.Println("💡 Stop it with t2 stop before importing, then start it again")
+			os.Exit(1)
+		}
+	}
+
+	metricsDir, err := config.GetMetricsDir()
+	if err != nil {
+		terminal.Printf("❌ Error getting metrics directory: %v\n", err)
+		os.Exit(1)
+	}
+	historyPath, err := config.GetHistoryPath()
+	if err != nil {
+		terminal.Printf("❌ Error getting history path: %v\n", err)
+		os.Exit(1)
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		terminal.Printf("❌ Error opening backup: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		terminal.Printf("❌ Not a T2 backup: %v\n", err)
+		os.Exit(1)
+	}
+	archive := tar.NewReader(gz)
+
+	files := 0
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			terminal.Printf("❌ Error reading backup: %v\n", err)
+			os.Exit(1)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(archive)
+		if err != nil {
+			terminal.Printf("❌ Error reading backup: %v\n", err)
+			os.Exit(1)
+		}
+
+		name := path.Clean(header.Name)
+		switch {
+		case name == backupConfig:
+			err = importConfig(data)
+		case name == backupHistory:
+			err = writeImported(historyPath, data)
+		case strings.HasPrefix(name, backupMetricsDir+"/"):
+			relative := strings.TrimPrefix(name, backupMetricsDir+"/")
+			if !filepath.IsLocal(relative) {
+				terminal.Printf("⚠️  Warning: Skipping %s\n", header.Name)
+				continue
+			}
+			err = writeImported(filepath.Join(metricsDir, filepath.FromSlash(relative)), data)
+		default:
+			terminal.Printf("⚠️  Warning: Skipping %s\n", header.Name)
+			continue
+		}
+		if err != nil {
+			terminal.Printf("❌ Error importing %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		if name != backupConfig {
+			files++
+		}
+	}
+
+	terminal.Printf("✅ Imported config and %d data files from %s\n", files, args[0])
+}
+
+// importConfig saves a backed-up config, keeping this Mac's API keys and
+// remote token when the backup was made without them
+func importConfig(data []byte) error {
+	var imported config.Config
+	if err := json.Unmarshal(data, &imported); err != nil {
+		return fmt.Errorf("invalid config: %v", err)
+	}
+
+	if current, err := config.LoadConfig(); err == nil {
+		if imported.AssemblyAIKey == "" {
+			imported.AssemblyAIKey = current.AssemblyAIKey
+		}
+		if imported.RemoteToken == "" {
+			imported.RemoteToken = current.RemoteToken
+		}
+		if imported.LLMAPIKey == "" {
+			imported.LLMAPIKey = current.LLMAPIKey
+		}
+		if imported.RescueTimeKey == "" {
+			imported.RescueTimeKey = current.RescueTimeKey
+		}
+	}
+	return config.SaveConfig(&imported)
+}
+
+func writeImported(filePath string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0600)
+}
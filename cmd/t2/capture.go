@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/app"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// handleCapture transcribes system audio from a loopback device, and with
+// --mic the microphone, into a file
+func handleCapture(args []string) {
+	captureFlags := flag.NewFlagSet("capture", flag.ExitOnError)
+	device := captureFlags.String("device", "", "Input device to record, e.g. \"BlackHole 2ch\" (default: loopback_device)")
+	output := captureFlags.String("output", "", "File the transcript is appended to (default: capture-<time>.txt)")
+	withMic := captureFlags.Bool("mic", false, "Transcribe the microphone too, tagging turns \"You\" and \"Others\", for call notes")
+	captureFlags.Parse(args)
+
+	if captureFlags.NArg() > 0 {
+		terminal.Println("Usage: t2 capture [--mic] [--device name] [--output file]")
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		*output = "capture-" + time.Now().Format("2006-01-02-1504") + ".txt"
+	}
+
+	if err := app.Capture(*device, *output, *withMic); err != nil {
+		terminal.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/bezmoradi/t2/internal/app"
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// handleCompare transcribes the same audio with the current config and a
+// variant, for A/B testing provider settings
+func handleCompare(args []string) {
+	compareFlags := flag.NewFlagSet("compare", flag.ExitOnError)
+	variantPath := compareFlags.String("variant", "", "JSON file with the settings B overrides, e.g. {\"disable_punctuation\": true}")
+	compareFlags.Parse(args)
+
+	// The recording can come before the flags
+	path := compareFlags.Arg(0)
+	if path != "" {
+		compareFlags.Parse(compareFlags.Args()[1:])
+	}
+
+	if *variantPath == "" || compareFlags.NArg() > 0 {
+		terminal.Println("Usage: t2 compare [recording.wav] --variant variant.json")
+		os.Exit(1)
+	}
+
+	current, err := config.LoadConfig()
+	if err != nil {
+		terminal.Printf("❌ Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// B is the current config with the variant's settings on top
+	data, err := os.ReadFile(*variantPath)
+	if err != nil {
+		terminal.Printf("❌ Error reading variant: %v\n", err)
+		os.Exit(1)
+	}
+	variant, err := current.Clone()
+	if err != nil {
+		terminal.Printf("❌ Error copying config: %v\n", err)
+		os.Exit(1)
+	}
+	if err := json.Unmarshal(data, variant); err != nil {
+		terminal.Printf("❌ Invalid variant %s: %v\n", *variantPath, err)
+		os.Exit(1)
+	}
+
+	// Default to the most recent session saved with save_audio
+	if path == "" {
+		recordings, err := openArchive().List()
+		if err != nil {
+			terminal.Printf("❌ Error reading recordings: %v\n", err)
+			os.Exit(1)
+		}
+		if len(recordings) == 0 {
+			terminal.Println("❌ No saved recordings")
+			terminal.Println("💡 Pass a WAV file, or set \"save_audio\": true in your config to keep each session's audio")
+			os.Exit(1)
+		}
+		path = recordings[len(recordings)-1].Path
+	}
+
+	if err := app.Compare(path, current, variant); err != nil {
+		terminal.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// controlActions maps `t2 ctl` actions to the daemon's trigger endpoints
+var controlActions = map[string]struct {
+	method string
+	path   string
+}{
+	"start":   {http.MethodPost, "/start"},
+	"stop":    {http.MethodPost, "/stop"},
+	"toggle":  {http.MethodPost, "/toggle"},
+	"repaste": {http.MethodPost, "/repaste"},
+	"last":    {http.MethodGet, "/last-transcript"},
+}
+
+// handleControl drives a running daemon from scripts, Shortcuts and AppleScript.
+// Output is plain text so it can be piped straight into other actions.
+func handleControl(args []string) {
+	if len(args) != 1 {
+		terminal.Fprintln(os.Stderr, "Usage: t2 ctl start|stop|toggle|repaste|last|stats")
+		os.Exit(1)
+	}
+
+	// Stats don't need the daemon
+	if args[0] == "stats" {
+		handleShowStatsJSON()
+		return
+	}
+
+	action, ok := controlActions[args[0]]
+	if !ok {
+		terminal.Fprintf(os.Stderr, "❌ Unknown action: %s (expected start, stop, toggle, repaste, last or stats)\n", args[0])
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		terminal.Fprintf(os.Stderr, "❌ Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.RemoteListenAddr == "" || cfg.RemoteToken == "" {
+		terminal.Fprintln(os.Stderr, "❌ Remote control is disabled")
+		terminal.Fprintln(os.Stderr, "💡 Set remote_listen_addr (e.g. \"127.0.0.1:7766\") in your config and restart T2")
+		os.Exit(1)
+	}
+
+	body, err := sendControl(cfg, action.method, action.path)
+	if err != nil {
+		terminal.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+	// The daemon's reply is data, so it's printed untranslated and unstripped
+	fmt.Print(body)
+}
+
+// sendControl calls a daemon endpoint and returns the response body.
+// Stopping waits for transcription, so the timeout is generous.
+func sendControl(cfg *config.Config, method string, path string) (string, error) {
+	req, err := http.NewRequest(method, "http://"+localAddr(cfg.RemoteListenAddr)+path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+cfg.RemoteToken)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("T2 is not reachable: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s", strings.TrimSpace(string(body)))
+	}
+	return string(body), nil
+}
+
+// localAddr turns a listen address like ":7766" into one we can dial
+func localAddr(listenAddr string) string {
+	host, port, err := net.SplitHostPort(listenAddr)
+	if err != nil {
+		return listenAddr
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/instance"
+	"github.com/bezmoradi/t2/internal/terminal"
+	"github.com/bezmoradi/t2/internal/version"
+)
+
+const (
+	// pprofAddr only listens on localhost - profiles expose memory contents
+	pprofAddr = "127.0.0.1:6060"
+
+	// bundleLogBytes is how much of the end of the log goes into a bundle
+	bundleLogBytes = 1024 * 1024
+
+	// goroutineDumpWait is how long to wait for the daemon to write its dump
+	goroutineDumpWait = 3 * time.Second
+
+	redacted = "REDACTED"
+)
+
+// startPprof serves net/http/pprof on localhost for profiling a live daemon
+func startPprof() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	terminal.Printf("🔬 pprof listening on http://%s/debug/pprof/\n", pprofAddr)
+	go func() {
+		if err := http.ListenAndServe(pprofAddr, mux); err != nil {
+			terminal.Printf("⚠️  Warning: pprof disabled: %v\n", err)
+		}
+	}()
+}
+
+// handleDebug collects diagnostics for bug reports
+func handleDebug(args []string) {
+	if len(args) == 0 || args[0] != "bundle" {
+		terminal.Println("Usage: t2 debug bundle [--output file.zip]")
+		os.Exit(1)
+	}
+
+	bundleFlags := flag.NewFlagSet("debug bundle", flag.ExitOnError)
+	output := bundleFlags.String("output", "", "Output file (defaults to t2-debug-<time>.zip)")
+	bundleFlags.Parse(args[1:])
+
+	outputPath := *output
+	if outputPath == "" {
+		outputPath = "t2-debug-" + time.Now().Format("2006-01-02T15-04-05") + ".zip"
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		terminal.Printf("❌ Error creating bundle: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	bundle := zip.NewWriter(file)
+	addBundleFile(bundle, "system.txt", []byte(systemInfo()))
+
+	if data, err := redactedConfig(); err != nil {
+		terminal.Printf("⚠️  Warning: Skipping config: %v\n", err)
+	} else {
+		addBundleFile(bundle, "config.json", data)
+	}
+
+	if data, err := recentLog(); err != nil {
+		terminal.Printf("⚠️  Warning: Skipping log: %v\n", err)
+	} else {
+		addBundleFile(bundle, "t2.log", data)
+	}
+
+	if data, err := daemonGoroutines(); err != nil {
+		terminal.Printf("⚠️  Warning: Skipping goroutine dump: %v\n", err)
+	} else {
+		addBundleFile(bundle, "goroutines.txt", data)
+	}
+
+	if err := bundle.Close(); err != nil {
+		terminal.Printf("❌ Error writing bundle: %v\n", err)
+		os.Exit(1)
+	}
+
+	terminal.Printf("✅ Debug bundle written to %s\n", outputPath)
+	terminal.Println("💡 API keys and remote token are redacted, but check the log before sharing it publicly")
+}
+
+func addBundleFile(bundle *zip.Writer, name string, data []byte) {
+	w, err := bundle.Create(name)
+	if err == nil {
+		_, err = w.Write(data)
+	}
+	if err != nil {
+		terminal.Printf("⚠️  Warning: Failed to add %s: %v\n", name, err)
+	}
+}
+
+func systemInfo() string {
+	return fmt.Sprintf("version: %s\ngo: %s\nos: %s/%s\nprofile: %s\ntime: %s\n",
+		version.VERSION, runtime.Version(), runtime.GOOS, runtime.GOARCH,
+		config.GetProfile(), time.Now().Format(time.RFC3339))
+}
+
+// redactedConfig returns config.json with secrets replaced
+func redactedConfig() ([]byte, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	redactSecrets(cfg)
+	return json.MarshalIndent(cfg, "", "  ")
+}
+
+// redactSecrets replaces the API keys and remote token in cfg, for output
+// that may be shared or logged
+func redactSecrets(cfg *config.Config) {
+	for _, secret := range []*string{&cfg.AssemblyAIKey, &cfg.RemoteToken, &cfg.LLMAPIKey, &cfg.RescueTimeKey} {
+		if *secret != "" {
+			*secret = redacted
+		}
+	}
+}
+
+// recentLog returns the end of the background daemon's log
+func recentLog() ([]byte, error) {
+	logPath, err := config.GetLogPath()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(logPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > bundleLogBytes {
+		if _, err := file.Seek(-bundleLogBytes, io.SeekEnd); err != nil {
+			return nil, err
+		}
+	}
+	return io.ReadAll(file)
+}
+
+// daemonGoroutines asks the running daemon for a goroutine dump via SIGUSR1
+func daemonGoroutines() ([]byte, error) {
+	lockPath, err := config.GetLockPath()
+	if err != nil {
+		return nil, err
+	}
+	dumpPath, err := config.GetGoroutineDumpPath()
+	if err != nil {
+		return nil, err
+	}
+
+	pid, running := instance.Running(lockPath)
+	if !running {
+		return nil, fmt.Errorf("T2 is not running")
+	}
+
+	// Remove any old dump so only a fresh one is picked up
+	os.Remove(dumpPath)
+	if err := syscall.Kill(pid, syscall.SIGUSR1); err != nil {
+		return nil, fmt.Errorf("failed to signal PID %d: %v", pid, err)
+	}
+
+	for deadline := time.Now().Add(goroutineDumpWait); time.Now().Before(deadline); time.Sleep(100 * time.Millisecond) {
+		if info, err := os.Stat(dumpPath); err == nil && info.Size() > 0 {
+			// Give the daemon a moment to finish writing
+			time.Sleep(100 * time.Millisecond)
+			return os.ReadFile(dumpPath)
+		}
+	}
+	return nil, fmt.Errorf("no dump from PID %d - it may be hung", pid)
+}
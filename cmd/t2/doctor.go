@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// handleDoctor checks the statistics files, recovering damaged ones, and
+// reports what was lost to damaged files so far
+func handleDoctor() {
+	metricsDir, err := config.GetMetricsDir()
+	if err != nil {
+		terminal.Printf("❌ Error getting metrics directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsManager, err := metrics.NewMetricsManager(metricsDir)
+	if err != nil {
+		terminal.Printf("❌ Error initializing metrics: %v\n", err)
+		os.Exit(1)
+	}
+
+	terminal.Printf("🩺 Checking statistics in %s\n", metricsDir)
+	problems := metricsManager.CheckFiles()
+
+	recoveries, err := metricsManager.GetRecoveries()
+	if err != nil {
+		terminal.Printf("❌ Error reading recoveries: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(problems) == 0 && len(recoveries) == 0 {
+		terminal.Println("✅ No damaged statistics files")
+		return
+	}
+
+	for _, problem := range problems {
+		terminal.Printf("❌ Can't read %s\n", problem)
+	}
+
+	if len(recoveries) > 0 {
+		terminal.Printf("⚠️  %d damaged files were set aside:\n", len(recoveries))
+		for _, recovery := range recoveries {
+			terminal.Printf("   %s (%s): %s\n", recovery.File, recovery.Time.In(metrics.Location()).Format("Jan 2 2006 15:04"), recovery.Error)
+			terminal.Printf("      Recovered %d sessions and %d skips, %d bytes couldn't be read\n",
+				recovery.Sessions, recovery.Skips, recovery.LostBytes)
+			terminal.Printf("      Original kept as %s\n", recovery.Backup)
+		}
+		terminal.Println("💡 Sessions after the damage are lost; check the originals if you need them")
+	}
+
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/version"
+)
+
+type periodReport struct {
+	Period   string                  `json:"period"`
+	Current  []*metrics.DailyMetrics `json:"current"`
+	Previous []*metrics.DailyMetrics `json:"previous"`
+}
+
+type configReport struct {
+	Profile string         `json:"profile,omitempty"`
+	Path    string         `json:"path"`
+	Exists  bool           `json:"exists"`
+	Config  *config.Config `json:"config,omitempty"`
+}
+
+type versionReport struct {
+	Version string `json:"version"`
+}
+
+// printJSON writes v as indented JSON to stdout
+func printJSON(v any) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
+
+// exitJSON reports err as a JSON object so scripts never have to parse emoji
+func exitJSON(err error) {
+	printJSON(map[string]string{"error": err.Error()})
+	os.Exit(1)
+}
+
+func openMetricsManagerJSON() *metrics.MetricsManager {
+	metricsDir, err := config.GetMetricsDir()
+	if err != nil {
+		exitJSON(err)
+	}
+
+	metricsManager, err := metrics.NewMetricsManager(metricsDir)
+	if err != nil {
+		exitJSON(err)
+	}
+	return metricsManager
+}
+
+func handleShowVersionJSON() {
+	printJSON(versionReport{Version: version.VERSION})
+}
+
+func handleShowConfigJSON() {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		exitJSON(err)
+	}
+
+	report := configReport{Profile: config.GetProfile(), Path: configPath}
+	if _, err := os.Stat(configPath); err == nil {
+		report.Exists = true
+		report.Config, err = config.LoadConfig()
+		if err != nil {
+			exitJSON(err)
+		}
+		redactSecrets(report.Config)
+	}
+
+	printJSON(report)
+}
+
+func handleShowStatsJSON() {
+	metricsManager := openMetricsManagerJSON()
+
+	report, err := metricsManager.GetReport()
+	if err != nil {
+		exitJSON(err)
+	}
+	printJSON(report)
+}
+
+func handleShowPeriodStatsJSON(period string) {
+	metricsManager := openMetricsManagerJSON()
+
+	current, previous, err := metricsManager.GetPeriodMetrics(period)
+	if err != nil {
+		exitJSON(err)
+	}
+
+	printJSON(periodReport{
+		Period:   period,
+		Current:  current,
+		Previous: previous,
+	})
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/history"
+	"github.com/bezmoradi/t2/internal/instance"
+	"github.com/bezmoradi/t2/internal/remote"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// Exit codes of t2 status, toggle and last, so launcher extensions like
+// Raycast and Alfred can react without parsing output
+const (
+	exitError      = 1 // Anything else went wrong
+	exitNotRunning = 2 // The daemon isn't running
+	exitNoRemote   = 3 // remote_listen_addr isn't set, so the daemon can't be driven
+)
+
+// Daemon states reported by t2 status --json and t2 toggle --json
+const (
+	stateStopped   = "stopped"   // Not running
+	stateRunning   = "running"   // Running, but remote control is off so we can't tell more
+	stateIdle      = "idle"      // Waiting for the hotkey
+	stateRecording = "recording" // Recording a dictation
+)
+
+// toggleReport is the `t2 toggle --json` payload
+type toggleReport struct {
+	State string `json:"state"`
+}
+
+// lastReport is the `t2 last --json` payload; Text is empty if there's no transcript yet
+type lastReport struct {
+	Text string    `json:"text"`
+	Time time.Time `json:"time,omitzero"`
+}
+
+// daemonStatus asks a running daemon for its status over the remote control
+// endpoint; nil if remote control is off or the daemon doesn't answer
+func daemonStatus(cfg *config.Config) *remote.Status {
+	if cfg.RemoteListenAddr == "" || cfg.RemoteToken == "" {
+		return nil
+	}
+	body, err := sendControl(cfg, http.MethodGet, "/status")
+	if err != nil {
+		return nil
+	}
+
+	var status remote.Status
+	if err := json.Unmarshal([]byte(body), &status); err != nil {
+		return nil
+	}
+	return &status
+}
+
+// daemonState is the state* constant for a running daemon's status
+func daemonState(status *remote.Status) string {
+	switch {
+	case status == nil:
+		return stateRunning
+	case status.Recording:
+		return stateRecording
+	default:
+		return stateIdle
+	}
+}
+
+// handleToggle starts or stops recording in the running daemon, like
+// `t2 ctl toggle`, but with exit codes a launcher can tell apart
+func handleToggle(jsonOutput bool) {
+	fail := func(code int, message string, hint terminal.Message) {
+		if jsonOutput {
+			printJSON(map[string]string{"error": message})
+		} else {
+			fmt.Fprintln(os.Stderr, terminal.Text(message))
+			if hint != "" {
+				terminal.Fprintln(os.Stderr, hint)
+			}
+		}
+		os.Exit(code)
+	}
+
+	lockPath, err := config.GetLockPath()
+	if err != nil {
+		fail(exitError, "❌ Error getting lock file path: "+err.Error(), "")
+	}
+	if _, running := instance.Running(lockPath); !running {
+		fail(exitNotRunning, "💤 T2 is not running", "💡 Start it with: t2 start --background")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fail(exitError, "❌ Error loading config: "+err.Error(), "")
+	}
+	if cfg.RemoteListenAddr == "" || cfg.RemoteToken == "" {
+		fail(exitNoRemote, "❌ Remote control is disabled", "💡 Set remote_listen_addr (e.g. \"127.0.0.1:7766\") in your config and restart T2")
+	}
+
+	body, err := sendControl(cfg, http.MethodPost, "/toggle")
+	if err != nil {
+		fail(exitError, "❌ "+err.Error(), "")
+	}
+
+	state := stateIdle
+	if strings.TrimSpace(body) == "recording" {
+		state = stateRecording
+	}
+	if jsonOutput {
+		printJSON(toggleReport{State: state})
+		return
+	}
+	fmt.Println(state)
+}
+
+// handleLast prints the most recent transcript from the transcript history,
+// which works whether or not the daemon is running
+func handleLast(jsonOutput bool) {
+	fail := func(err error) {
+		if jsonOutput {
+			exitJSON(err)
+		}
+		terminal.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(exitError)
+	}
+
+	historyPath, err := config.GetHistoryPath()
+	if err != nil {
+		fail(err)
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fail(err)
+	}
+	key, err := history.LoadKey(cfg.HistoryIdentity)
+	if err != nil {
+		fail(err)
+	}
+	entries, err := history.Load(historyPath, key)
+	if err != nil {
+		fail(err)
+	}
+
+	var last lastReport
+	if len(entries) > 0 {
+		entry := entries[len(entries)-1]
+		last = lastReport{Text: entry.Text, Time: entry.Time}
+	}
+	if jsonOutput {
+		printJSON(last)
+		return
+	}
+	if last.Text == "" {
+		terminal.Fprintln(os.Stderr, "📭 No transcripts yet")
+		return
+	}
+	// The transcript goes out exactly as it was pasted, for piping
+	fmt.Print(last.Text)
+}
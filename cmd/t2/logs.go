@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/session"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// recentSessions is how many sessions "t2 logs" lists
+const recentSessions = 20
+
+// sessionSummary is one session as seen in the daemon log
+type sessionSummary struct {
+	id      string
+	started string // Timestamp of its first line
+	lines   int
+	outcome string // Its last [SESSION] event
+}
+
+// handleLogs lists the sessions in the daemon log, or prints one session's timeline
+func handleLogs(args []string) {
+	logsFlags := flag.NewFlagSet("logs", flag.ExitOnError)
+	sessionID := logsFlags.String("session", "", "Print every log line of the session with this ID (a unique prefix is enough)")
+	logsFlags.Parse(args)
+
+	logPath, err := config.GetLogPath()
+	if err != nil {
+		terminal.Printf("❌ Error getting log file path: %v\n", err)
+		os.Exit(1)
+	}
+
+	file, err := os.Open(logPath)
+	if os.IsNotExist(err) {
+		terminal.Printf("❌ No log file at %s\n", logPath)
+		terminal.Println("💡 Session logs are written there while T2 runs with t2 start --background")
+		os.Exit(1)
+	}
+	if err != nil {
+		terminal.Printf("❌ Error opening log file: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	if *sessionID == "" {
+		listSessions(file)
+	} else {
+		printSession(file, strings.ToLower(*sessionID))
+	}
+}
+
+// listSessions prints the most recent sessions with their outcome
+func listSessions(file *os.File) {
+	var sessions []*sessionSummary
+	byID := make(map[string]*sessionSummary)
+
+	scanner := newLogScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		id := session.FromLine(line)
+		if id == "" {
+			continue
+		}
+
+		summary, ok := byID[id]
+		if !ok {
+			summary = &sessionSummary{id: id, started: strings.TrimSpace(line[:strings.Index(line, "[session ")])}
+			byID[id] = summary
+			sessions = append(sessions, summary)
+		}
+		summary.lines++
+		if _, event, ok := strings.Cut(line, "[SESSION] "); ok && !strings.Contains(event, "SESSION COMPLETE") {
+			summary.outcome = event
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		terminal.Printf("❌ Error reading log file: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(sessions) == 0 {
+		terminal.Println("No sessions in the log yet")
+		return
+	}
+	if len(sessions) > recentSessions {
+		sessions = sessions[len(sessions)-recentSessions:]
+	}
+	for _, summary := range sessions {
+		terminal.Printf("%s  %s  %3d lines  %s\n", summary.started, summary.id, summary.lines, summary.outcome)
+	}
+	terminal.Println("💡 Run t2 logs --session <id> to see everything that happened in a session")
+}
+
+// printSession prints every log line tagged with the session matching prefix
+func printSession(file *os.File, prefix string) {
+	var lines []string
+	matched := ""
+
+	scanner := newLogScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		id := session.FromLine(line)
+		if id == "" || !strings.HasPrefix(id, prefix) {
+			continue
+		}
+		if matched != "" && id != matched {
+			terminal.Printf("❌ More than one session starts with %s - use more of the ID\n", prefix)
+			os.Exit(1)
+		}
+		matched = id
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		terminal.Printf("❌ Error reading log file: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(lines) == 0 {
+		terminal.Printf("❌ No log lines for session %s\n", prefix)
+		os.Exit(1)
+	}
+	for _, line := range lines {
+		terminal.Printf("%s\n", line)
+	}
+}
+
+// newLogScanner reads the log line by line, allowing for long lines like stack traces
+func newLogScanner(file *os.File) *bufio.Scanner {
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return scanner
+}
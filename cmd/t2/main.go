@@ -1,27 +1,139 @@
 package main
 
 import (
+	"context"
 	"flag"
-	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/bezmoradi/t2/internal/app"
 	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/i18n"
+	"github.com/bezmoradi/t2/internal/instance"
 	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/statsui"
+	"github.com/bezmoradi/t2/internal/terminal"
+	"github.com/bezmoradi/t2/internal/tracing"
 	"github.com/bezmoradi/t2/internal/version"
 )
 
 func main() {
-	isValid, newVersion := version.CheckVersion()
-	if !isValid {
-		fmt.Printf(`The newest version of T2 is %v but the installed version on your system is %v.
+	// Session timelines in the log need better than one-second resolution
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
+
+	// --profile applies to every command, so resolve it before dispatching
+	args, profile := splitStringFlag(os.Args[1:], "profile")
+	os.Args = append([]string{os.Args[0]}, args...)
+	if profile == "" {
+		profile = os.Getenv(config.ProfileEnvVar)
+	}
+	if err := config.SetProfile(profile); err != nil {
+		terminal.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	// Background daemons are re-executed and inherit the profile through the environment
+	os.Setenv(config.ProfileEnvVar, profile)
+
+	// --plain is global too; NO_COLOR carries it over to background daemons
+	args, plain := splitBoolFlag(os.Args[1:], "plain")
+	os.Args = append([]string{os.Args[0]}, args...)
+	if plain {
+		os.Setenv(terminal.NoColorEnvVar, "1")
+	}
+	terminal.SetPlain(terminal.PlainFromEnv())
 
-%v
+	// Pick the message catalog before anything else is printed
+	if cfg, err := config.LoadConfig(); err == nil {
+		if err := i18n.SetLocale(cfg.Locale); err != nil {
+			terminal.Printf("⚠️  Warning: Ignoring locale: %v\n", err)
+		}
+		if err := metrics.SetTimezone(cfg.Timezone); err != nil {
+			terminal.Printf("⚠️  Warning: Ignoring timezone: %v\n", err)
+		}
+	}
 
-To get the latest features and likely bugfixes, please install the latest version by running 'go install github.com/bezmoradi/t2/cmd/t2@%v'.`+"\n", newVersion, version.VERSION, version.UPDATE_MESSAGE, newVersion)
-		return
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			if len(os.Args) > 2 && isBackupPath(os.Args[2]) {
+				handleBackupExport(os.Args[2], os.Args[3:])
+				return
+			}
+			handleExport(os.Args[2:])
+			return
+		case "report":
+			handleReport(os.Args[2:])
+			return
+		case "import":
+			handleImport(os.Args[2:])
+			return
+		case "goal":
+			handleGoal(os.Args[2:])
+			return
+		case "debug":
+			handleDebug(os.Args[2:])
+			return
+		case "doctor":
+			handleDoctor()
+			return
+		case "audio":
+			handleAudio(os.Args[2:])
+			return
+		case "logs":
+			handleLogs(os.Args[2:])
+			return
+		case "pick":
+			handlePick()
+			return
+		case "purge":
+			handlePurge(os.Args[2:])
+			return
+		case "compare":
+			handleCompare(os.Args[2:])
+			return
+		case "capture":
+			handleCapture(os.Args[2:])
+			return
+		case "summarize":
+			handleSummarize(os.Args[2:])
+			return
+		case "ctl":
+			handleControl(os.Args[2:])
+			return
+		case "measure-typing-speed":
+			handleMeasureTypingSpeed()
+			return
+		case "stop":
+			handleStop()
+			return
+		case "status":
+			_, jsonOutput := splitBoolFlag(os.Args[2:], "json")
+			handleStatus(jsonOutput)
+			return
+		case "toggle":
+			_, jsonOutput := splitBoolFlag(os.Args[2:], "json")
+			handleToggle(jsonOutput)
+			return
+		case "last":
+			_, jsonOutput := splitBoolFlag(os.Args[2:], "json")
+			handleLast(jsonOutput)
+			return
+		case "stats":
+			// Same as --stats, so "t2 stats --interactive" reads naturally
+			os.Args = append([]string{os.Args[0], "--stats"}, os.Args[2:]...)
+		case "start":
+			args, background := splitBoolFlag(os.Args[2:], "background")
+			if background {
+				handleStartBackground(args)
+				return
+			}
+			// Foreground start is the same as running t2 directly
+			os.Args = append([]string{os.Args[0]}, args...)
+		}
 	}
 
 	var (
@@ -29,23 +141,54 @@ To get the latest features and likely bugfixes, please install the latest versio
 		showConfig     = flag.Bool("show-config", false, "Show current configuration location")
 		showVersion    = flag.Bool("version", false, "Show current version")
 		showStats      = flag.Bool("stats", false, "Show usage statistics and productivity metrics")
+		statsPeriod    = flag.String("period", "", "Show --stats for a period: month or year")
+		interactive    = flag.Bool("interactive", false, "Browse --stats in an interactive dashboard")
 		resetStats     = flag.Bool("reset-stats", false, "Clear all usage statistics")
 		setTypingSpeed = flag.String("set-typing-speed", "", "Set your typing speed in words per minute (e.g., --set-typing-speed=65)")
+		noUpdateCheck  = flag.Bool("no-update-check", false, "Skip checking for a newer version on startup")
+		_              = flag.String("profile", "", "Use a named profile with its own API key, settings and metrics (or set T2_PROFILE)")
+		_              = flag.Bool("plain", false, "Print without emoji or in-place updates, for logs, ssh and screen readers (or set NO_COLOR)")
+		takeover       = flag.Bool("takeover", false, "Stop an already running T2 daemon and take over")
+		jsonOutput     = flag.Bool("json", false, "Print --stats, --show-config and --version output as JSON")
+		listenAddr     = flag.String("listen", "", "Serve the HTTP control API on this address (e.g. 127.0.0.1:7766), overriding remote_listen_addr")
+		debugPprof     = flag.Bool("debug-pprof", false, "Serve net/http/pprof on 127.0.0.1:6060 for profiling")
+		otel           = flag.Bool("otel", false, "Send a trace of every session to a local OpenTelemetry collector (OTEL_EXPORTER_OTLP_ENDPOINT, default localhost:4318)")
+		simulate       = flag.String("simulate", "", "Transcribe a 16 kHz mono WAV file through the full pipeline and print the result instead of pasting")
 	)
 	flag.Parse()
 
 	if *showVersion {
-		handleShowVersion()
+		if *jsonOutput {
+			handleShowVersionJSON()
+		} else {
+			handleShowVersion()
+		}
 		return
 	}
 
 	if *showConfig {
-		handleShowConfig()
+		if *jsonOutput {
+			handleShowConfigJSON()
+		} else {
+			handleShowConfig()
+		}
 		return
 	}
 
 	if *showStats {
-		handleShowStats()
+		switch {
+		case *interactive && !terminal.Plain():
+			// The dashboard redraws the screen, so plain mode gets the text report instead
+			handleShowStatsInteractive()
+		case *jsonOutput && *statsPeriod != "":
+			handleShowPeriodStatsJSON(*statsPeriod)
+		case *jsonOutput:
+			handleShowStatsJSON()
+		case *statsPeriod != "":
+			handleShowPeriodStats(*statsPeriod)
+		default:
+			handleShowStats()
+		}
 		return
 	}
 
@@ -63,148 +206,444 @@ To get the latest features and likely bugfixes, please install the latest versio
 		handleResetKey()
 	}
 
+	// Simulation doesn't touch the mic or hotkeys, so it can run alongside the daemon
+	if *simulate != "" {
+		if err := app.NewDaemon().Simulate(*simulate); err != nil {
+			terminal.Printf("❌ Simulation failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	lock := acquireInstanceLock(*takeover)
+	defer lock.Release()
+
+	if !*noUpdateCheck {
+		go handleUpdateCheck()
+	}
+
+	if *debugPprof {
+		startPprof()
+	}
+
+	if *otel {
+		terminal.Printf("🔭 Sending session traces to %s\n", tracing.Enable())
+	}
+
+	// Ctrl+C cancels the daemon's context, which stops all of it
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	daemon := app.NewDaemon()
-	if err := daemon.Initialize(); err != nil {
+	daemon.SetListenAddr(*listenAddr)
+	if err := daemon.Initialize(ctx); err != nil {
+		lock.Release()
 		log.Fatalf("Failed to initialize daemon: %v", err)
 	}
-
-	if err := daemon.Run(); err != nil {
+	if err := daemon.Run(ctx); err != nil {
+		lock.Release()
 		log.Fatalf("Daemon error: %v", err)
 	}
 }
 
+// acquireInstanceLock makes sure only one daemon polls the hotkey and mic at a time
+func acquireInstanceLock(takeover bool) *instance.Lock {
+	lockPath, err := config.GetLockPath()
+	if err != nil {
+		terminal.Printf("❌ Error getting lock file path: %v\n", err)
+		os.Exit(1)
+	}
+
+	if takeover {
+		lock, err := instance.Takeover(lockPath, 5*time.Second)
+		if err != nil {
+			terminal.Printf("❌ Takeover failed: %v\n", err)
+			os.Exit(1)
+		}
+		return lock
+	}
+
+	lock, err := instance.Acquire(lockPath)
+	if err != nil {
+		if _, ok := err.(*instance.AlreadyRunningError); ok {
+			terminal.Printf("❌ %v\n", err)
+			terminal.Println("💡 Use --takeover to stop it and start here instead")
+		} else {
+			terminal.Printf("❌ Error acquiring lock: %v\n", err)
+		}
+		os.Exit(1)
+	}
+	return lock
+}
+
+// handleUpdateCheck prints a warning if a newer release exists. It runs in the
+// background so a slow or unreachable GitHub never delays startup.
+func handleUpdateCheck() {
+	cachePath, err := config.GetVersionCachePath()
+	if err != nil {
+		cachePath = ""
+	}
+
+	isValid, newVersion := version.CheckVersion(cachePath)
+	if isValid {
+		return
+	}
+
+	terminal.Printf(`⬆️  The newest version of T2 is %v but the installed version on your system is %v.
+
+%v
+
+To get the latest features and likely bugfixes, please install the latest version by running 'go install github.com/bezmoradi/t2/cmd/t2@%v'.`+"\n\n", newVersion, version.VERSION, version.UPDATE_MESSAGE, newVersion)
+}
+
 func handleShowConfig() {
 	configPath, err := config.GetConfigPath()
 	if err != nil {
-		fmt.Printf("❌ Error getting config path: %v\n", err)
+		terminal.Printf("❌ Error getting config path: %v\n", err)
 		os.Exit(1)
 	}
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		fmt.Println("📝 Config file does not exist yet")
+		terminal.Println("📝 Config file does not exist yet")
 	} else {
-		fmt.Printf("📁 Config file location: %s\n", configPath)
-		fmt.Println()
-		fmt.Println("📋 Config file contents:")
+		terminal.Printf("📁 Config file location: %s\n", configPath)
+		terminal.Println()
+		terminal.Println("📋 Config file contents:")
 
 		// Read and display the config file contents
 		content, err := os.ReadFile(configPath)
 		if err != nil {
-			fmt.Printf("❌ Error reading config file: %v\n", err)
+			terminal.Printf("❌ Error reading config file: %v\n", err)
 			return
 		}
 
 		// Pretty print the JSON content
-		fmt.Println(string(content))
+		terminal.Printf("%s\n", content)
 	}
 }
 
 func handleResetKey() {
 	configPath, _ := config.GetConfigPath()
 	if err := os.Remove(configPath); err != nil && !os.IsNotExist(err) {
-		fmt.Printf("⚠️  Warning: Failed to remove existing config: %v\n", err)
+		terminal.Printf("⚠️  Warning: Failed to remove existing config: %v\n", err)
 	}
-	fmt.Println("🔄 API key reset. You'll be prompted for a new one.")
+	terminal.Println("🔄 API key reset. You'll be prompted for a new one.")
 }
 
 func handleShowVersion() {
-	fmt.Printf("T2 (Talk to Text) %s\n", version.VERSION)
+	terminal.Printf("T2 (Talk to Text) %s\n", version.VERSION)
 }
 
 func handleShowStats() {
 	metricsDir, err := config.GetMetricsDir()
 	if err != nil {
-		fmt.Printf("❌ Error getting metrics directory: %v\n", err)
+		terminal.Printf("❌ Error getting metrics directory: %v\n", err)
 		os.Exit(1)
 	}
 
 	metricsManager, err := metrics.NewMetricsManager(metricsDir)
 	if err != nil {
-		fmt.Printf("❌ Error initializing metrics: %v\n", err)
+		terminal.Printf("❌ Error initializing metrics: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Get total metrics
 	totalMetrics, err := metricsManager.GetTotalMetrics()
 	if err != nil {
-		fmt.Printf("❌ Error getting total metrics: %v\n", err)
+		terminal.Printf("❌ Error getting total metrics: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Get recent metrics for context
 	recentDays, err := metricsManager.GetRecentDays(7)
 	if err != nil {
-		fmt.Printf("⚠️  Warning: Failed to get recent metrics: %v\n", err)
+		terminal.Printf("⚠️  Warning: Failed to get recent metrics: %v\n", err)
 	}
 
 	formatter := metrics.NewStatsFormatter()
 
 	// Display total stats
-	fmt.Println(formatter.FormatTotalStats(totalMetrics))
-	fmt.Println()
+	terminal.Printf("%s\n", terminal.Text(formatter.FormatTotalStats(totalMetrics)))
+	terminal.Println()
 
 	// Display weekly stats if available
 	if len(recentDays) > 0 {
-		fmt.Println(formatter.FormatWeeklyStats(recentDays))
-		fmt.Println()
+		terminal.Printf("%s\n", terminal.Text(formatter.FormatWeeklyStats(recentDays)))
+		terminal.Println()
+	}
+
+	// Display latency percentiles to tell network slowness from provider slowness
+	latencyStats, err := metricsManager.GetLatencyStats()
+	if err != nil {
+		terminal.Printf("⚠️  Warning: Failed to get latency metrics: %v\n", err)
+	} else if latencyStats.Sessions > 0 {
+		terminal.Printf("%s\n", terminal.Text(formatter.FormatLatencyStats(latencyStats)))
+		terminal.Println()
+	}
+
+	// Display why sessions were skipped, for tuning the quick press and silence thresholds
+	skipStats, err := metricsManager.GetSkipStats()
+	if err != nil {
+		terminal.Printf("⚠️  Warning: Failed to get skipped sessions: %v\n", err)
+	} else if skipStats.Skipped > 0 {
+		terminal.Printf("%s\n", terminal.Text(formatter.FormatSkipStats(skipStats)))
+		terminal.Println()
+	}
+
+	// Display API usage against the free tier
+	usage, err := metricsManager.GetMonthlyUsage()
+	if err != nil {
+		terminal.Printf("⚠️  Warning: Failed to get API usage: %v\n", err)
+	} else {
+		terminal.Printf("%s\n", terminal.Text(formatter.FormatUsage(usage)))
+		terminal.Println()
+	}
+
+	// Display goal progress and streak
+	streak, err := metricsManager.GetStreak()
+	if err != nil {
+		terminal.Printf("⚠️  Warning: Failed to get streak: %v\n", err)
+	}
+	todayMetrics, err := metricsManager.GetTodayMetrics()
+	if err != nil {
+		terminal.Printf("⚠️  Warning: Failed to get today's metrics: %v\n", err)
+	}
+	goalTarget, goalUnit := metricsManager.GetGoal()
+	if goalLine := formatter.FormatGoalProgress(goalTarget, goalUnit, todayMetrics, streak); goalLine != "" {
+		terminal.Printf("%s\n", terminal.Text(goalLine))
+		terminal.Println()
 	}
 
 	// Display typing speed setting
 	typingSpeed := metricsManager.GetTypingSpeed()
-	fmt.Printf("⌨️  Current typing speed setting: %d WPM\n", typingSpeed)
-	fmt.Println("💡 Use --set-typing-speed to update for more accurate time savings")
+	terminal.Printf("⌨️  Current typing speed setting: %d WPM\n", typingSpeed)
+	terminal.Println("💡 Use --set-typing-speed to update for more accurate time savings")
+}
+
+func handleShowStatsInteractive() {
+	metricsDir, err := config.GetMetricsDir()
+	if err != nil {
+		terminal.Printf("❌ Error getting metrics directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsManager, err := metrics.NewMetricsManager(metricsDir)
+	if err != nil {
+		terminal.Printf("❌ Error initializing metrics: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := statsui.Run(metricsManager); err != nil {
+		terminal.Printf("❌ Error running dashboard: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func handleShowPeriodStats(period string) {
+	metricsDir, err := config.GetMetricsDir()
+	if err != nil {
+		terminal.Printf("❌ Error getting metrics directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsManager, err := metrics.NewMetricsManager(metricsDir)
+	if err != nil {
+		terminal.Printf("❌ Error initializing metrics: %v\n", err)
+		os.Exit(1)
+	}
+
+	current, previous, err := metricsManager.GetPeriodMetrics(period)
+	if err != nil {
+		terminal.Printf("❌ Error getting %s metrics: %v\n", period, err)
+		os.Exit(1)
+	}
+
+	formatter := metrics.NewStatsFormatter()
+	terminal.Printf("%s\n", terminal.Text(formatter.FormatPeriodStats(period, current, previous)))
 }
 
 func handleResetStats() {
 	metricsDir, err := config.GetMetricsDir()
 	if err != nil {
-		fmt.Printf("❌ Error getting metrics directory: %v\n", err)
+		terminal.Printf("❌ Error getting metrics directory: %v\n", err)
 		os.Exit(1)
 	}
 
 	metricsManager, err := metrics.NewMetricsManager(metricsDir)
 	if err != nil {
-		fmt.Printf("❌ Error initializing metrics: %v\n", err)
+		terminal.Printf("❌ Error initializing metrics: %v\n", err)
 		os.Exit(1)
 	}
 
 	if err := metricsManager.ClearAllMetrics(); err != nil {
-		fmt.Printf("❌ Error clearing metrics: %v\n", err)
+		terminal.Printf("❌ Error clearing metrics: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Println("🗑️  All usage statistics have been cleared")
+	terminal.Println("🗑️  All usage statistics have been cleared")
 }
 
 func handleSetTypingSpeed(speedStr string) {
 	speed, err := strconv.Atoi(speedStr)
 	if err != nil {
-		fmt.Printf("❌ Invalid typing speed: %s (must be a number)\n", speedStr)
+		terminal.Printf("❌ Invalid typing speed: %s (must be a number)\n", speedStr)
 		os.Exit(1)
 	}
 
 	if speed < 10 || speed > 200 {
-		fmt.Printf("❌ Typing speed must be between 10 and 200 WPM (got %d)\n", speed)
+		terminal.Printf("❌ Typing speed must be between 10 and 200 WPM (got %d)\n", speed)
 		os.Exit(1)
 	}
 
 	metricsDir, err := config.GetMetricsDir()
 	if err != nil {
-		fmt.Printf("❌ Error getting metrics directory: %v\n", err)
+		terminal.Printf("❌ Error getting metrics directory: %v\n", err)
 		os.Exit(1)
 	}
 
 	metricsManager, err := metrics.NewMetricsManager(metricsDir)
 	if err != nil {
-		fmt.Printf("❌ Error initializing metrics: %v\n", err)
+		terminal.Printf("❌ Error initializing metrics: %v\n", err)
 		os.Exit(1)
 	}
 
 	if err := metricsManager.SetTypingSpeed(speed); err != nil {
-		fmt.Printf("❌ Error setting typing speed: %v\n", err)
+		terminal.Printf("❌ Error setting typing speed: %v\n", err)
+		os.Exit(1)
+	}
+
+	notifyDaemonReload()
+
+	terminal.Printf("✅ Typing speed updated to %d WPM\n", speed)
+	terminal.Println("💡 This will be used to calculate more accurate time savings in future sessions")
+}
+
+func handleExport(args []string) {
+	exportFlags := flag.NewFlagSet("export", flag.ExitOnError)
+	var (
+		all    = exportFlags.Bool("all", false, "Export the full history instead of the last 30 days")
+		format = exportFlags.String("format", metrics.FormatCSV, "Export format: csv or parquet")
+		output = exportFlags.String("output", "", "Output file (defaults to t2-export.<format>, use - for stdout)")
+	)
+	exportFlags.Parse(args)
+
+	if *format != metrics.FormatCSV && *format != metrics.FormatParquet {
+		terminal.Printf("❌ Invalid export format: %s (must be csv or parquet)\n", *format)
+		os.Exit(1)
+	}
+
+	metricsDir, err := config.GetMetricsDir()
+	if err != nil {
+		terminal.Printf("❌ Error getting metrics directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsManager, err := metrics.NewMetricsManager(metricsDir)
+	if err != nil {
+		terminal.Printf("❌ Error initializing metrics: %v\n", err)
+		os.Exit(1)
+	}
+
+	rows, err := metricsManager.ExportSessions(*all)
+	if err != nil {
+		terminal.Printf("❌ Error reading sessions: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("✅ Typing speed updated to %d WPM\n", speed)
-	fmt.Println("💡 This will be used to calculate more accurate time savings in future sessions")
+	outputPath := *output
+	if outputPath == "" {
+		outputPath = "t2-export." + *format
+	}
+
+	if outputPath == "-" {
+		if err := metrics.WriteExport(os.Stdout, *format, rows); err != nil {
+			terminal.Fprintf(os.Stderr, "❌ Error exporting sessions: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		terminal.Printf("❌ Error creating %s: %v\n", outputPath, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	if err := metrics.WriteExport(file, *format, rows); err != nil {
+		terminal.Printf("❌ Error exporting sessions: %v\n", err)
+		os.Exit(1)
+	}
+
+	terminal.Printf("📦 Exported %d sessions to %s\n", len(rows), outputPath)
+}
+
+func handleGoal(args []string) {
+	metricsDir, err := config.GetMetricsDir()
+	if err != nil {
+		terminal.Printf("❌ Error getting metrics directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsManager, err := metrics.NewMetricsManager(metricsDir)
+	if err != nil {
+		terminal.Printf("❌ Error initializing metrics: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(args) == 0 {
+		terminal.Println("Usage: t2 goal set <number> words|sessions")
+		terminal.Println("       t2 goal clear")
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "set":
+		if len(args) != 3 {
+			terminal.Println("❌ Usage: t2 goal set <number> words|sessions")
+			os.Exit(1)
+		}
+
+		target, err := strconv.Atoi(args[1])
+		if err != nil || target <= 0 {
+			terminal.Printf("❌ Invalid goal: %s (must be a positive number)\n", args[1])
+			os.Exit(1)
+		}
+
+		unit := args[2]
+		if unit != metrics.GoalWords && unit != metrics.GoalSessions {
+			terminal.Printf("❌ Invalid goal unit: %s (must be words or sessions)\n", unit)
+			os.Exit(1)
+		}
+
+		if err := metricsManager.SetGoal(target, unit); err != nil {
+			terminal.Printf("❌ Error setting goal: %v\n", err)
+			os.Exit(1)
+		}
+		notifyDaemonReload()
+		terminal.Printf("🎯 Daily goal set to %d %s\n", target, unit)
+
+	case "clear":
+		if err := metricsManager.ClearGoal(); err != nil {
+			terminal.Printf("❌ Error clearing goal: %v\n", err)
+			os.Exit(1)
+		}
+		notifyDaemonReload()
+		terminal.Println("🗑️  Daily goal cleared")
+
+	default:
+		terminal.Printf("❌ Unknown goal command: %s (expected set or clear)\n", args[0])
+		os.Exit(1)
+	}
+}
+
+// notifyDaemonReload asks a running daemon to pick up changed settings
+func notifyDaemonReload() {
+	lockPath, err := config.GetLockPath()
+	if err != nil {
+		return
+	}
+	if pid, running := instance.Running(lockPath); running && pid > 0 {
+		syscall.Kill(pid, syscall.SIGHUP)
+	}
 }
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/bezmoradi/t2/internal/clipboard"
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/history"
+	"github.com/bezmoradi/t2/internal/pickui"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// handlePick lets the user choose a recent transcript and copies it to the clipboard
+func handlePick() {
+	historyPath, err := config.GetHistoryPath()
+	if err != nil {
+		terminal.Printf("❌ Error getting history path: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		terminal.Printf("❌ Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	key, err := history.LoadKey(cfg.HistoryIdentity)
+	if err != nil {
+		terminal.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	entries, err := history.Load(historyPath, key)
+	if err != nil {
+		terminal.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if len(entries) == 0 {
+		terminal.Println("📭 No transcripts yet")
+		terminal.Println("💡 Transcripts are kept here after they're pasted, unless history_size is -1")
+		return
+	}
+
+	entry, picked, err := pickui.Run(entries)
+	if err != nil {
+		terminal.Printf("❌ Error running picker: %v\n", err)
+		os.Exit(1)
+	}
+	if !picked {
+		return
+	}
+
+	if err := clipboard.CopyText(entry.Text); err != nil {
+		terminal.Printf("❌ Failed to copy transcript: %v\n", err)
+		os.Exit(1)
+	}
+	terminal.Printf("📋 Copied %d words to the clipboard - press Cmd+V to paste\n", len(strings.Fields(entry.Text)))
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/app"
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/instance"
+	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// handlePurge deletes statistics, transcript history and saved audio from before a date
+func handlePurge(args []string) {
+	purgeFlags := flag.NewFlagSet("purge", flag.ExitOnError)
+	before := purgeFlags.String("before", "", "Delete everything from before this date (YYYY-MM-DD)")
+	purgeFlags.Parse(args)
+
+	if *before == "" {
+		terminal.Println("Usage: t2 purge --before YYYY-MM-DD")
+		os.Exit(1)
+	}
+	// Midnight in the configured timezone, where statistics days start
+	cutoff, err := time.ParseInLocation("2006-01-02", *before, metrics.Location())
+	if err != nil {
+		terminal.Printf("❌ Invalid date %q (expected YYYY-MM-DD)\n", *before)
+		os.Exit(1)
+	}
+
+	// A running daemon keeps the history in memory and would save purged transcripts again
+	if lockPath, err := config.GetLockPath(); err == nil {
+		if pid, running := instance.Running(lockPath); running {
+			terminal.Printf("❌ T2 is running (PID %d)\n", pid)
+			terminal.Println("💡 Stop it with t2 stop before purging, then start it again")
+			os.Exit(1)
+		}
+	}
+
+	purged, err := app.PurgeBefore(cutoff)
+	if err != nil {
+		terminal.Printf("❌ Error purging data: %v\n", err)
+		os.Exit(1)
+	}
+	terminal.Printf("✅ Deleted %d days of statistics, %d transcripts and %d recordings from before %s\n",
+		purged.Days, purged.Transcripts, purged.Recordings, cutoff.Format("Jan 2, 2006"))
+}
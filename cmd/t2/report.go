@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// handleReport writes a month of statistics with charts to an HTML or
+// Markdown file to share or archive
+func handleReport(args []string) {
+	reportFlags := flag.NewFlagSet("report", flag.ExitOnError)
+	var (
+		monthFlag = reportFlags.String("month", time.Now().In(metrics.Location()).Format("2006-01"), "Month to report on, e.g. 2024-06")
+		format    = reportFlags.String("format", metrics.FormatHTML, "Report format: html or markdown")
+		output    = reportFlags.String("output", "", "Output file (defaults to t2-report-<month>.html or .md, use - for stdout)")
+	)
+	reportFlags.Parse(args)
+
+	if *format != metrics.FormatHTML && *format != metrics.FormatMarkdown {
+		terminal.Printf("❌ Invalid report format: %s (must be html or markdown)\n", *format)
+		os.Exit(1)
+	}
+
+	month, err := time.ParseInLocation("2006-01", *monthFlag, metrics.Location())
+	if err != nil {
+		terminal.Printf("❌ Invalid month: %s (expected YYYY-MM, e.g. 2024-06)\n", *monthFlag)
+		os.Exit(1)
+	}
+
+	metricsDir, err := config.GetMetricsDir()
+	if err != nil {
+		terminal.Printf("❌ Error getting metrics directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsManager, err := metrics.NewMetricsManager(metricsDir)
+	if err != nil {
+		terminal.Printf("❌ Error initializing metrics: %v\n", err)
+		os.Exit(1)
+	}
+
+	days, err := metricsManager.GetMonthMetrics(month)
+	if err != nil {
+		terminal.Printf("❌ Error reading statistics: %v\n", err)
+		os.Exit(1)
+	}
+	if len(days) == 0 {
+		terminal.Printf("📭 No statistics for %s\n", month.Format("January 2006"))
+		os.Exit(1)
+	}
+
+	outputPath := *output
+	if outputPath == "" {
+		extension := "html"
+		if *format == metrics.FormatMarkdown {
+			extension = "md"
+		}
+		outputPath = fmt.Sprintf("t2-report-%s.%s", month.Format("2006-01"), extension)
+	}
+
+	if outputPath == "-" {
+		if err := metrics.WriteMonthReport(os.Stdout, *format, month, days); err != nil {
+			terminal.Fprintf(os.Stderr, "❌ Error writing report: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		terminal.Printf("❌ Error creating %s: %v\n", outputPath, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	if err := metrics.WriteMonthReport(file, *format, month, days); err != nil {
+		terminal.Printf("❌ Error writing report: %v\n", err)
+		os.Exit(1)
+	}
+
+	terminal.Printf("📄 Wrote the %s report to %s\n", month.Format("January 2006"), outputPath)
+}
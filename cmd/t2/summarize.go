@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/clipboard"
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/formatting"
+	"github.com/bezmoradi/t2/internal/history"
+	"github.com/bezmoradi/t2/internal/llm"
+	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// digestInstructions asks the model for the daily dictation digest
+const digestInstructions = `You write a short daily digest of someone's voice dictations.
+The input is every transcript they dictated on one day, each with the time it was pasted.
+A phrase in square brackets, like [insert signature], stands for stored boilerplate that was inserted; it's not part of what they wrote.
+Reply in Markdown with two sections:
+"## What I drafted" - one bullet per piece of writing (an email, a message, notes), saying what it was and who or what it was for.
+"## Key points" - the decisions, facts, commitments and to-dos worth remembering.
+Be brief, use the dictation's own language, and don't invent anything that isn't in the transcripts.`
+
+// handleSummarize sends a day's transcripts from the history to the configured
+// LLM and prints, saves or copies the digest
+func handleSummarize(args []string) {
+	summarizeFlags := flag.NewFlagSet("summarize", flag.ExitOnError)
+	var (
+		_      = summarizeFlags.Bool("today", false, "Summarize today's transcripts (the default)")
+		date   = summarizeFlags.String("date", "", "Summarize this day's transcripts instead (YYYY-MM-DD)")
+		output = summarizeFlags.String("output", "", "Save the digest to this file")
+		copyIt = summarizeFlags.Bool("copy", false, "Copy the digest to the clipboard")
+	)
+	summarizeFlags.Parse(args)
+
+	// Days are counted in the configured timezone, like the statistics
+	day := time.Now().In(metrics.Location())
+	if *date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", *date, metrics.Location())
+		if err != nil {
+			terminal.Printf("❌ Invalid date %q (expected YYYY-MM-DD)\n", *date)
+			os.Exit(1)
+		}
+		day = parsed
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		terminal.Printf("❌ Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	client, err := llm.NewClient(cfg.LLMProvider, cfg.LLMModel, cfg.LLMAPIKey)
+	if err != nil {
+		terminal.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	historyPath, err := config.GetHistoryPath()
+	if err != nil {
+		terminal.Printf("❌ Error getting history path: %v\n", err)
+		os.Exit(1)
+	}
+	key, err := history.LoadKey(cfg.HistoryIdentity)
+	if err != nil {
+		terminal.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	entries, err := history.Load(historyPath, key)
+	if err != nil {
+		terminal.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	transcripts := transcriptsOn(entries, day, cfg.Snippets)
+	if transcripts == "" {
+		terminal.Printf("📭 No transcripts on %s\n", day.Format("Jan 2"))
+		terminal.Println("💡 Only transcripts still in the history can be summarized - raise history_size to keep a whole day")
+		return
+	}
+
+	terminal.Println("🤖 Summarizing...")
+	digest, err := client.Complete(digestInstructions, transcripts)
+	if err != nil {
+		terminal.Printf("❌ Error summarizing: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch {
+	case *output != "":
+		if err := os.WriteFile(*output, []byte(digest+"\n"), 0600); err != nil {
+			terminal.Printf("❌ Error saving digest: %v\n", err)
+			os.Exit(1)
+		}
+		terminal.Printf("✅ Digest saved to %s\n", *output)
+	case *copyIt:
+		if err := clipboard.CopyText(digest); err != nil {
+			terminal.Printf("❌ Failed to copy digest: %v\n", err)
+			os.Exit(1)
+		}
+		terminal.Println("📋 Digest copied to the clipboard - press Cmd+V to paste")
+	default:
+		fmt.Println(digest)
+	}
+}
+
+// transcriptsOn lists the entries pasted on day, one per line with their
+// time, with snippet expansions collapsed to their phrase
+func transcriptsOn(entries []history.Entry, day time.Time, snippets map[string]string) string {
+	var b strings.Builder
+	year, month, date := day.Date()
+	for _, entry := range entries {
+		pasted := entry.Time.In(metrics.Location())
+		if y, m, d := pasted.Date(); y != year || m != month || d != date {
+			continue
+		}
+		fmt.Fprintf(&b, "[%s] %s\n", pasted.Format("15:04"), formatting.CollapseSnippets(entry.Text, snippets))
+	}
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+var typingPassages = []string{
+	"The quick brown fox jumps over the lazy dog while the farmer watches from the porch and sips his morning coffee.",
+	"Good software is written twice, once to understand the problem and once more to solve it in a way other people can read.",
+	"When the meeting ran long we moved the review to Friday, so please send your notes before lunch on Thursday.",
+	"Voice dictation works best when you speak in full sentences and pause briefly before the next thought begins.",
+}
+
+// typingWPM scores typed text against the passage using the standard five
+// characters per word, subtracting one word for every mistyped word
+func typingWPM(passage string, typed string, elapsed time.Duration) int {
+	if elapsed <= 0 {
+		return 0
+	}
+
+	expectedWords := strings.Fields(passage)
+	typedWords := strings.Fields(typed)
+
+	errors := 0
+	for i, word := range typedWords {
+		if i >= len(expectedWords) || word != expectedWords[i] {
+			errors++
+		}
+	}
+
+	grossWords := float64(len(typed)) / 5
+	netWords := grossWords - float64(errors)
+	if netWords < 0 {
+		return 0
+	}
+	return int(netWords / elapsed.Minutes())
+}
+
+func handleMeasureTypingSpeed() {
+	passage := typingPassages[rand.Intn(len(typingPassages))]
+	scanner := bufio.NewScanner(os.Stdin)
+
+	terminal.Println("⌨️  Typing speed test")
+	terminal.Println("📋 Type the following text as quickly and accurately as you can, then press Enter:")
+	terminal.Println()
+	terminal.Printf("   %s\n", passage)
+	terminal.Println()
+	terminal.Print("⏎  Press Enter when you're ready to start... ")
+	if !scanner.Scan() {
+		terminal.Println("❌ Failed to read input")
+		os.Exit(1)
+	}
+
+	terminal.Print("🏁 Go: ")
+	start := time.Now()
+	if !scanner.Scan() {
+		terminal.Println("❌ Failed to read input")
+		os.Exit(1)
+	}
+	elapsed := time.Since(start)
+
+	speed := typingWPM(passage, strings.TrimSpace(scanner.Text()), elapsed)
+	if speed < 10 || speed > 200 {
+		terminal.Printf("❌ Measured %d WPM, which is outside the 10-200 WPM range - please try again\n", speed)
+		os.Exit(1)
+	}
+
+	metricsDir, err := config.GetMetricsDir()
+	if err != nil {
+		terminal.Printf("❌ Error getting metrics directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsManager, err := metrics.NewMetricsManager(metricsDir)
+	if err != nil {
+		terminal.Printf("❌ Error initializing metrics: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := metricsManager.SetTypingSpeed(speed); err != nil {
+		terminal.Printf("❌ Error setting typing speed: %v\n", err)
+		os.Exit(1)
+	}
+
+	notifyDaemonReload()
+
+	terminal.Println()
+	terminal.Printf("✅ You typed %d WPM in %s - typing speed updated\n", speed, metrics.NewTimeFormatter().FormatDurationShort(elapsed))
+	terminal.Println("💡 This will be used to calculate more accurate time savings in future sessions")
+}
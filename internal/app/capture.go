@@ -0,0 +1,159 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/audio"
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// defaultLoopbackDevice is the virtual device most people route system audio through
+const defaultLoopbackDevice = "BlackHole"
+
+// captureSource is one input t2 capture transcribes, on its own connection
+type captureSource struct {
+	label  string // Tags the source's turns when there's more than one
+	device string // Input device name, "" for the default input
+	daemon *Daemon
+}
+
+// Capture transcribes system audio - a call or video you're listening to -
+// from a loopback device until interrupted, appending each finished turn to
+// output instead of pasting it. An empty device uses loopback_device. With
+// withMic the microphone is transcribed too, and every turn is tagged with
+// the side of the call it came from.
+func Capture(device, output string, withMic bool) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+	if device == "" {
+		device = cfg.LoopbackDevice
+	}
+	if device == "" {
+		device = defaultLoopbackDevice
+	}
+
+	sources := []*captureSource{{label: "Others", device: device}}
+	if withMic {
+		sources = append(sources, &captureSource{label: "You"})
+	}
+
+	file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", output, err)
+	}
+	defer file.Close()
+
+	if err := audio.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize PortAudio: %v", err)
+	}
+	defer audio.Terminate()
+
+	// Turns arrive on each source's connection while we wait for Ctrl+C
+	var (
+		turns      int
+		writeErr   error
+		turnsMutex sync.Mutex
+	)
+	writeTurn := func(source *captureSource, text string) {
+		turnsMutex.Lock()
+		defer turnsMutex.Unlock()
+		if len(sources) > 1 {
+			text = source.label + ": " + text
+		}
+		if _, err := fmt.Fprintf(file, "[%s] %s\n", time.Now().Format("15:04:05"), text); err != nil && writeErr == nil {
+			writeErr = err
+			terminal.Printf("⚠️  Warning: Failed to write to %s: %v\n", output, err)
+		}
+		turns++
+		terminal.Printf("📝 %s\n", text)
+	}
+
+	for _, source := range sources {
+		source.daemon = NewDaemonWith(Dependencies{Config: cfg})
+		err := source.daemon.startCapture(source.device, func(text string) {
+			writeTurn(source, text)
+		})
+		if err != nil {
+			stopCapture(sources)
+			return err
+		}
+	}
+
+	terminal.Printf("🎧 Transcribing audio from %s into %s\n", device, output)
+	if withMic {
+		terminal.Println("🎙️  Transcribing your microphone too; wear headphones so it doesn't pick up the other side")
+	}
+	terminal.Println("💡 Press Ctrl+C to stop")
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+	<-stop
+
+	stopCapture(sources)
+
+	turnsMutex.Lock()
+	defer turnsMutex.Unlock()
+	terminal.Println()
+	terminal.Printf("✅ Saved %d turns to %s\n", turns, output)
+	return writeErr
+}
+
+// startCapture connects and starts recording from device, passing every
+// finished turn to onTurn
+func (d *Daemon) startCapture(device string, onTurn func(string)) error {
+	if _, err := d.initPipeline(); err != nil {
+		return err
+	}
+	d.turnListener = onTurn
+
+	if err := d.transcriptClient.Connect(d.runCtx, d.apiKey); err != nil {
+		return fmt.Errorf("failed to connect to AssemblyAI streaming API: %v", err)
+	}
+
+	d.startProcessor()
+	d.transcriptClient.ResetSessionStats()
+	d.recorder.SetInputDevice(device)
+	if err := d.recorder.Start(d.runCtx); err != nil {
+		d.transcriptClient.Close()
+		if device == "" {
+			return fmt.Errorf("failed to record from the microphone: %v", err)
+		}
+		return fmt.Errorf("failed to record from %q: %v", device, err)
+	}
+	return nil
+}
+
+// stopCapture stops every started source, waiting for the provider to
+// finish the turn that was still being spoken
+func stopCapture(sources []*captureSource) {
+	var wg sync.WaitGroup
+	for _, source := range sources {
+		d := source.daemon
+		if d == nil || d.recorder == nil || !d.recorder.IsRecording() {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.recorder.Stop()
+			d.transcriptClient.Terminate()
+			select {
+			case <-d.currentProcessor().WaitForTermination():
+			case <-time.After(simulateTimeout):
+				terminal.Println("⚠️  Warning: No termination from AssemblyAI, the last turn may be missing")
+			}
+			d.transcriptClient.Close()
+		}()
+	}
+	wg.Wait()
+}
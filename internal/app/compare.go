@@ -0,0 +1,129 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/audio"
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// Compare streams the same WAV file through two pipelines at once, one with
+// the current config (A) and one with variant (B), then prints and logs both
+// transcripts and a word diff. It's for trying provider settings on your own
+// voice before switching to them; every run uses twice the audio quota.
+func Compare(path string, current *config.Config, variant *config.Config) error {
+	pcm, err := audio.ReadWAV(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	terminal.Printf("🎧 Streaming %.1fs of audio from %s to A and B\n", audio.PCMDuration(int64(len(pcm))), path)
+
+	configs := []*config.Config{current, variant}
+	results := make([]*simulation, len(configs))
+	errs := make([]error, len(configs))
+	var wg sync.WaitGroup
+	for i, cfg := range configs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = NewDaemonWith(Dependencies{Config: cfg}).transcribePCM(pcm)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("%s failed: %v", variantName(i), err)
+		}
+	}
+
+	log.Printf("[AB] Compared %s", path)
+	for i, result := range results {
+		terminal.Printf("%s: %q\n", variantName(i), result.text)
+		terminal.Printf("   Confidence %.2f, latency after end of audio %v\n", result.confidence, result.latency.Round(time.Millisecond))
+		if !result.isFinal {
+			terminal.Println("   ⚠️  No final transcript, showing the best partial one")
+		}
+		log.Printf("[AB] %s (confidence %.2f, latency %v): %q", variantName(i), result.confidence, result.latency.Round(time.Millisecond), result.text)
+	}
+
+	diff := wordDiff(results[0].text, results[1].text)
+	if diff == "" {
+		terminal.Println("✅ A and B produced the same transcript")
+		log.Printf("[AB] Same transcript")
+		return nil
+	}
+	terminal.Printf("🔀 Diff ([-A only-] {+B only+}): %s\n", diff)
+	log.Printf("[AB] Diff: %s", diff)
+	return nil
+}
+
+func variantName(i int) string {
+	if i == 0 {
+		return "A (current config)"
+	}
+	return "B (variant)"
+}
+
+// wordDiff marks the words that differ between a and b, "[-a only-] {+b only+}",
+// and returns "" if they're the same
+func wordDiff(a string, b string) string {
+	wordsA, wordsB := strings.Fields(a), strings.Fields(b)
+
+	// Longest common subsequence of words, built from the end
+	lcs := make([][]int, len(wordsA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(wordsB)+1)
+	}
+	for i := len(wordsA) - 1; i >= 0; i-- {
+		for j := len(wordsB) - 1; j >= 0; j-- {
+			if wordsA[i] == wordsB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out, removed, added []string
+	changed := false
+	flush := func() {
+		if len(removed) > 0 {
+			out = append(out, "[-"+strings.Join(removed, " ")+"-]")
+		}
+		if len(added) > 0 {
+			out = append(out, "{+"+strings.Join(added, " ")+"+}")
+		}
+		changed = changed || len(removed) > 0 || len(added) > 0
+		removed, added = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < len(wordsA) || j < len(wordsB) {
+		switch {
+		case i < len(wordsA) && j < len(wordsB) && wordsA[i] == wordsB[j]:
+			flush()
+			out = append(out, wordsA[i])
+			i++
+			j++
+		case j < len(wordsB) && (i == len(wordsA) || lcs[i][j+1] >= lcs[i+1][j]):
+			added = append(added, wordsB[j])
+			j++
+		default:
+			removed = append(removed, wordsA[i])
+			i++
+		}
+	}
+	flush()
+
+	if !changed {
+		return ""
+	}
+	return strings.Join(out, " ")
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/hotkeys"
+	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/terminal"
+	"github.com/bezmoradi/t2/internal/transcription"
+)
+
+// addToDraft appends a dictation to the compose draft and shows the draft so far
+func (d *Daemon) addToDraft(text string, recordingDuration time.Duration) {
+	d.draftMutex.Lock()
+	defer d.draftMutex.Unlock()
+	d.draft = append(d.draft, text)
+	d.draftDuration += recordingDuration
+
+	d.printDraft()
+	terminal.Printf("💡 Press %s to paste it\n", hotkeys.CommitDisplay)
+}
+
+// undoDraft drops the most recent dictation from the draft
+func (d *Daemon) undoDraft() {
+	d.draftMutex.Lock()
+	defer d.draftMutex.Unlock()
+	if len(d.draft) == 0 {
+		return
+	}
+	d.draft = d.draft[:len(d.draft)-1]
+
+	if len(d.draft) == 0 {
+		d.clearDraft()
+		terminal.Println("📝 Draft is empty")
+		return
+	}
+	d.printDraft()
+}
+
+// clearDraft empties the draft. Called with draftMutex held.
+func (d *Daemon) clearDraft() {
+	d.draft = nil
+	d.draftDuration = 0
+}
+
+// printDraft shows the draft so far. Called with draftMutex held.
+func (d *Daemon) printDraft() {
+	draft := strings.TrimRightFunc(strings.Join(d.draft, ""), func(r rune) bool { return r == ' ' || r == '\n' })
+	terminal.Printf("📝 Draft (%d parts, %d words):\n", len(d.draft), len(strings.Fields(draft)))
+	for _, line := range strings.Split(draft, "\n") {
+		terminal.Printf("   %s\n", line)
+	}
+}
+
+// OnCommit implements hotkeys.CommitHandler
+func (d *Daemon) OnCommit() {
+	d.commitDraft(false)
+	terminal.Println()
+}
+
+// commitDraft pastes the whole draft as one session. The draft is kept if
+// pasting fails, so it can be committed again.
+func (d *Daemon) commitDraft(submit bool) {
+	d.draftMutex.Lock()
+	defer d.draftMutex.Unlock()
+	if len(d.draft) == 0 {
+		terminal.Println("📝 Draft is empty - nothing to paste")
+		return
+	}
+
+	if bundleID := d.protectedApp(); bundleID != "" {
+		if d.neverPasteAction() == config.NeverPasteHold {
+			terminal.Printf("🚫 Not pasting into %s - switch to another app and commit the draft there\n", bundleID)
+		} else if d.copyInstead(strings.Join(d.draft, "")) {
+			terminal.Printf("📋 Not pasting into %s - copied the draft to the clipboard instead\n", bundleID)
+			d.clearDraft()
+		}
+		return
+	}
+
+	if d.clipboardOnly() {
+		copied := d.copyTranscript(strings.Join(d.draft, ""), d.draftDuration, metrics.SessionDetails{
+			App:      d.clipboard.FrontmostApp(),
+			Provider: transcription.ProviderName,
+		})
+		if copied {
+			d.clearDraft()
+		}
+		return
+	}
+
+	text := d.joinWithField(strings.Join(d.draft, ""))
+	if err := d.pasteText(text); err != nil {
+		terminal.Printf("❌ Paste failed: %v\n", err)
+		d.noteError("Paste failed: " + err.Error())
+		d.beeper.PlayBeep("error")
+		return
+	}
+	recordingDuration := d.draftDuration
+	d.clearDraft()
+	d.setLastTranscript(text)
+
+	app := d.clipboard.FrontmostApp()
+	d.pressReturnIfWanted(app, submit)
+
+	d.displaySessionMetrics(text, recordingDuration, metrics.SessionDetails{
+		App:      app,
+		Provider: transcription.ProviderName,
+	})
+}
+
+// dropDraft shows and discards the draft when compose mode is turned off
+func (d *Daemon) dropDraft() {
+	d.draftMutex.Lock()
+	defer d.draftMutex.Unlock()
+	if len(d.draft) == 0 {
+		return
+	}
+	terminal.Println("📝 Compose mode turned off - your draft wasn't pasted:")
+	d.printDraft()
+	d.clearDraft()
+}
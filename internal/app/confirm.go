@@ -0,0 +1,100 @@
+package app
+
+import (
+	"time"
+
+	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/terminal"
+	"github.com/bezmoradi/t2/internal/transcription"
+)
+
+// pendingTranscript is a low-confidence transcript held back from pasting
+type pendingTranscript struct {
+	text              string
+	confidence        float64
+	language          string
+	recordingDuration time.Duration
+	sessionID         string
+}
+
+// holdForConfirmation shows a low-confidence transcript in the terminal instead
+// of pasting it, so garbage never lands in the active app
+func (d *Daemon) holdForConfirmation(text string, confidence float64, language string, sessionID string) {
+	d.pending = &pendingTranscript{
+		text:              text,
+		confidence:        confidence,
+		language:          language,
+		recordingDuration: d.spoken,
+		sessionID:         sessionID,
+	}
+
+	d.beeper.PlayBeep("warning")
+	terminal.Printf("🤔 Low confidence (%.0f%%) - not pasted:\n", confidence*100)
+	terminal.Printf("   %q\n", text)
+	terminal.Println("💡 Quick-press the hotkey to paste it anyway")
+}
+
+// pastePending pastes the held transcript, if any, and reports whether it did
+func (d *Daemon) pastePending(submit bool) bool {
+	pending := d.pending
+	if pending == nil {
+		return false
+	}
+	d.pending = nil
+
+	if d.composeMode() {
+		d.addToDraft(pending.text, pending.recordingDuration)
+		if submit {
+			d.commitDraft(true)
+		}
+		return true
+	}
+
+	if bundleID := d.protectedApp(); bundleID != "" {
+		d.pending = pending
+		terminal.Printf("🚫 Still in %s - switch to another app to paste the held transcript\n", bundleID)
+		return true
+	}
+
+	if d.clipboardOnly() {
+		d.copyTranscript(pending.text, pending.recordingDuration, metrics.SessionDetails{
+			App:        d.clipboard.FrontmostApp(),
+			Provider:   transcription.ProviderName,
+			Confidence: pending.confidence,
+			Language:   pending.language,
+			SessionID:  pending.sessionID,
+		})
+		return true
+	}
+
+	text := d.joinWithField(pending.text)
+	if err := d.pasteText(text); err != nil {
+		terminal.Printf("❌ Paste failed: %v\n", err)
+		d.noteError("Paste failed: " + err.Error())
+		d.beeper.PlayBeep("error")
+		return true
+	}
+	d.setLastTranscript(text)
+
+	app := d.clipboard.FrontmostApp()
+	d.pressReturnIfWanted(app, submit)
+
+	// No timings - the wait for confirmation would skew latency stats
+	d.displaySessionMetrics(text, pending.recordingDuration, metrics.SessionDetails{
+		App:        app,
+		Provider:   transcription.ProviderName,
+		Confidence: pending.confidence,
+		Language:   pending.language,
+		SessionID:  pending.sessionID,
+	})
+	return true
+}
+
+// pressReturnIfWanted submits chat messages and terminal commands right away when asked to
+func (d *Daemon) pressReturnIfWanted(app string, submit bool) {
+	if submit || d.autoEnterFor(app) {
+		if err := d.clipboard.PressReturn(); err != nil {
+			terminal.Printf("⚠️  Warning: %v\n", err)
+		}
+	}
+}
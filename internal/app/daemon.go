@@ -1,77 +1,151 @@
 package app
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/bezmoradi/t2/internal/audio"
-	"github.com/bezmoradi/t2/internal/clipboard"
 	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/events"
+	"github.com/bezmoradi/t2/internal/formatting"
+	"github.com/bezmoradi/t2/internal/history"
 	"github.com/bezmoradi/t2/internal/hotkeys"
 	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/permissions"
+	"github.com/bezmoradi/t2/internal/remote"
+	"github.com/bezmoradi/t2/internal/supervisor"
 	"github.com/bezmoradi/t2/internal/terminal"
+	"github.com/bezmoradi/t2/internal/tracing"
 	"github.com/bezmoradi/t2/internal/transcription"
 )
 
 type Daemon struct {
-	config             *config.Config
-	recorder           *audio.Recorder
-	transcriptClient   *transcription.Client
-	processor          *transcription.Processor
-	hotkeyManager      *hotkeys.Manager
-	metricsManager     *metrics.MetricsManager
-	terminalControl    *terminal.Control
-	apiKey             string
-	currentTurnOrder   int
-	sessionStartTime   time.Time
-	isFirstSession     bool
-	pressTime          time.Time
+	config              *config.Config
+	recorder            Recorder
+	transcriptClient    Provider
+	processor           *transcription.Processor // Collects the transcripts of the latest recording
+	endingProcessor     *transcription.Processor // Recording whose persistent turn was ended but not finalized yet
+	processorMutex      sync.Mutex               // Guards processor and endingProcessor
+	hotkeyManager       Hotkeys
+	clipboard           Clipboard
+	beeper              Beeper
+	notifier            Notifier
+	deps                Dependencies
+	remoteServer        *remote.Server
+	monitor             *audio.Monitor
+	metricsManager      *metrics.MetricsManager
+	counters            *metrics.Counters
+	terminalControl     *terminal.Control
+	apiKey              string
+	sessionStartTime    time.Time
+	sessionID           string     // Tags the current session's log lines, see timeline.go
+	sessionIDMutex      sync.Mutex // Guards sessionID
+	isFirstSession      bool
+	pressTime           time.Time
+	releaseTime         time.Time
 	quickPressThreshold time.Duration
+	quickPressMode      string
+	pending             *pendingTranscript // Low-confidence transcript waiting for confirmation
+	wakeSession         bool               // Current session was started by the wake-word monitor, guarded by sessionMutex
+	lastTranscript      string             // Most recent paste, for repasting
+	draft               []string           // Dictations collected in compose mode
+	draftDuration       time.Duration      // Total recording time of the draft
+	draftMutex          sync.Mutex         // Guards draft and draftDuration
+	lastMutex           sync.Mutex         // Guards lastTranscript
+	history             *history.History   // Recent transcripts for t2 pick
+	events              *events.Sink       // Session events for events_url and events_script
+	trace               *tracing.Trace     // The current session's trace with --otel, nil otherwise
+	limitWarning        *time.Timer        // Warns before the max session length
+	limitStop           *time.Timer        // Stops the session at the max session length
+	limitMutex          sync.Mutex         // Guards the session limit timers
+	live                *liveTyper         // Types the current session as it is spoken, with live_typing
+	liveMutex           sync.Mutex         // Guards live
+	turnListener        func(string)       // Receives every finished turn, set by Capture
+	spoken              time.Duration      // Speech length of the session being finished, see spokenDuration
+	finishing           chan struct{}      // Closed once the session finishing in the background is done; nil when none is
+	queued              bool               // A recording started while the previous session was finishing
+	queuedAudio         [][]byte           // Audio of the queued recording, held until it can be streamed
+	queueDropped        bool               // The queued recording was dropped as its connection failed
+	queuedWake          bool               // The queued recording was started by the wake word
+	queuedSessionID     string             // ID the queued recording's events carry, current once it's streamed
+	queueMutex          sync.Mutex         // Guards finishing and the queue
+	sessionMutex        sync.Mutex         // Serializes starting and stopping recordings, whoever asks
+	recordings          atomic.Uint64      // Counts started recordings, so a late timer can't stop the next one
+	listenAddr          string             // --listen override for remote_listen_addr
+	dryRun              bool               // Transcribing a file for simulate or compare, not dictating
+	runCtx              context.Context    // Initialize's and then Run's context, for connections, recordings and servers restarted on reload
+	lastError           string             // Latest failure, reported by t2 status
+	lastErrorAt         time.Time
+	errorMutex          sync.Mutex // Guards lastError and lastErrorAt
+	startTime           time.Time
+	configMutex         sync.Mutex // Guards config and the settings derived from it
+	reloadMutex         sync.Mutex // Runs one ReloadConfig at a time
 }
 
 func NewDaemon() *Daemon {
+	return NewDaemonWith(Dependencies{})
+}
+
+// NewDaemonWith returns a daemon that uses deps in place of the real
+// microphone, provider, hotkeys, clipboard, beeps and notifications
+func NewDaemonWith(deps Dependencies) *Daemon {
+	if deps.Clipboard == nil {
+		deps.Clipboard = systemClipboard{}
+	}
+	if deps.Beeper == nil {
+		deps.Beeper = audio.NewSounds()
+	}
+	if deps.Notifier == nil {
+		deps.Notifier = systemNotifier{}
+	}
+
 	return &Daemon{
+		deps:                deps,
+		clipboard:           deps.Clipboard,
+		beeper:              deps.Beeper,
+		notifier:            deps.Notifier,
 		isFirstSession:      true,
-		quickPressThreshold: 800 * time.Millisecond,
+		startTime:           time.Now(),
+		counters:            metrics.NewCounters(),
+		quickPressThreshold: defaultQuickPressThreshold,
+		quickPressMode:      config.QuickPressDuration,
+		runCtx:              context.Background(),
 	}
 }
 
-func (d *Daemon) Initialize() error {
-	// Get API key using fallback priority system
-	var err error
-	d.apiKey, err = config.GetAPIKey()
-	if err != nil {
-		return fmt.Errorf("failed to get AssemblyAI API key: %v", err)
-	}
-
-	// Load configuration
-	d.config, err = config.LoadConfig()
+// Initialize sets up the daemon and connects; cancelling ctx closes the
+// connection and stops any recording
+func (d *Daemon) Initialize(ctx context.Context) error {
+	d.runCtx = ctx
+	cfg, err := d.initPipeline()
 	if err != nil {
-		d.config = &config.Config{}
+		return err
 	}
 
-	// Initialize processor
-	d.processor = transcription.NewProcessor()
-
-	// Initialize transcription client
-	d.transcriptClient = transcription.NewClient(
-		d.handleTranscript,
-		d.handleConnection,
-	)
-	d.transcriptClient.SetTerminationCallback(d.handleTermination)
-
-	// Initialize recorder with audio callback
-	d.recorder = audio.NewRecorder(d.transcriptClient.SendAudio)
-
 	// Silence detection is now handled on key release instead of real-time callback
 	// d.recorder.SetSilenceCallback(d.handleSilenceDetected)
 
-	// Initialize hotkey manager
-	d.hotkeyManager = hotkeys.NewManager(d)
+	// Initialize hotkey manager, on a MIDI pedal or pad if configured
+	if d.deps.Hotkeys != nil {
+		d.hotkeyManager = d.deps.Hotkeys(d)
+	} else if cfg.MIDITrigger != "" {
+		trigger, err := hotkeys.ParseMIDITrigger(cfg.MIDITrigger)
+		if err != nil {
+			return err
+		}
+		d.hotkeyManager = hotkeys.NewMIDIManager(d, trigger)
+	} else {
+		d.hotkeyManager = hotkeys.NewManager(d)
+	}
+	d.hotkeyManager.SetCommitEnabled(cfg.ComposeMode)
+	d.setEndOfSpeech(cfg)
 
 	// Initialize metrics manager
 	metricsDir, err := config.GetMetricsDir()
@@ -86,48 +160,178 @@ func (d *Daemon) Initialize() error {
 	// Initialize terminal control
 	d.terminalControl = terminal.NewControl()
 
+	// Walk the user through any missing macOS permissions before we need them
+	if d.usesSystemAudio() || d.deps.Clipboard == (systemClipboard{}) {
+		if !permissions.EnsureGranted() {
+			terminal.Println("⚠️  Warning: Some permissions are still missing - recording or pasting may not work")
+			terminal.Println()
+		}
+	}
+
 	// Initialize PortAudio
-	if err := audio.Initialize(); err != nil {
-		return fmt.Errorf("failed to initialize PortAudio: %v", err)
+	if d.usesSystemAudio() {
+		if err := audio.Initialize(); err != nil {
+			return fmt.Errorf("failed to initialize PortAudio: %v", err)
+		}
 	}
 
 	// Connect to AssemblyAI
-	if err := d.transcriptClient.Connect(d.apiKey); err != nil {
+	if err := d.transcriptClient.Connect(d.runCtx, d.apiKey); err != nil {
 		return fmt.Errorf("failed to connect to AssemblyAI streaming API: %v", err)
 	}
 
+	if cfg.StandbyConnection {
+		d.transcriptClient.EnableStandby(d.apiKey)
+	}
+
 	return nil
 }
 
-func (d *Daemon) Run() error {
-	if err := d.hotkeyManager.Start(); err != nil {
+// initPipeline sets up everything between the microphone and the transcript:
+// API key, processor, transcription client, recorder and config
+func (d *Daemon) initPipeline() (*config.Config, error) {
+	// Get API key using fallback priority system
+	var err error
+	d.apiKey = d.deps.APIKey
+	if d.apiKey == "" {
+		d.apiKey, err = config.GetAPIKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get AssemblyAI API key: %v", err)
+		}
+	}
+
+	// Initialize transcription client
+	if d.deps.Provider != nil {
+		d.transcriptClient = d.deps.Provider(d.handleTranscript, d.handleConnection)
+	} else {
+		d.transcriptClient = transcription.NewClient(
+			d.handleTranscript,
+			d.handleConnection,
+		)
+	}
+	d.transcriptClient.SetTerminationCallback(d.handleTermination)
+	d.transcriptClient.Subscribe(d.handleConnectionState)
+
+	// Initialize recorder with audio callback
+	if d.deps.Recorder != nil {
+		d.recorder = d.deps.Recorder(d.sendAudio)
+	} else {
+		d.recorder = audio.NewRecorder(d.sendAudio)
+	}
+
+	// Recent transcripts, sized and encrypted once the config is applied
+	historyPath, err := config.GetHistoryPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history path: %v", err)
+	}
+	d.history = history.New(historyPath)
+	d.events = events.NewSink()
+
+	// Load configuration
+	cfg := d.deps.Config
+	if cfg == nil {
+		cfg, err = config.LoadConfig()
+		if err != nil {
+			cfg = &config.Config{}
+		}
+	}
+	d.applyConfig(cfg)
+	d.startProcessor()
+
+	return cfg, nil
+}
+
+// Run listens for the hotkey until ctx is cancelled, e.g. by Ctrl+C, which
+// stops every part of the daemon that was started with it
+func (d *Daemon) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	d.runCtx = ctx
+
+	if err := d.hotkeyManager.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start hotkey: %v", err)
 	}
 
-	// Setup graceful shutdown
+	if err := d.startRemoteServer(ctx); err != nil {
+		terminal.Printf("⚠️  Warning: Remote trigger disabled: %v\n", err)
+	}
+
+	if err := d.startWakeWordMonitor(); err != nil {
+		terminal.Printf("⚠️  Warning: Always-listening mode disabled: %v\n", err)
+	}
+
+	// Pick up config.json edits without losing the warm connection
+	go d.watchConfig(ctx)
+
+	// Delete statistics, history and audio past retention_days
+	go d.watchRetention(ctx)
+
+	// Notify a summary of the day or week at report_time
+	go d.watchReports(ctx)
+
+	// Survive sleep/wake and headset changes without failing the next press
+	if d.usesSystemAudio() {
+		d.watchPower()
+		go d.watchInputDevices(ctx)
+	}
+
+	// SIGHUP for an explicit config reload and SIGUSR1 for a goroutine dump
+	// (used by t2 debug bundle)
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	signal.Notify(c, syscall.SIGHUP, syscall.SIGUSR1)
+	defer signal.Stop(c)
 
-	fmt.Println("🎤 T2 - Voice-to-Text Daemon Started")
-	fmt.Printf("📋 Hold %s to record, release to transcribe & paste\n", d.hotkeyManager.GetHotkeyDisplay())
-	fmt.Println("🛑 Press Ctrl+C to exit")
-	fmt.Println()
+	terminal.Println("🎤 T2 - Voice-to-Text Daemon Started")
+	if profile := config.GetProfile(); profile != "" {
+		terminal.Printf("👤 Profile: %s\n", profile)
+	}
+	terminal.Printf("📋 Hold %s to record, release to transcribe & paste\n", d.hotkeyManager.GetHotkeyDisplay())
+	terminal.Println("🔒 Double-tap to record hands-free, tap again to stop")
+	if d.composeMode() {
+		terminal.Printf("📝 Compose mode: dictations build up a draft, press %s to paste it\n", hotkeys.CommitDisplay)
+	}
+	terminal.Println("🛑 Press Ctrl+C to exit")
+	terminal.Println()
 
 	// Start hotkey listening in a goroutine
-	go d.hotkeyManager.Listen()
-
-	// Wait for shutdown signal
-	<-c
-	fmt.Println("\n🛑 Shutting down...")
-	d.Cleanup()
-	return nil
+	// The listener runs the press and release handlers, which take the daemon's locks
+	go supervisor.RunOrExit("hotkey listener", d.hotkeyManager.Listen)
+
+	// Wait for shutdown, handling the other signals in between
+	for {
+		select {
+		case <-ctx.Done():
+			terminal.Println("\n🛑 Shutting down...")
+			d.Cleanup()
+			return nil
+		case sig := <-c:
+			if sig == syscall.SIGHUP {
+				d.ReloadConfig()
+			} else {
+				d.dumpGoroutines()
+			}
+		}
+	}
 }
 
+// Cleanup stops what cancelling Run's context doesn't: the recording, the
+// wake-word monitor, the connection and PortAudio
 func (d *Daemon) Cleanup() {
-	// Stop hotkey manager
+	// No new sessions from here on, even if Run's context is still live
 	if d.hotkeyManager != nil {
 		d.hotkeyManager.Stop()
 	}
+	if d.remoteServer != nil {
+		d.remoteServer.Stop()
+	}
+
+	// Let a transcript still being pasted land
+	d.waitForFinish()
+
+	// Stop always-listening mode
+	if d.monitor != nil {
+		d.monitor.Stop()
+	}
 
 	// Stop recording if still running
 	if d.recorder != nil {
@@ -136,43 +340,109 @@ func (d *Daemon) Cleanup() {
 
 	// Close transcription client
 	if d.transcriptClient != nil {
+		d.transcriptClient.DisableStandby()
 		d.transcriptClient.Close()
 	}
 
 	// Terminate PortAudio
-	audio.Terminate()
+	if d.usesSystemAudio() {
+		audio.Terminate()
+	}
+}
+
+// usesSystemAudio reports whether the daemon records from the real microphone
+func (d *Daemon) usesSystemAudio() bool {
+	return d.deps.Recorder == nil
+}
+
+// SetListenAddr overrides remote_listen_addr, e.g. from --listen
+func (d *Daemon) SetListenAddr(addr string) {
+	d.listenAddr = addr
+}
+
+// startRemoteServer starts the HTTP trigger if --listen or remote_listen_addr is set
+func (d *Daemon) startRemoteServer(ctx context.Context) error {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+
+	addr := d.config.RemoteListenAddr
+	if d.listenAddr != "" {
+		addr = d.listenAddr
+	}
+	if addr == "" {
+		return nil
+	}
+
+	// Never listen without a token - create one on first use
+	if d.config.RemoteToken == "" {
+		token, err := remote.GenerateToken()
+		if err != nil {
+			return fmt.Errorf("failed to generate token: %v", err)
+		}
+		d.config.RemoteToken = token
+		if err := config.SaveConfig(d.config); err != nil {
+			return fmt.Errorf("failed to save token: %v", err)
+		}
+	}
+
+	d.remoteServer = remote.NewServer(addr, d.config.RemoteToken, d)
+	if err := d.remoteServer.Start(ctx); err != nil {
+		d.remoteServer = nil
+		return err
+	}
+
+	terminal.Printf("📱 Remote trigger listening on %s (token in config file)\n", addr)
+	return nil
 }
 
 // OnPress implements hotkeys.EventHandler
 func (d *Daemon) OnPress() {
+	d.sessionMutex.Lock()
+	defer d.sessionMutex.Unlock()
+	d.press()
+}
+
+// press starts a recording. Called with sessionMutex held.
+func (d *Daemon) press() {
 
 	// Check if already recording to prevent overlapping sessions
 	if d.recorder.IsRecording() {
 		return
 	}
 
-	// Check if connection needs refresh due to degradation
-	if d.transcriptClient.ConnectionNeedsRefresh() {
-		d.transcriptClient.Close()
-		time.Sleep(100 * time.Millisecond)
+	// Don't listen during focus_blocks
+	if until := d.focusBlockEnd(time.Now()); until != "" {
+		terminal.Printf("🧘 Focus block until %s - not listening\n", until)
+		d.beeper.PlayBeep("skipped")
+		return
 	}
 
-	// Silently reconnect if needed (happens after Terminate closes the connection)
-	if !d.transcriptClient.IsConnected() {
-		if err := d.transcriptClient.Connect(d.apiKey); err != nil {
-			fmt.Printf("❌ Connection failed: %v\n", err)
-			d.transcriptClient.ReportSessionFailure()
-			return
-		}
-		// Brief pause to let connection establish
-		time.Sleep(150 * time.Millisecond)
+	// While the previous session is still being transcribed and pasted, record
+	// right away and stream the audio once it's done
+	d.queueMutex.Lock()
+	if d.finishing != nil {
+		d.queueSession()
+		d.queueMutex.Unlock()
+		return
+	}
+	d.queueMutex.Unlock()
+
+	d.beginSessionLog()
+	d.logSession("Hotkey pressed")
+	d.trace = tracing.NewTrace("session")
+	d.trace.SetRootAttr("session.id", d.currentSessionID())
+	d.trace.Stage("press")
+
+	if !d.ensureConnected() {
+		d.trace.End()
+		return
 	}
 
-	audio.PlayBeep("start")
+	d.beeper.PlayBeep("start")
 
-	// Reset processor for new recording
-	d.processor.Reset()
-	d.currentTurnOrder = 0
+	// Every recording collects its transcripts in a processor of its own
+	d.startProcessor()
+	d.transcriptClient.ResetSessionStats()
 
 	// Record press time for quick-press detection (just before starting recording)
 	d.pressTime = time.Now()
@@ -180,92 +450,467 @@ func (d *Daemon) OnPress() {
 	// Record session start time for metrics
 	d.sessionStartTime = time.Now()
 
-	d.recorder.Start()
+	if err := d.recorder.Start(d.runCtx); err != nil {
+		d.logSession("Recording failed: %v", err)
+		terminal.Printf("❌ Recording failed: %v\n", err)
+		d.noteError("Recording failed: " + err.Error())
+		d.beeper.PlayBeep("error")
+		terminal.Println()
+		d.trace.End()
+		return
+	}
+	d.trace.Stage("record")
+	d.startSessionLimit()
+	d.emit(events.Event{Type: events.Started, SessionID: d.currentSessionID()})
+	d.startLiveTyping(d.wakeSession)
 }
 
-// OnRelease implements hotkeys.EventHandler
+// ensureConnected silently reconnects if needed (after Terminate, a dropped
+// connection or repeated failures), reporting a failure and returning false
+// if it can't
+func (d *Daemon) ensureConnected() bool {
+	if d.transcriptClient.CanStream() {
+		return true
+	}
+
+	// Prefer the standby connection so we don't pay for a fresh handshake
+	if d.standbyEnabled() && d.transcriptClient.SwapToStandby() {
+		return true
+	}
+	if err := d.transcriptClient.Connect(d.runCtx, d.apiKey); err != nil {
+		d.logSession("Connection failed: %v", err)
+		terminal.Printf("❌ Connection failed: %v\n", err)
+		d.noteError("Connection failed: " + err.Error())
+		d.beeper.PlayBeep("error")
+		d.recordSkip(metrics.SkipConnection, 0, 0, 0, d.currentSessionID())
+		return false
+	}
+	// Brief pause to let connection establish
+	time.Sleep(150 * time.Millisecond)
+	return true
+}
+
+// OnLock implements hotkeys.LockHandler
+func (d *Daemon) OnLock() {
+	if !d.recorder.IsRecording() {
+		return
+	}
+	d.beeper.PlayBeep("start")
+	terminal.Println("🔒 Recording locked - tap the hotkey to stop")
+}
+
+// OnMissedRelease implements hotkeys.MissedReleaseHandler
+func (d *Daemon) OnMissedRelease() {
+	if !d.recorder.IsRecording() {
+		return
+	}
+	d.logSession("Hotkey found released without a release event, stopping")
+	terminal.Println("⚠️  Warning: The hotkey release was missed - stopped recording")
+	d.notifier.Notify("Recording stopped - the hotkey release was missed")
+}
+
+// OnRelease implements hotkeys.EventHandler. The hotkey, remote control, wake
+// word and timers can all ask at once; only the first one stops the recording.
 func (d *Daemon) OnRelease() {
+	d.sessionMutex.Lock()
+	defer d.sessionMutex.Unlock()
+	d.release()
+}
+
+// release stops the recording and finishes the session. Called with
+// sessionMutex held.
+func (d *Daemon) release() {
 
 	// Check if we're actually recording
 	if !d.recorder.IsRecording() {
 		return
 	}
+	trace := d.trace
+
+	// Releasing with Option held asks for Return after the paste, and with
+	// Command held (or always, with clipboard_only) for a copy without pasting
+	submitAfterPaste := d.hotkeyManager.ReleasedWithOption()
+	copyOnly := d.hotkeyManager.ReleasedWithCommand() || d.clipboardOnly()
+
+	// Releasing with Fn held (or always, with translate_always) translates to translate_to
+	translate := d.hotkeyManager.ReleasedWithFn() || d.translateAlways()
+
+	// Wake-word sessions are started by any noise, so stay quiet about skipping them
+	wakeSession := d.wakeSession
+	d.wakeSession = false
 
 	// Calculate recording duration for quick-press detection
-	recordingDuration := time.Since(d.pressTime)
+	releaseTime := time.Now()
+	recordingDuration := releaseTime.Sub(d.pressTime)
+
+	// Keep listening a moment so a word still being finished isn't cut off;
+	// quick presses are skipped anyway, so they don't wait
+	if grace := d.releaseGrace(); grace > 0 {
+		if threshold, _ := d.quickPressSettings(); recordingDuration >= threshold {
+			time.Sleep(grace)
+		}
+	}
 
+	// Everything from here to asking for termination holds up the transcript
+	trace.Stage("stream")
 	d.recorder.Stop()
-	audio.PlayBeep("stop")
+	d.stopSessionLimit()
+	d.beeper.PlayBeep("stop")
+
+	// A recording queued behind the previous session has to wait for it to be
+	// streamed before this session's state can be touched
+	if !d.waitForFinish() {
+		return
+	}
+	d.releaseTime = releaseTime
+	d.logSession("Hotkey released after %v", recordingDuration.Round(time.Millisecond))
+
+	// Live typing stops here; the final transcript replaces what was typed, and
+	// anything typed for a session that ends up skipped is deleted again.
+	// Sessions that get past the checks below are finished in the background.
+	live := d.takeLiveTyper()
+	finishing := false
+	defer func() {
+		if !finishing {
+			live.Discard()
+			d.logSession("===== SESSION COMPLETE =====")
+			trace.End()
+		}
+	}()
+
+	// A slow network shows up as a deep send queue or dropped audio
+	queueDepth, droppedChunks := d.recorder.QueueStats()
+	d.counters.RecordAudioQueue(queueDepth, droppedChunks)
+	trace.SetAttr("audio.queue_depth", queueDepth)
+	trace.SetAttr("audio.dropped_chunks", droppedChunks)
+	if droppedChunks > 0 {
+		terminal.Printf("⚠️  Warning: Dropped %d audio chunks - the network couldn't keep up\n", droppedChunks)
+	}
+
+	// Keep the audio so a bad transcript can be re-run later
+	if pcm := d.recorder.TakeCapture(); len(pcm) > 0 {
+		go d.saveAudio(pcm)
+	}
+
+	// A persistent session closes the turn right away, so audio from a skipped
+	// recording never leaks into the next one
+	persistent := d.persistentSession()
+	if persistent {
+		if err := d.endTurn(); err != nil {
+			d.logSession("Error ending turn: %v", err)
+		}
+	}
+
+	// Track streamed audio for cost estimates - skipped sessions are billed too
+	if err := d.metricsManager.RecordAudioUsage(d.transcriptClient.SessionAudioDuration()); err != nil {
+		terminal.Printf("⚠️  Warning: Failed to record API usage: %v\n", err)
+	}
 
 	// Layer 1: Check for quick press - skip transcription if too short
-	if recordingDuration < d.quickPressThreshold {
-		fmt.Println("⚡ Quick press detected - skipped")
-		fmt.Println()
+	quickPressThreshold, quickPressMode := d.quickPressSettings()
+	isQuickPress := recordingDuration < quickPressThreshold
+	if isQuickPress && quickPressMode == config.QuickPressDuration {
+		// A quick press confirms a held low-confidence transcript
+		d.logSession("Skipped: quick press")
+		if wakeSession {
+			return
+		}
+		if !d.pastePending(submitAfterPaste) {
+			terminal.Println("⚡ Quick press detected - skipped")
+			d.recordSkip(metrics.SkipQuickPress, recordingDuration, d.recorder.GetMaxRMS(), 0, d.currentSessionID())
+		}
+		terminal.Println()
 		return
 	}
+	d.spoken = d.spokenDuration()
 
 	// Layer 2: Check for prolonged silence or low audio levels
 	maxRMS := d.recorder.GetMaxRMS()
 	hadProlongedSilence := d.recorder.HasProlongedSilence()
+	silenceThreshold := d.recorder.SilenceThreshold()
 
 	// Skip if we had prolonged silence without any significant speech
-	if hadProlongedSilence && maxRMS < 150.0 {
-		fmt.Println("🔇 Real-time silence detected - skipped")
-		fmt.Println()
-		// Reset processor to discard any accumulated audio from this session
-		d.processor.Reset()
+	if hadProlongedSilence && maxRMS < silenceThreshold {
+		d.logSession("Skipped: prolonged silence (max RMS %.0f)", maxRMS)
+		terminal.Println("🔇 Real-time silence detected - skipped")
+		terminal.Println()
+		if !wakeSession {
+			d.beeper.PlayBeep("skipped")
+		}
+		d.recordSkip(metrics.SkipSilence, recordingDuration, maxRMS, silenceThreshold, d.currentSessionID())
 		return
 	}
 
 	// Also check traditional silence detection for very quiet recordings
-	if !hadProlongedSilence && maxRMS < 150.0 {
-		fmt.Println("🔇 No speech detected - skipped")
-		fmt.Println()
-		// Reset processor to discard any accumulated audio from this session
-		d.processor.Reset()
+	if !hadProlongedSilence && maxRMS < silenceThreshold {
+		d.logSession("Skipped: no speech (max RMS %.0f)", maxRMS)
+		terminal.Println("🔇 No speech detected - skipped")
+		terminal.Println()
+		if !wakeSession {
+			d.beeper.PlayBeep("skipped")
+		}
+		d.recordSkip(metrics.SkipLowAudio, recordingDuration, maxRMS, silenceThreshold, d.currentSessionID())
 		return
 	}
 
+	// Clipping and very quiet input both quietly hurt accuracy
+	d.warnAboutLevels()
+
+	// Waiting for the transcript and pasting it happen in the background, so
+	// the next dictation can start recording in the meantime
+	finishing = true
+	d.startFinishing()
+	go d.finishSession(&stoppedSession{
+		processor:         d.currentProcessor(),
+		sessionID:         d.currentSessionID(),
+		live:              live,
+		trace:             trace,
+		wakeSession:       wakeSession,
+		persistent:        persistent,
+		submitAfterPaste:  submitAfterPaste,
+		copyOnly:          copyOnly,
+		translate:         translate,
+		isQuickPress:      isQuickPress,
+		recordingDuration: recordingDuration,
+		maxRMS:            maxRMS,
+	})
+}
+
+// finishSession waits for the transcript of a stopped session, formats it and
+// pastes, copies or holds it
+func (d *Daemon) finishSession(s *stoppedSession) {
+	defer d.sessionFinished()
+	defer s.log("===== SESSION COMPLETE =====")
+	defer s.trace.End()
+	defer s.live.Discard()
+	live, wakeSession, persistent := s.live, s.wakeSession, s.persistent
+	submitAfterPaste, copyOnly, translate := s.submitAfterPaste, s.copyOnly, s.translate
+	processor := s.processor
+
 	// Immediate termination for true streaming - send termination right away
-	d.transcriptClient.Terminate()
+	s.trace.Stage("terminate")
+	if !persistent {
+		d.transcriptClient.Terminate()
+	}
 
+	// A persistent session waits for the final turn that ends the recording,
+	// just like a terminated one waits for termination
 	terminationTimeout := 1 * time.Second // Balanced timeout for reliability + UX
+	terminationStart := time.Now()
 	select {
-	case <-d.processor.WaitForTermination():
+	case <-processor.WaitForTermination():
 	case <-time.After(terminationTimeout):
 	}
+	terminationWait := time.Since(terminationStart)
 
-	// Get the final transcript or fallback to best partial
-	text, _ := d.processor.ConsumeTranscriptWithFallback()
+	transcriptWait := time.Duration(0)
+	if lastTranscript := processor.LastTranscriptTime(); lastTranscript.After(d.releaseTime) {
+		transcriptWait = lastTranscript.Sub(d.releaseTime)
+	}
 
-	// Guarantee clean state for next session (prevents cross-session contamination)
-	d.processor.Reset()
+	// Get the final transcript or fallback to best partial
+	confidence := processor.GetConfidence()
+	language := d.transcriptClient.SessionLanguage()
+	transcriptCount, terminated := processor.SessionStats()
+	text, _ := processor.ConsumeTranscriptWithFallback()
+	if wakeSession {
+		var spoken bool
+		if text, spoken = formatting.StripWakeWord(text, d.wakeWord()); !spoken || text == "" {
+			s.log("Skipped: wake word not spoken")
+			return
+		}
+	}
+	s.trace.SetAttr("transcript.termination_wait_ms", terminationWait.Milliseconds())
+	s.trace.SetAttr("transcript.wait_ms", transcriptWait.Milliseconds())
+	s.trace.Stage("format")
+	opts := d.formattingOptions(d.formattingApp())
+	if live != nil {
+		opts = live.opts // Format the final transcript like the partials that were typed
+	}
+	text, commands := d.formatTranscript(text, translate, opts)
+	s.log("Transcript: %d words, confidence %.2f (termination wait %v, transcript wait %v)",
+		len(strings.Fields(text)), confidence, terminationWait.Round(time.Millisecond), transcriptWait.Round(time.Millisecond))
+	if language != "" {
+		s.log("Detected language: %s", language)
+	}
+	if text == "" {
+		// Clear out spoken commands before they act on the app
+		live.Discard()
+	}
 
-	if text != "" {
-		if err := clipboard.PasteTextSafely(text); err != nil {
-			fmt.Printf("❌ Paste failed: %v\n", err)
+	// Voice commands act on the previous paste and are never pasted themselves
+	composing := d.composeMode()
+	if commands.Undo {
+		if composing {
+			d.undoDraft()
+		} else if err := d.clipboard.Undo(); err != nil {
+			terminal.Printf("⚠️  Warning: %v\n", err)
+		}
+	}
+	submitAfterPaste = submitAfterPaste || commands.Send
+
+	if text != "" && live != nil {
+		// Already typed while speaking - just settle on the final transcript
+		s.log("Typed live")
+		d.pending = nil
+		text = live.Finish(text)
+		d.setLastTranscript(text)
+		app := d.clipboard.FrontmostApp()
+		d.pressReturnIfWanted(app, submitAfterPaste)
+		d.displaySessionMetrics(text, d.spoken, metrics.SessionDetails{
+			App:        app,
+			Provider:   transcription.ProviderName,
+			SessionID:  s.sessionID,
+			Latency:    time.Since(d.releaseTime),
+			Confidence: confidence,
+			Language:   language,
+
+			TerminationWait: terminationWait,
+			TranscriptWait:  transcriptWait,
+		})
+		d.transcriptClient.ReportSessionSuccess()
+	} else if text != "" && confidence < d.minConfidence() {
+		s.log("Held for confirmation: low confidence")
+		d.holdForConfirmation(text, confidence, language, s.sessionID)
+	} else if text != "" && composing {
+		// Compose mode collects dictations into a draft; asking for Return pastes it
+		s.log("Added to draft")
+		d.pending = nil
+		d.addToDraft(text, d.spoken)
+		if submitAfterPaste {
+			d.commitDraft(true)
+		}
+	} else if text != "" && d.keptOutOfApp(text, confidence, s.sessionID) {
+		// Copied or held instead of pasted, but the transcription itself worked
+		s.log("Kept out of %s", d.protectedApp())
+		d.transcriptClient.ReportSessionSuccess()
+	} else if text != "" && copyOnly {
+		s.log("Copied without pasting")
+		d.pending = nil
+		d.copyTranscript(text, d.spoken, metrics.SessionDetails{
+			App:        d.clipboard.FrontmostApp(),
+			Provider:   transcription.ProviderName,
+			SessionID:  s.sessionID,
+			Latency:    time.Since(d.releaseTime),
+			Confidence: confidence,
+			Language:   language,
+
+			TerminationWait: terminationWait,
+			TranscriptWait:  transcriptWait,
+		})
+		d.transcriptClient.ReportSessionSuccess()
+	} else if text != "" {
+		// A new transcript replaces anything still waiting for confirmation
+		d.pending = nil
+
+		text = d.joinWithField(text)
+		s.trace.Stage("paste")
+		pasteStart := time.Now()
+		if err := d.pasteText(text); err != nil {
+			s.log("Paste failed: %v", err)
+			terminal.Printf("❌ Paste failed: %v\n", err)
+			d.noteError("Paste failed: " + err.Error())
+			d.beeper.PlayBeep("error")
 		} else {
+			pasteTime := time.Since(pasteStart)
+			s.log("Pasted in %v", pasteTime.Round(time.Millisecond))
+			d.setLastTranscript(text)
+			latency := time.Since(d.releaseTime)
+			app := d.clipboard.FrontmostApp()
+
+			d.pressReturnIfWanted(app, submitAfterPaste)
+
 			// Record metrics and display enhanced output
-			d.displaySessionMetrics(text)
+			d.displaySessionMetrics(text, d.spoken, metrics.SessionDetails{
+				App:        app,
+				Provider:   transcription.ProviderName,
+				SessionID:  s.sessionID,
+				Latency:    latency,
+				Confidence: confidence,
+				Language:   language,
+
+				TerminationWait: terminationWait,
+				TranscriptWait:  transcriptWait,
+				PasteTime:       pasteTime,
+			})
 			// Report successful session to improve connection health
 			d.transcriptClient.ReportSessionSuccess()
 		}
+	} else if commands.Any() {
+		d.pending = nil
+		if composing && commands.Send {
+			d.commitDraft(true)
+		} else if commands.Send {
+			d.pressReturnIfWanted("", true)
+		}
+		s.log("Voice command handled")
+		terminal.Println("🗣️  Voice command handled")
+	} else if s.isQuickPress {
+		// In transcript mode an empty quick press was most likely accidental
+		if !d.pastePending(submitAfterPaste) {
+			terminal.Println("⚡ Quick press detected - skipped")
+			d.recordSkip(metrics.SkipQuickPress, s.recordingDuration, s.maxRMS, 0, s.sessionID)
+		}
 	} else {
-		fmt.Println("❌ No transcription received")
+		diagnosis := sessionDiagnosis{
+			chunksSent:  d.transcriptClient.SessionChunks(),
+			transcripts: transcriptCount,
+			terminated:  terminated,
+			connected:   d.transcriptClient.IsConnected(),
+		}
+		s.log("No transcript (%d chunks sent, %d transcripts, terminated: %v, connected: %v)",
+			diagnosis.chunksSent, diagnosis.transcripts, diagnosis.terminated, diagnosis.connected)
+		diagnosis.print()
+		cause, _ := diagnosis.probableCause()
+		d.noteError("No transcription received: " + cause)
+		d.beeper.PlayBeep("error")
 		// Report failed session to degrade connection health
 		d.transcriptClient.ReportSessionFailure()
+		d.counters.RecordFailure()
 	}
-	fmt.Println()
+	terminal.Println()
 }
 
-// handleTranscript handles incoming transcripts from the transcription client
-func (d *Daemon) handleTranscript(transcript string, isComplete bool, endOfTurn bool, confidence float64) {
-	// AssemblyAI sends progressive partial transcripts that already contain
-	// the accumulated text, so we use the same turn order (0) for all partials
-	// and only mark completion when we get the final formatted transcript
+// formatTranscript pulls out voice commands, applies opts, translates if
+// asked and expands snippets. Snippets expand last so their stored text is
+// pasted as written.
+func (d *Daemon) formatTranscript(text string, translate bool, opts formatting.Options) (string, formatting.Commands) {
+	text, commands := formatting.ExtractCommands(text, d.voiceCommandPhrases())
+	text = formatting.Apply(text, opts)
+	if translate {
+		text = d.translate(text)
+	}
+	if expanded, count := formatting.ExpandSnippets(text, d.snippets()); count > 0 {
+		d.logSession("Expanded %d snippet(s)", count)
+		text = expanded
+	}
+	return text, commands
+}
+
+// joinWithField fits text to what's already in front of the cursor, so a
+// dictation can continue a sentence
+func (d *Daemon) joinWithField(text string) string {
+	if !d.smartJoin() {
+		return text
+	}
+	before, ok := d.clipboard.TextBeforeCursor()
+	if !ok {
+		return text
+	}
+	// Joining only looks at the case options, which don't differ per app
+	return formatting.JoinAfter(text, before, d.formattingOptions(""))
+}
 
-	turnOrder := 0
-	d.processor.ProcessTranscript(transcript, turnOrder, isComplete, endOfTurn, confidence)
+// handleTranscript handles incoming transcripts from the transcription client
+func (d *Daemon) handleTranscript(transcript string, turnOrder int, isComplete bool, endOfTurn bool, confidence float64) {
+	// AssemblyAI sends progressive partial transcripts that contain the whole
+	// turn so far; the processor keeps the latest per turn and orders finished
+	// turns by turn order
+	d.turnProcessor().ProcessTranscript(transcript, turnOrder, isComplete, endOfTurn, confidence)
+	d.updateLiveTyping()
+	if isComplete && d.turnListener != nil {
+		d.turnListener(transcript)
+	}
 }
 
 // handleConnection handles connection status changes
@@ -274,49 +919,75 @@ func (d *Daemon) handleConnection(connected bool) {
 	// Audio beeps provide user feedback instead
 }
 
+// handleConnectionState counts every connection that becomes ready
+func (d *Daemon) handleConnectionState(event transcription.StateEvent) {
+	// Persistent sessions return to Ready after every recording - that's not a new connection
+	if event.To == transcription.Ready && event.From != transcription.Streaming {
+		d.counters.RecordReconnect()
+		d.dropEndingTurn()
+	}
+}
+
 // handleTermination handles session termination from AssemblyAI
 func (d *Daemon) handleTermination() {
-	d.processor.SignalTermination()
+	d.takeTurnProcessor().SignalTermination()
 }
 
 // handleSilenceDetected handles real-time silence detection from audio recorder
 func (d *Daemon) handleSilenceDetected() {
-	log.Printf("[SESSION] Real-time silence detected by audio recorder")
+	d.logSession("Real-time silence detected by audio recorder")
 
 	// Check if we're actually recording to prevent race conditions
 	if !d.recorder.IsRecording() {
-		log.Printf("[SESSION] Silence detected but not recording, ignoring")
+		d.logSession("Silence detected but not recording, ignoring")
 		return
 	}
 
 	// Stop recording immediately
-	log.Printf("[SESSION] Stopping recording due to real-time silence detection")
+	d.logSession("Stopping recording due to real-time silence detection")
 	d.recorder.Stop()
-	audio.PlayBeep("stop")
+	d.stopSessionLimit()
+	d.beeper.PlayBeep("skipped")
 
 	// Log the session as skipped due to silence
-	log.Printf("[SESSION] Real-time silence skipped")
-	fmt.Println("🔇 Real-time silence detected - skipped")
-	fmt.Println()
-	log.Printf("[SESSION] ===== SESSION COMPLETE =====")
+	d.logSession("Real-time silence skipped")
+	d.recordSkip(metrics.SkipSilence, time.Since(d.pressTime), d.recorder.GetMaxRMS(), d.recorder.SilenceThreshold(), d.currentSessionID())
+	terminal.Println("🔇 Real-time silence detected - skipped")
+	terminal.Println()
+	d.logSession("===== SESSION COMPLETE =====")
 }
 
-func (d *Daemon) displaySessionMetrics(text string) {
-	// Calculate recording duration
-	recordingDuration := time.Since(d.sessionStartTime)
+// recordSkip counts a session that ended without a transcript and saves it
+// with its reason, for the skip breakdown in --stats
+func (d *Daemon) recordSkip(reason string, recordingDuration time.Duration, maxRMS, threshold float64, sessionID string) {
+	d.counters.RecordSkip(reason)
+	d.emit(events.Event{Type: events.Skipped, SessionID: sessionID, DurationMs: recordingDuration.Milliseconds(), Reason: reason})
+	if err := d.metricsManager.RecordSkip(reason, recordingDuration, maxRMS, threshold, sessionID); err != nil {
+		terminal.Printf("⚠️  Warning: Failed to record skipped session: %v\n", err)
+	}
+}
+
+func (d *Daemon) displaySessionMetrics(text string, recordingDuration time.Duration, details metrics.SessionDetails) {
+	if details.SessionID == "" {
+		details.SessionID = d.currentSessionID()
+	}
+	details.Tag = d.sessionTag()
+	d.counters.RecordSession(text, details.Latency)
+	d.emit(events.Event{Type: events.Finished, SessionID: details.SessionID, DurationMs: recordingDuration.Milliseconds(),
+		Words: len(strings.Fields(text)), App: details.App})
 
 	// Record session metrics
-	sessionMetrics, err := d.metricsManager.RecordSession(text, recordingDuration)
+	sessionMetrics, err := d.metricsManager.RecordSession(text, recordingDuration, details)
 	if err != nil {
-		fmt.Printf("⚠️  Warning: Failed to record session metrics: %v\n", err)
-		fmt.Println("✅ Pasted to active application")
+		terminal.Printf("⚠️  Warning: Failed to record session metrics: %v\n", err)
+		terminal.Println("✅ Pasted to active application")
 		return
 	}
 
 	// Get today's metrics for cumulative display
 	todayMetrics, err := d.metricsManager.GetTodayMetrics()
 	if err != nil {
-		fmt.Printf("⚠️  Warning: Failed to get today's metrics: %v\n", err)
+		terminal.Printf("⚠️  Warning: Failed to get today's metrics: %v\n", err)
 		todayMetrics = nil
 	}
 
@@ -324,6 +995,13 @@ func (d *Daemon) displaySessionMetrics(text string) {
 	formatter := metrics.NewStatsFormatter()
 	lines := formatter.FormatSessionSummaryLines(sessionMetrics, todayMetrics)
 
+	// Show goal progress and streak when there's something to show
+	streak, _ := d.metricsManager.GetStreak()
+	goalTarget, goalUnit := d.metricsManager.GetGoal()
+	if goalLine := formatter.FormatGoalProgress(goalTarget, goalUnit, todayMetrics, streak); goalLine != "" {
+		lines = append(lines, goalLine)
+	}
+
 	// Use terminal control for dynamic updates
 	d.terminalControl.UpdateInPlace(lines, d.isFirstSession)
 
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+)
+
+// newTestDaemon returns an initialized daemon that streams to a fake
+// AssemblyAI answering with transcripts in turn, and keeps its data in a temp dir
+func newTestDaemon(t *testing.T, cfg *config.Config, transcripts ...string) (*Daemon, *fakeAssemblyAI, *fakeClipboard) {
+	t.Helper()
+	t.Setenv(config.ConfigDirEnvVar, t.TempDir())
+
+	server := newFakeAssemblyAI(t, "test-key", transcripts...)
+	cfg.StreamingURL = server.URL()
+	clipboard := newFakeClipboard()
+	d := NewDaemonWith(Dependencies{
+		APIKey:    "test-key",
+		Config:    cfg,
+		Recorder:  newScriptedRecorder,
+		Hotkeys:   newFakeHotkeys,
+		Clipboard: clipboard,
+		Beeper:    silentBeeper{},
+		Notifier:  silentNotifier{},
+	})
+	if err := d.Initialize(t.Context()); err != nil {
+		t.Fatalf("Initialize() failed: %v", err)
+	}
+	return d, server, clipboard
+}
+
+// dictate holds the hotkey for duration and returns what got pasted
+func dictate(t *testing.T, d *Daemon, clipboard *fakeClipboard, duration time.Duration) string {
+	t.Helper()
+	d.OnPress()
+	time.Sleep(duration)
+	d.OnRelease()
+
+	select {
+	case text := <-clipboard.pastes:
+		return text
+	case <-time.After(5 * time.Second):
+		t.Fatal("nothing was pasted")
+		return ""
+	}
+}
+
+func TestPressReleasePastes(t *testing.T) {
+	d, server, clipboard := newTestDaemon(t, &config.Config{}, "Hello from the fake server.")
+
+	// Sentences are pasted with a trailing space, ready for the next one
+	if got, want := dictate(t, d, clipboard, time.Second), "Hello from the fake server. "; got != want {
+		t.Errorf("pasted %q, want %q", got, want)
+	}
+	if server.AudioBytes() == 0 {
+		t.Error("no audio was streamed")
+	}
+}
+
+func TestPersistentSessionPastesEachRecording(t *testing.T) {
+	d, _, clipboard := newTestDaemon(t, &config.Config{PersistentSession: true}, "Same again.")
+
+	for i := 0; i < 2; i++ {
+		if got, want := dictate(t, d, clipboard, time.Second), "Same again. "; got != want {
+			t.Errorf("recording %d pasted %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestPersistentSessionDropsLateTurn(t *testing.T) {
+	d, server, clipboard := newTestDaemon(t, &config.Config{PersistentSession: true}, "Too late.", "On time.")
+
+	// The first recording gives up on its final turn, which then arrives
+	// while the second one is being recorded
+	server.DelayTurns(1, 1500*time.Millisecond)
+	d.OnPress()
+	time.Sleep(time.Second)
+	d.OnRelease()
+	d.waitForFinish()
+
+	if got, want := dictate(t, d, clipboard, time.Second), "On time. "; got != want {
+		t.Errorf("pasted %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"log"
+	"os"
+	"runtime/pprof"
+
+	"github.com/bezmoradi/t2/internal/config"
+)
+
+// dumpGoroutines writes every goroutine's stack to the dump file, for
+// diagnosing hangs without killing the daemon
+func (d *Daemon) dumpGoroutines() {
+	path, err := config.GetGoroutineDumpPath()
+	if err != nil {
+		log.Printf("Error getting goroutine dump path: %v", err)
+		return
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		log.Printf("Error creating goroutine dump: %v", err)
+		return
+	}
+	defer file.Close()
+
+	if err := pprof.Lookup("goroutine").WriteTo(file, 2); err != nil {
+		log.Printf("Error writing goroutine dump: %v", err)
+		return
+	}
+	log.Printf("Goroutine dump written to %s", path)
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/audio"
+	"github.com/bezmoradi/t2/internal/clipboard"
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/hotkeys"
+	"github.com/bezmoradi/t2/internal/notify"
+	"github.com/bezmoradi/t2/internal/transcription"
+)
+
+// Recorder captures microphone audio and hands it to the provider; implemented by *audio.Recorder
+type Recorder interface {
+	Start(ctx context.Context) error
+	Stop()
+	IsRecording() bool
+	GetMaxRMS() float64
+	HasProlongedSilence() bool
+	QueueStats() (int, int)
+	Levels() (int, int)
+	SpeechDuration() time.Duration
+	TakeCapture() []byte
+	ChunkDuration() time.Duration
+	SetChunkDuration(duration time.Duration)
+	SetQueuePolicy(policy string)
+	SetCapture(enabled bool)
+	SetSilenceCallback(callback func())
+	SetSilenceThreshold(threshold float64)
+	SetEndOfSpeech(after time.Duration, callback func())
+	SilenceThreshold() float64
+	SetInputDevice(name string)
+	SetInputChannels(channels map[string]int)
+	SetSessionID(id string)
+	RestartAudio() error
+}
+
+// Provider streams audio to a transcription service; implemented by *transcription.Client
+type Provider interface {
+	Connect(ctx context.Context, apiKey string) error
+	Close()
+	Terminate() error
+	EndTurn() error
+	SendAudio(data []byte) error
+	IsConnected() bool
+	CanStream() bool
+	State() transcription.ConnectionState
+	Subscribe(subscriber func(transcription.StateEvent))
+	SetTerminationCallback(callback func())
+	SetFormatTurns(enabled bool) bool
+	SetLanguageDetection(enabled bool) bool
+	SetTurnDetection(turnDetection transcription.TurnDetection) (bool, error)
+	SetStreamURL(streamURL string) bool
+	SetProxy(proxyURL string) (bool, error)
+	SetPersistentSession(enabled bool)
+	EnableStandby(apiKey string)
+	DisableStandby()
+	SwapToStandby() bool
+	SetSessionID(id string)
+	ResetSessionStats()
+	SessionChunks() int
+	SessionLanguage() string
+	SessionAudioDuration() time.Duration
+	ReportSessionSuccess()
+	ReportSessionFailure()
+}
+
+// Hotkeys turns key presses into OnPress/OnRelease calls; implemented by *hotkeys.Manager
+type Hotkeys interface {
+	Start(ctx context.Context) error
+	Listen()
+	Stop()
+	GetHotkeyDisplay() string
+	ReleasedWithOption() bool
+	ReleasedWithCommand() bool
+	ReleasedWithFn() bool
+	SetCommitEnabled(enabled bool)
+}
+
+// Clipboard delivers text and keystrokes to the frontmost application
+type Clipboard interface {
+	Paste(text string) error
+	PasteRich(text string, html string) error
+	Copy(text string) error
+	Type(text string) error
+	Backspace(count int) error
+	Undo() error
+	PressReturn() error
+	FrontmostApp() string
+	FrontmostBundleID() string
+	TextBeforeCursor() (string, bool)
+}
+
+// Beeper plays audio feedback
+type Beeper interface {
+	PlayBeep(beepType string)
+}
+
+// Notifier shows desktop notifications
+type Notifier interface {
+	Notify(message string)
+}
+
+// SoundConfigurer is implemented by beepers that honor the sound settings in config.json
+type SoundConfigurer interface {
+	Configure(settings audio.SoundSettings)
+}
+
+// Dependencies replaces the parts of the daemon that need a microphone, macOS
+// permissions or AssemblyAI, so fakes can drive it in integration tests. Nil
+// fields use the real implementation.
+type Dependencies struct {
+	APIKey    string         // Skips the API key lookup and prompt when set
+	Config    *config.Config // Used instead of config.json when set
+	Recorder  func(sendAudio func([]byte) error) Recorder
+	Provider  func(onTranscript func(string, int, bool, bool, float64), onConnection func(bool)) Provider
+	Hotkeys   func(handler hotkeys.EventHandler) Hotkeys
+	Clipboard Clipboard
+	Beeper    Beeper
+	Notifier  Notifier
+}
+
+// systemClipboard pastes through the macOS clipboard and System Events
+type systemClipboard struct{}
+
+func (systemClipboard) Paste(text string) error           { return clipboard.PasteTextSafely(text) }
+func (systemClipboard) PasteRich(text, html string) error { return clipboard.PasteRichText(text, html) }
+func (systemClipboard) Copy(text string) error            { return clipboard.CopyText(text) }
+func (systemClipboard) Type(text string) error            { return clipboard.TypeText(text) }
+func (systemClipboard) Backspace(count int) error         { return clipboard.Backspace(count) }
+func (systemClipboard) Undo() error                       { return clipboard.Undo() }
+func (systemClipboard) PressReturn() error                { return clipboard.PressReturn() }
+func (systemClipboard) FrontmostApp() string              { return clipboard.FrontmostApp() }
+func (systemClipboard) FrontmostBundleID() string         { return clipboard.FrontmostBundleID() }
+func (systemClipboard) TextBeforeCursor() (string, bool)  { return clipboard.TextBeforeCursor() }
+
+// systemNotifier posts macOS notifications
+type systemNotifier struct{}
+
+func (systemNotifier) Notify(message string) { notify.Send(message) }
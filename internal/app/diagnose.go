@@ -0,0 +1,43 @@
+package app
+
+import "github.com/bezmoradi/t2/internal/terminal"
+
+// sessionDiagnosis captures what happened during a session that produced no text
+type sessionDiagnosis struct {
+	chunksSent  int
+	transcripts int
+	terminated  bool
+	connected   bool
+}
+
+// probableCause maps the session's symptoms to the most likely cause and fix
+func (s sessionDiagnosis) probableCause() (string, string) {
+	switch {
+	case s.chunksSent == 0 && !s.connected:
+		return "the connection to AssemblyAI was lost before any audio was sent",
+			"check your network connection - T2 reconnects on the next press"
+	case s.chunksSent == 0:
+		return "no audio reached AssemblyAI",
+			"check microphone access in System Settings → Privacy & Security → Microphone and your input device"
+	case !s.connected:
+		return "the connection to AssemblyAI dropped mid-recording",
+			"check your network connection - T2 reconnects on the next press"
+	case s.transcripts == 0 && s.terminated:
+		return "AssemblyAI received your audio but heard no words",
+			"speak closer to the microphone or check that the right input device is selected"
+	case s.transcripts == 0:
+		return "AssemblyAI never responded to the audio",
+			"check your network, and your API key and remaining quota on the AssemblyAI dashboard"
+	default:
+		return "the transcript didn't arrive before the timeout",
+			"try again - if it keeps happening your connection may be slow"
+	}
+}
+
+// print explains the probable cause instead of a bare failure message
+func (s sessionDiagnosis) print() {
+	cause, fix := s.probableCause()
+	terminal.Println("❌ No transcription received")
+	terminal.Printf("🔍 Probable cause: %s (%d audio chunks sent, %d transcripts received)\n", cause, s.chunksSent, s.transcripts)
+	terminal.Printf("💡 Fix: %s\n", fix)
+}
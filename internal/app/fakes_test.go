@@ -0,0 +1,264 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/hotkeys"
+	"github.com/gorilla/websocket"
+)
+
+// fakeAssemblyAI speaks AssemblyAI's v3 streaming protocol: Begin on connect,
+// then the next scripted transcript as a formatted final turn once the client
+// terminates the session or forces the end of the turn
+type fakeAssemblyAI struct {
+	*httptest.Server
+	apiKey      string
+	transcripts []string
+
+	mutex      sync.Mutex
+	audioBytes int
+	turnOrder  int
+	lateTurns  int           // How many forced final turns to send late
+	lateBy     time.Duration // How late
+}
+
+func newFakeAssemblyAI(t *testing.T, apiKey string, transcripts ...string) *fakeAssemblyAI {
+	f := &fakeAssemblyAI{apiKey: apiKey, transcripts: transcripts}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// URL returns the websocket URL to set as streaming_url
+func (f *fakeAssemblyAI) URL() string {
+	return "ws" + strings.TrimPrefix(f.Server.URL, "http")
+}
+
+// DelayTurns sends the next count forced final turns after delay
+func (f *fakeAssemblyAI) DelayTurns(count int, delay time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.lateTurns, f.lateBy = count, delay
+}
+
+// AudioBytes returns how much audio was streamed across all sessions
+func (f *fakeAssemblyAI) AudioBytes() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.audioBytes
+}
+
+func (f *fakeAssemblyAI) serve(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != f.apiKey {
+		http.Error(w, "invalid API key", http.StatusUnauthorized)
+		return
+	}
+	upgrader := websocket.Upgrader{}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var writeMutex sync.Mutex
+	send := func(message map[string]any) error {
+		writeMutex.Lock()
+		defer writeMutex.Unlock()
+		return conn.WriteJSON(message)
+	}
+	if send(map[string]any{"type": "Begin", "id": "fake-session"}) != nil {
+		return
+	}
+
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if messageType == websocket.BinaryMessage {
+			f.mutex.Lock()
+			f.audioBytes += len(data)
+			f.mutex.Unlock()
+			continue
+		}
+
+		var message struct {
+			Type string `json:"type"`
+		}
+		if json.Unmarshal(data, &message) != nil {
+			continue
+		}
+		switch message.Type {
+		case "ForceEndpoint":
+			turn := f.finalTurn()
+			if delay := f.takeDelay(); delay > 0 {
+				time.AfterFunc(delay, func() { send(turn) })
+			} else if send(turn) != nil {
+				return
+			}
+		case "Terminate":
+			if send(f.finalTurn()) != nil || send(map[string]any{"type": "Termination"}) != nil {
+				return
+			}
+		}
+	}
+}
+
+func (f *fakeAssemblyAI) finalTurn() map[string]any {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.turnOrder++
+	return map[string]any{
+		"type":                   "Turn",
+		"transcript":             f.transcripts[(f.turnOrder-1)%len(f.transcripts)],
+		"turn_order":             f.turnOrder,
+		"turn_is_formatted":      true,
+		"end_of_turn":            true,
+		"end_of_turn_confidence": 0.95,
+	}
+}
+
+func (f *fakeAssemblyAI) takeDelay() time.Duration {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.lateTurns == 0 {
+		return 0
+	}
+	f.lateTurns--
+	return f.lateBy
+}
+
+// scriptedRecorder plays back loud audio chunks while recording, like a
+// microphone someone is speaking into
+type scriptedRecorder struct {
+	sendAudio func([]byte) error
+
+	mutex     sync.Mutex
+	recording bool
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func newScriptedRecorder(sendAudio func([]byte) error) Recorder {
+	return &scriptedRecorder{sendAudio: sendAudio}
+}
+
+// scriptedChunk is 50ms of 16 kHz mono PCM
+var scriptedChunk = make([]byte, 1600)
+
+func (r *scriptedRecorder) Start(ctx context.Context) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.recording {
+		return nil
+	}
+	r.recording = true
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	go r.play(ctx, r.stop, r.done)
+	return nil
+}
+
+func (r *scriptedRecorder) play(ctx context.Context, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(r.ChunkDuration())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			go r.Stop()
+			return
+		case <-ticker.C:
+			r.sendAudio(scriptedChunk)
+		}
+	}
+}
+
+func (r *scriptedRecorder) Stop() {
+	r.mutex.Lock()
+	if !r.recording {
+		r.mutex.Unlock()
+		return
+	}
+	r.recording = false
+	close(r.stop)
+	done := r.done
+	r.mutex.Unlock()
+	<-done
+}
+
+func (r *scriptedRecorder) IsRecording() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.recording
+}
+
+func (r *scriptedRecorder) GetMaxRMS() float64                       { return 3000 }
+func (r *scriptedRecorder) HasProlongedSilence() bool                { return false }
+func (r *scriptedRecorder) QueueStats() (int, int)                   { return 0, 0 }
+func (r *scriptedRecorder) Levels() (int, int)                       { return 0, 0 }
+func (r *scriptedRecorder) SpeechDuration() time.Duration            { return 0 }
+func (r *scriptedRecorder) TakeCapture() []byte                      { return nil }
+func (r *scriptedRecorder) ChunkDuration() time.Duration             { return 50 * time.Millisecond }
+func (r *scriptedRecorder) SetChunkDuration(duration time.Duration)  {}
+func (r *scriptedRecorder) SetQueuePolicy(policy string)             {}
+func (r *scriptedRecorder) SetCapture(enabled bool)                  {}
+func (r *scriptedRecorder) SetSilenceCallback(callback func())       {}
+func (r *scriptedRecorder) SetSilenceThreshold(threshold float64)    {}
+func (r *scriptedRecorder) SetEndOfSpeech(time.Duration, func())     {}
+func (r *scriptedRecorder) SilenceThreshold() float64                { return 500 }
+func (r *scriptedRecorder) SetInputDevice(name string)               {}
+func (r *scriptedRecorder) SetInputChannels(channels map[string]int) {}
+func (r *scriptedRecorder) SetSessionID(id string)                   {}
+func (r *scriptedRecorder) RestartAudio() error                      { return nil }
+
+// fakeHotkeys never fires; tests call OnPress and OnRelease themselves
+type fakeHotkeys struct{}
+
+func newFakeHotkeys(hotkeys.EventHandler) Hotkeys { return fakeHotkeys{} }
+
+func (fakeHotkeys) Start(ctx context.Context) error { return nil }
+func (fakeHotkeys) Listen()                         {}
+func (fakeHotkeys) Stop()                           {}
+func (fakeHotkeys) GetHotkeyDisplay() string        { return "Fake" }
+func (fakeHotkeys) ReleasedWithOption() bool        { return false }
+func (fakeHotkeys) ReleasedWithCommand() bool       { return false }
+func (fakeHotkeys) ReleasedWithFn() bool            { return false }
+func (fakeHotkeys) SetCommitEnabled(enabled bool)   {}
+
+// fakeClipboard hands every paste to the pastes channel
+type fakeClipboard struct {
+	pastes chan string
+}
+
+func newFakeClipboard() *fakeClipboard {
+	return &fakeClipboard{pastes: make(chan string, 10)}
+}
+
+func (c *fakeClipboard) Paste(text string) error           { c.pastes <- text; return nil }
+func (c *fakeClipboard) PasteRich(text, html string) error { c.pastes <- text; return nil }
+func (c *fakeClipboard) Copy(text string) error            { return nil }
+func (c *fakeClipboard) Type(text string) error            { return nil }
+func (c *fakeClipboard) Backspace(count int) error         { return nil }
+func (c *fakeClipboard) Undo() error                       { return nil }
+func (c *fakeClipboard) PressReturn() error                { return nil }
+func (c *fakeClipboard) FrontmostApp() string              { return "TextEdit" }
+func (c *fakeClipboard) FrontmostBundleID() string         { return "com.apple.TextEdit" }
+func (c *fakeClipboard) TextBeforeCursor() (string, bool)  { return "", false }
+
+type silentBeeper struct{}
+
+func (silentBeeper) PlayBeep(beepType string) {}
+
+type silentNotifier struct{}
+
+func (silentNotifier) Notify(message string) {}
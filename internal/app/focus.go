@@ -0,0 +1,59 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/events"
+)
+
+// focusBlock is one daily span of focus_blocks, in minutes since midnight
+type focusBlock struct {
+	start int
+	end   int
+}
+
+// parseFocusBlock parses "HH:MM-HH:MM"; a block that ends before it starts
+// runs past midnight
+func parseFocusBlock(block string) (focusBlock, error) {
+	startText, endText, ok := strings.Cut(block, "-")
+	if !ok {
+		return focusBlock{}, fmt.Errorf("use HH:MM-HH:MM, e.g. \"09:00-11:00\"")
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(startText))
+	if err != nil {
+		return focusBlock{}, fmt.Errorf("invalid start: use HH:MM-HH:MM, e.g. \"09:00-11:00\"")
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(endText))
+	if err != nil {
+		return focusBlock{}, fmt.Errorf("invalid end: use HH:MM-HH:MM, e.g. \"09:00-11:00\"")
+	}
+	return focusBlock{start: start.Hour()*60 + start.Minute(), end: end.Hour()*60 + end.Minute()}, nil
+}
+
+// contains reports whether minute, counted from midnight, falls in the block
+func (b focusBlock) contains(minute int) bool {
+	if b.start <= b.end {
+		return minute >= b.start && minute < b.end
+	}
+	return minute >= b.start || minute < b.end
+}
+
+// focusBlockEnd returns when the focus block now falls in ends, as "15:04",
+// or "" outside focus blocks
+func (d *Daemon) focusBlockEnd(now time.Time) string {
+	minute := now.Hour()*60 + now.Minute()
+	for _, block := range d.focusBlocks() {
+		if block.contains(minute) {
+			return fmt.Sprintf("%02d:%02d", block.end/60, block.end%60)
+		}
+	}
+	return ""
+}
+
+// emit sends a session event to events_url and events_script
+func (d *Daemon) emit(event events.Event) {
+	event.Time = time.Now().UTC()
+	d.events.Emit(event)
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"math"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+const (
+	// minClippedChunks of clipped audio in one recording counts as sustained clipping
+	minClippedChunks = 3
+
+	// lowPeakLevel is the loudest sample (about -30 dBFS) below which speech is
+	// too quiet to transcribe reliably
+	lowPeakLevel = 1000
+)
+
+// warnAboutLevels prints a one-line warning with advice when the recording
+// clipped or never got loud, since both hurt accuracy without any other sign
+func (d *Daemon) warnAboutLevels() {
+	peak, clippedChunks := d.recorder.Levels()
+	switch {
+	case clippedChunks >= minClippedChunks:
+		d.logSession("Input clipped in %d chunks", clippedChunks)
+		terminal.Println("⚠️  Warning: Your microphone is clipping - lower its input volume in System Settings > Sound > Input, or move back a little")
+	case peak > 0 && peak < lowPeakLevel:
+		d.logSession("Input level low (peak %.0f dBFS)", dbfs(peak))
+		terminal.Printf("⚠️  Warning: Your microphone is very quiet (peak %.0f dBFS) - raise its input volume in System Settings > Sound > Input, or move closer\n", dbfs(peak))
+	}
+}
+
+// dbfs converts a PCM16 sample level to decibels relative to full scale
+func dbfs(level int) float64 {
+	return 20 * math.Log10(float64(level)/math.MaxInt16)
+}
+
+// spokenDuration is how long the user spoke this session: the recording with
+// the silence before and after speech trimmed, so the pause before talking
+// doesn't skew the recording time and speaking rate in metrics
+func (d *Daemon) spokenDuration() time.Duration {
+	if spoken := d.recorder.SpeechDuration(); spoken > 0 {
+		return spoken
+	}
+	return time.Since(d.sessionStartTime)
+}
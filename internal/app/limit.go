@@ -0,0 +1,89 @@
+package app
+
+import (
+	"log"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// defaultMaxSessionLength stops sessions that run this long, e.g. after a missed key release
+const defaultMaxSessionLength = 5 * time.Minute
+
+// startSessionLimit counts a recording that just started, warns at 80% of the
+// maximum session length and stops the session at the limit, so a stuck
+// hotkey can't stream audio forever. Called with sessionMutex held.
+func (d *Daemon) startSessionLimit() {
+	limit := d.maxSessionLength()
+	recording := d.recordings.Add(1)
+
+	d.limitMutex.Lock()
+	defer d.limitMutex.Unlock()
+	d.stopLimitTimers()
+
+	d.limitWarning = time.AfterFunc(limit*8/10, func() {
+		if !d.isRecording(recording) {
+			return
+		}
+		d.beeper.PlayBeep("warning")
+		terminal.Printf("⏳ Recording stops in %v (max session length)\n", (limit - limit*8/10).Round(time.Second))
+	})
+	d.limitStop = time.AfterFunc(limit, func() {
+		d.stopRecording(recording, func() {
+			log.Printf("[SESSION] Max session length of %v reached, stopping", limit)
+			terminal.Printf("⏹️  Max session length of %v reached - transcribing what was recorded\n", limit)
+		})
+	})
+}
+
+// isRecording reports whether the numbered recording is still running
+func (d *Daemon) isRecording(recording uint64) bool {
+	return d.recordings.Load() == recording && d.recorder.IsRecording()
+}
+
+// stopRecording stops the numbered recording like a hotkey release, first
+// calling announce, unless it has already ended
+func (d *Daemon) stopRecording(recording uint64, announce func()) {
+	d.sessionMutex.Lock()
+	defer d.sessionMutex.Unlock()
+	if !d.isRecording(recording) {
+		return
+	}
+	announce()
+	d.release()
+}
+
+// stopSessionLimit cancels the timers once the session has ended
+func (d *Daemon) stopSessionLimit() {
+	d.limitMutex.Lock()
+	defer d.limitMutex.Unlock()
+	d.stopLimitTimers()
+}
+
+func (d *Daemon) stopLimitTimers() {
+	if d.limitWarning != nil {
+		d.limitWarning.Stop()
+		d.limitWarning = nil
+	}
+	if d.limitStop != nil {
+		d.limitStop.Stop()
+		d.limitStop = nil
+	}
+}
+
+// setEndOfSpeech applies auto_stop_silence_ms. Only sessions started with the
+// hotkey end on a pause; t2 capture records until it's stopped.
+func (d *Daemon) setEndOfSpeech(cfg *config.Config) {
+	d.recorder.SetEndOfSpeech(time.Duration(cfg.AutoStopSilenceMs)*time.Millisecond, d.handleEndOfSpeech)
+}
+
+// handleEndOfSpeech finishes the session once the user has paused after
+// speaking, with auto_stop_silence_ms, even if the hotkey is still held; the
+// eventual release then finds nothing recording and does nothing
+func (d *Daemon) handleEndOfSpeech() {
+	d.stopRecording(d.recordings.Load(), func() {
+		d.logSession("Auto-stopped after a pause in speech")
+		terminal.Println("⏹️  Pause detected - transcribing")
+	})
+}
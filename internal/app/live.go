@@ -0,0 +1,121 @@
+package app
+
+import (
+	"log"
+	"sync"
+
+	"github.com/bezmoradi/t2/internal/formatting"
+)
+
+// liveTyper types a session's transcript into the focused app while it is
+// spoken, revising what it typed with backspaces when a partial changes
+type liveTyper struct {
+	mu        sync.Mutex
+	clipboard Clipboard
+	join      func(string) string // Fits text to what was in front of the cursor when the session started
+	opts      formatting.Options  // For the app the session started in
+	typed     []rune
+	finished  bool
+}
+
+// Update makes the typed text match text, deleting and retyping only what differs
+func (l *liveTyper) Update(text string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.update(l.join(text))
+}
+
+// Finish revises the typed text to the final transcript, stops further
+// updates and returns what ended up in the app
+func (l *liveTyper) Finish(text string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.update(l.join(text))
+	l.finished = true
+	return string(l.typed)
+}
+
+// Discard deletes everything typed, unless the session already finished.
+// It's safe to call on a nil liveTyper.
+func (l *liveTyper) Discard() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.update("")
+	l.finished = true
+}
+
+func (l *liveTyper) update(text string) {
+	if l.finished {
+		return
+	}
+
+	target := []rune(text)
+	common := 0
+	for common < len(l.typed) && common < len(target) && l.typed[common] == target[common] {
+		common++
+	}
+
+	if extra := len(l.typed) - common; extra > 0 {
+		if err := l.clipboard.Backspace(extra); err != nil {
+			log.Printf("[LIVE] Failed to delete: %v", err)
+			return
+		}
+		l.typed = l.typed[:common]
+	}
+	if common < len(target) {
+		if err := l.clipboard.Type(string(target[common:])); err != nil {
+			log.Printf("[LIVE] Failed to type: %v", err)
+			return
+		}
+	}
+	l.typed = target
+}
+
+// startLiveTyping begins typing partial transcripts for the session that is
+// starting, if live_typing is on and nothing else needs the whole transcript
+// first. Wake-word sessions aren't typed, as they start with the wake word.
+func (d *Daemon) startLiveTyping(wakeSession bool) {
+	if !d.liveTyping() || d.composeMode() || d.clipboardOnly() || wakeSession || d.protectedApp() != "" {
+		return
+	}
+
+	opts := d.formattingOptions(d.formattingApp())
+	join := func(text string) string { return text }
+	if d.smartJoin() {
+		if before, ok := d.clipboard.TextBeforeCursor(); ok {
+			join = func(text string) string {
+				if text == "" {
+					return ""
+				}
+				return formatting.JoinAfter(text, before, opts)
+			}
+		}
+	}
+
+	d.liveMutex.Lock()
+	defer d.liveMutex.Unlock()
+	d.live = &liveTyper{clipboard: d.clipboard, join: join, opts: opts}
+}
+
+// takeLiveTyper ends live updates for the session and returns its typer, nil if none
+func (d *Daemon) takeLiveTyper() *liveTyper {
+	d.liveMutex.Lock()
+	defer d.liveMutex.Unlock()
+	live := d.live
+	d.live = nil
+	return live
+}
+
+// updateLiveTyping types the latest transcript of a live session
+func (d *Daemon) updateLiveTyping() {
+	d.liveMutex.Lock()
+	live := d.live
+	d.liveMutex.Unlock()
+
+	if live != nil {
+		live.Update(formatting.Apply(d.currentProcessor().LiveTranscript(), live.opts))
+	}
+}
@@ -0,0 +1,14 @@
+package app
+
+import "github.com/bezmoradi/t2/internal/formatting"
+
+// pasteText pastes text into the active app, adding rendered HTML and RTF
+// flavors when rich_paste is on and the text contains Markdown
+func (d *Daemon) pasteText(text string) error {
+	if d.richPaste() {
+		if html, ok := formatting.RenderMarkdown(text); ok {
+			return d.clipboard.PasteRich(text, html)
+		}
+	}
+	return d.clipboard.Paste(text)
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/audio"
+	"github.com/bezmoradi/t2/internal/power"
+)
+
+const (
+	// deviceCheckInterval is how often the audio devices are checked for changes
+	deviceCheckInterval = 2 * time.Second
+
+	// sleepFinishTimeout bounds how long sleep waits for a session's transcript
+	sleepFinishTimeout = 3 * time.Second
+)
+
+// watchPower releases the microphone and connection before sleep and brings
+// audio back up after wake, since both are dead by then anyway
+func (d *Daemon) watchPower() {
+	if err := power.Watch(d.onSleep, d.onWake); err != nil {
+		log.Printf("[POWER] Not watching sleep/wake: %v", err)
+	}
+}
+
+func (d *Daemon) onSleep() {
+	log.Printf("[POWER] System going to sleep, closing audio and connection")
+
+	// A recording is stopped like a release, so what was said still gets
+	// transcribed if it can arrive before the connection is closed
+	d.sessionMutex.Lock()
+	d.release()
+	d.sessionMutex.Unlock()
+	d.queueMutex.Lock()
+	finishing := d.finishing
+	d.queueMutex.Unlock()
+	if finishing != nil {
+		select {
+		case <-finishing:
+		case <-time.After(sleepFinishTimeout):
+		}
+	}
+
+	if d.monitor != nil {
+		d.monitor.Stop()
+	}
+
+	if d.standbyEnabled() {
+		d.transcriptClient.DisableStandby()
+	}
+	if d.transcriptClient.IsConnected() {
+		d.transcriptClient.Close()
+	}
+}
+
+func (d *Daemon) onWake() {
+	log.Printf("[POWER] System woke up, re-initializing audio")
+	d.resetAudio()
+
+	// The next press reconnects on its own; only the standby needs a nudge
+	if d.standbyEnabled() {
+		d.transcriptClient.EnableStandby(d.apiKey)
+	}
+}
+
+// watchInputDevices re-initializes audio when the default input device
+// changes or devices come and go, e.g. when AirPods connect
+func (d *Daemon) watchInputDevices(ctx context.Context) {
+	last := audio.CurrentDevices()
+	ticker := time.NewTicker(deviceCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Switching devices mid-session would cut the recording short; catch up afterwards
+			if d.recorder.IsRecording() {
+				continue
+			}
+			if current := audio.CurrentDevices(); current != last {
+				log.Printf("[AUDIO] Audio devices changed (%+v -> %+v), re-initializing", last, current)
+				last = current
+				d.resetAudio()
+			}
+		}
+	}
+}
+
+// resetAudio restarts PortAudio so the default input device is picked up again,
+// pausing the wake-word monitor while it does
+func (d *Daemon) resetAudio() {
+	if d.monitor != nil {
+		d.monitor.Stop()
+	}
+
+	if err := d.recorder.RestartAudio(); err != nil {
+		log.Printf("[AUDIO] Failed to re-initialize audio: %v", err)
+	}
+
+	if d.monitor != nil {
+		if err := d.monitor.Start(); err != nil {
+			log.Printf("[AUDIO] Failed to restart the wake-word monitor: %v", err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package app
+
+import "github.com/bezmoradi/t2/internal/transcription"
+
+// startProcessor gives the recording that is starting a processor of its own,
+// so transcripts of an earlier recording can't end up in it
+func (d *Daemon) startProcessor() *transcription.Processor {
+	processor := transcription.NewProcessor(d.currentSessionID(), d.trailingSuffix())
+
+	d.processorMutex.Lock()
+	defer d.processorMutex.Unlock()
+	d.processor = processor
+	return processor
+}
+
+// currentProcessor returns the processor of the latest recording, which
+// incoming transcripts belong to
+func (d *Daemon) currentProcessor() *transcription.Processor {
+	d.processorMutex.Lock()
+	defer d.processorMutex.Unlock()
+	return d.processor
+}
+
+// endTurn ends the turn of the latest recording in a persistent session.
+// Transcripts keep going to its processor until the final turn arrives, so
+// a late one can't end up in the next recording.
+func (d *Daemon) endTurn() error {
+	d.processorMutex.Lock()
+	d.endingProcessor = d.processor
+	d.processorMutex.Unlock()
+	return d.transcriptClient.EndTurn()
+}
+
+// turnProcessor returns the processor incoming transcripts belong to: that of
+// an ended turn still waiting for its final turn, or else the latest one
+func (d *Daemon) turnProcessor() *transcription.Processor {
+	d.processorMutex.Lock()
+	defer d.processorMutex.Unlock()
+	if d.endingProcessor != nil {
+		return d.endingProcessor
+	}
+	return d.processor
+}
+
+// takeTurnProcessor returns the processor a termination or final turn
+// belongs to; later transcripts go to the latest recording again
+func (d *Daemon) takeTurnProcessor() *transcription.Processor {
+	d.processorMutex.Lock()
+	defer d.processorMutex.Unlock()
+	if processor := d.endingProcessor; processor != nil {
+		d.endingProcessor = nil
+		return processor
+	}
+	return d.processor
+}
+
+// dropEndingTurn forgets an ended turn on a new connection, whose final turn
+// can never arrive
+func (d *Daemon) dropEndingTurn() {
+	d.processorMutex.Lock()
+	defer d.processorMutex.Unlock()
+	d.endingProcessor = nil
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/events"
+	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// protectedApp returns the bundle ID of the frontmost app if T2 must never
+// paste into it, or "" if pasting is fine
+func (d *Daemon) protectedApp() string {
+	d.configMutex.Lock()
+	cfg := d.config
+	d.configMutex.Unlock()
+
+	if len(cfg.NeverPasteApps) == 0 {
+		return ""
+	}
+	if bundleID := d.clipboard.FrontmostBundleID(); cfg.NeverPasteInto(bundleID) {
+		return bundleID
+	}
+	return ""
+}
+
+// keptOutOfApp copies or holds text instead of pasting it when the frontmost
+// app is on never_paste_apps, and reports whether it did
+func (d *Daemon) keptOutOfApp(text string, confidence float64, sessionID string) bool {
+	bundleID := d.protectedApp()
+	if bundleID == "" {
+		return false
+	}
+
+	if d.neverPasteAction() == config.NeverPasteHold {
+		d.pending = &pendingTranscript{
+			text:              text,
+			confidence:        confidence,
+			recordingDuration: d.spoken,
+			sessionID:         sessionID,
+		}
+		terminal.Printf("🚫 Not pasting into %s - held:\n", bundleID)
+		terminal.Printf("   %q\n", text)
+		terminal.Println("💡 Switch to another app and quick-press the hotkey to paste it")
+		return true
+	}
+
+	d.pending = nil
+	if d.copyInstead(text) {
+		terminal.Printf("📋 Not pasting into %s - copied to the clipboard instead\n", bundleID)
+	}
+	return true
+}
+
+// copyInstead puts text on the clipboard without pasting it
+func (d *Daemon) copyInstead(text string) bool {
+	if err := d.clipboard.Copy(text); err != nil {
+		terminal.Printf("❌ Copy failed: %v\n", err)
+		d.noteError("Copy failed: " + err.Error())
+		d.beeper.PlayBeep("error")
+		return false
+	}
+	d.setLastTranscript(text)
+	return true
+}
+
+// copyTranscript delivers a session's transcript through the clipboard only,
+// for clipboard_only or a release with Command held, and reports whether it did
+func (d *Daemon) copyTranscript(text string, recordingDuration time.Duration, details metrics.SessionDetails) bool {
+	if !d.copyInstead(text) {
+		return false
+	}
+	terminal.Printf("📋 Copied %d words to the clipboard - press Cmd+V to paste\n", len(strings.Fields(text)))
+
+	if details.SessionID == "" {
+		details.SessionID = d.currentSessionID()
+	}
+	details.Tag = d.sessionTag()
+
+	d.counters.RecordSession(text, details.Latency)
+	d.emit(events.Event{Type: events.Finished, SessionID: details.SessionID, DurationMs: recordingDuration.Milliseconds(),
+		Words: len(strings.Fields(text)), App: details.App})
+	if _, err := d.metricsManager.RecordSession(text, recordingDuration, details); err != nil {
+		terminal.Printf("⚠️  Warning: Failed to record session metrics: %v\n", err)
+	}
+	return true
+}
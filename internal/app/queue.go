@@ -0,0 +1,160 @@
+package app
+
+import (
+	"log"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/events"
+	"github.com/bezmoradi/t2/internal/session"
+	"github.com/bezmoradi/t2/internal/terminal"
+	"github.com/bezmoradi/t2/internal/tracing"
+	"github.com/bezmoradi/t2/internal/transcription"
+)
+
+// stoppedSession is what finishSession needs to know about a recording that
+// passed the checks in OnRelease
+type stoppedSession struct {
+	processor         *transcription.Processor
+	sessionID         string
+	live              *liveTyper
+	trace             *tracing.Trace
+	wakeSession       bool
+	persistent        bool
+	submitAfterPaste  bool
+	copyOnly          bool
+	translate         bool
+	isQuickPress      bool
+	recordingDuration time.Duration
+	maxRMS            float64
+}
+
+// log logs a daemon event for the stopped session
+func (s *stoppedSession) log(format string, args ...any) {
+	session.Printf(s.sessionID, "[SESSION] "+format, args...)
+}
+
+// startFinishing marks a session as being finished in the background
+func (d *Daemon) startFinishing() {
+	d.queueMutex.Lock()
+	defer d.queueMutex.Unlock()
+	d.finishing = make(chan struct{})
+}
+
+// waitForFinish blocks until the session finishing in the background, if any,
+// is done and a recording queued behind it has been streamed. It returns false
+// if the queued recording had to be dropped.
+func (d *Daemon) waitForFinish() bool {
+	d.queueMutex.Lock()
+	finishing := d.finishing
+	d.queueMutex.Unlock()
+	if finishing != nil {
+		<-finishing
+	}
+
+	d.queueMutex.Lock()
+	defer d.queueMutex.Unlock()
+	dropped := d.queueDropped
+	d.queueDropped = false
+	return !dropped
+}
+
+// sendAudio streams a chunk, or holds it while the recording is queued behind
+// a session that is still waiting for its transcript
+func (d *Daemon) sendAudio(chunk []byte) error {
+	d.queueMutex.Lock()
+	defer d.queueMutex.Unlock()
+	if d.queued {
+		// The recorder reuses its buffers, so keep a copy
+		d.queuedAudio = append(d.queuedAudio, append([]byte(nil), chunk...))
+		return nil
+	}
+	return d.transcriptClient.SendAudio(chunk)
+}
+
+// queueSession starts recording while the previous session is still being
+// finished; its audio is held until sessionFinished streams it. Called with
+// queueMutex held.
+func (d *Daemon) queueSession() {
+	d.queued = true
+	d.queuedWake = d.wakeSession
+	d.queuedAudio = nil
+	d.queuedSessionID = session.NewID()
+	d.beeper.PlayBeep("start")
+
+	d.pressTime = time.Now()
+	d.sessionStartTime = time.Now()
+	d.trace = tracing.NewTrace("session")
+	d.trace.SetRootAttr("session.queued", true)
+
+	if err := d.recorder.Start(d.runCtx); err != nil {
+		d.trace.End()
+		d.queued = false
+		terminal.Printf("❌ Recording failed: %v\n", err)
+		d.noteError("Recording failed: " + err.Error())
+		d.beeper.PlayBeep("error")
+		terminal.Println()
+		return
+	}
+	d.trace.Stage("record")
+	d.startSessionLimit()
+	d.emit(events.Event{Type: events.Started, SessionID: d.queuedSessionID})
+	log.Printf("[SESSION] Hotkey pressed while the previous session was finishing - recording queued")
+}
+
+// sessionFinished ends a background finish, streaming the audio of a
+// recording that was queued behind it
+func (d *Daemon) sessionFinished() {
+	defer func() {
+		d.queueMutex.Lock()
+		defer d.queueMutex.Unlock()
+		if d.finishing != nil {
+			close(d.finishing)
+			d.finishing = nil
+		}
+	}()
+
+	d.queueMutex.Lock()
+	queued, wake, trace, id := d.queued, d.queuedWake, d.trace, d.queuedSessionID
+	d.queueMutex.Unlock()
+	if !queued {
+		return
+	}
+
+	// The recording keeps being held while we connect
+	d.setSessionID(id)
+	d.logSession("Hotkey pressed while the previous session was finishing")
+	trace.SetRootAttr("session.id", d.currentSessionID())
+	if !d.ensureConnected() {
+		trace.End()
+		d.queueMutex.Lock()
+		d.queued = false
+		d.queuedAudio = nil
+		d.queueDropped = true
+		d.queueMutex.Unlock()
+		d.recorder.Stop()
+		d.stopSessionLimit()
+		terminal.Println()
+		return
+	}
+	d.startProcessor()
+	d.transcriptClient.ResetSessionStats()
+
+	// Stream under the lock so new audio can't overtake what was held
+	d.queueMutex.Lock()
+	queuedAudio := d.queuedAudio
+	for _, chunk := range queuedAudio {
+		if err := d.transcriptClient.SendAudio(chunk); err != nil {
+			d.logSession("Error sending queued audio: %v", err)
+			break
+		}
+	}
+	d.queued = false
+	d.queuedAudio = nil
+	d.queueMutex.Unlock()
+	d.logSession("Streamed %d queued audio chunks", len(queuedAudio))
+	trace.SetAttr("audio.queued_chunks", len(queuedAudio))
+
+	if d.recorder.IsRecording() {
+		d.startLiveTyping(wake)
+	}
+}
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"log"
+
+	"github.com/bezmoradi/t2/internal/audio"
+	"github.com/bezmoradi/t2/internal/config"
+)
+
+// saveAudio writes a session's audio to the recordings archive
+func (d *Daemon) saveAudio(pcm []byte) {
+	dir, err := config.GetRecordingsDir()
+	if err != nil {
+		log.Printf("Error getting recordings directory: %v", err)
+		return
+	}
+
+	retentionDays, maxMB := d.audioArchiveLimits()
+	if _, err := audio.NewArchive(dir, retentionDays, maxMB).Save(pcm); err != nil {
+		log.Printf("Error saving session audio: %v", err)
+	}
+}
@@ -0,0 +1,437 @@
+package app
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/audio"
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/formatting"
+	"github.com/bezmoradi/t2/internal/history"
+	"github.com/bezmoradi/t2/internal/i18n"
+	"github.com/bezmoradi/t2/internal/llm"
+	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/terminal"
+	"github.com/bezmoradi/t2/internal/transcription"
+)
+
+const (
+	// configPollInterval is how often the config file is checked for edits
+	configPollInterval = 2 * time.Second
+
+	defaultQuickPressThreshold = 800 * time.Millisecond
+)
+
+// applyConfig installs cfg and recomputes the settings derived from it
+func (d *Daemon) applyConfig(cfg *config.Config) {
+	d.configMutex.Lock()
+	d.config = cfg
+
+	// Apply quick-press overrides so short dictations like "yes" can get through
+	d.quickPressThreshold = defaultQuickPressThreshold
+	if cfg.QuickPressThresholdMs > 0 {
+		d.quickPressThreshold = time.Duration(cfg.QuickPressThresholdMs) * time.Millisecond
+	}
+	d.quickPressMode = config.QuickPressDuration
+	if cfg.QuickPressMode == config.QuickPressTranscript {
+		d.quickPressMode = config.QuickPressTranscript
+	}
+	d.configMutex.Unlock()
+
+	d.transcriptClient.SetPersistentSession(cfg.PersistentSession)
+	d.recorder.SetChunkDuration(time.Duration(cfg.AudioChunkMs) * time.Millisecond)
+	d.recorder.SetQueuePolicy(cfg.AudioQueuePolicy)
+	d.recorder.SetInputChannels(cfg.InputChannels)
+	d.recorder.SetSilenceThreshold(cfg.SilenceThreshold)
+	if d.hotkeyManager != nil {
+		d.setEndOfSpeech(cfg)
+	}
+	d.recorder.SetCapture(cfg.SaveAudio)
+	if _, err := time.Parse("15:04", cfg.ReportTime); cfg.ReportTime != "" && err != nil {
+		terminal.Printf("⚠️  Warning: Ignoring report_time %q (use HH:MM, e.g. \"18:00\")\n", cfg.ReportTime)
+	}
+	if cfg.ReportPeriod != "" && cfg.ReportPeriod != config.ReportDaily && cfg.ReportPeriod != config.ReportWeekly {
+		terminal.Printf("⚠️  Warning: Unknown report_period %q, sending daily reports\n", cfg.ReportPeriod)
+	}
+	for _, block := range cfg.FocusBlocks {
+		if _, err := parseFocusBlock(block); err != nil {
+			terminal.Printf("⚠️  Warning: Ignoring focus block %q: %v\n", block, err)
+		}
+	}
+	d.events.Configure(cfg.EventsURL, cfg.EventsScript)
+	d.events.ConfigureTrackers(cfg.ActivityWatchURL, cfg.RescueTimeKey)
+	if _, ok := formatting.LookupConventions(cfg.FormatLocale); cfg.FormatLocale != "" && !ok {
+		terminal.Printf("⚠️  Warning: Unknown format_locale %q, using US conventions\n", cfg.FormatLocale)
+	}
+	// Simulations don't paste, so they leave the history alone
+	if !d.dryRun {
+		if err := d.history.Configure(cfg.HistorySize, cfg.HistoryIdentity); err != nil {
+			terminal.Printf("⚠️  Warning: Transcript history won't be saved: %v\n", err)
+		}
+	}
+	// Encryption only covers the transcript history
+	if cfg.SaveAudio && (cfg.HistoryIdentity != "" || os.Getenv(history.PassphraseEnvVar) != "") {
+		terminal.Println("⚠️  Warning: Audio saved with save_audio isn't encrypted, only the transcript history is")
+	}
+	d.configureSounds(cfg)
+
+	proxyChanged, err := d.transcriptClient.SetProxy(cfg.ProxyURL)
+	if err != nil {
+		terminal.Printf("⚠️  Warning: Ignoring proxy_url: %v\n", err)
+	}
+	endpointChanged := d.transcriptClient.SetStreamURL(cfg.StreamingURL)
+	punctuationChanged := d.transcriptClient.SetFormatTurns(!cfg.DisablePunctuation)
+	languageChanged := d.transcriptClient.SetLanguageDetection(cfg.DetectLanguage)
+	turnsChanged, err := d.transcriptClient.SetTurnDetection(transcription.TurnDetection{
+		ConfidenceThreshold: cfg.EndOfTurnConfidence,
+		MinSilenceMs:        cfg.MinTurnSilenceMs,
+		MaxSilenceMs:        cfg.MaxTurnSilenceMs,
+	})
+	if err != nil {
+		terminal.Printf("⚠️  Warning: Ignoring turn detection settings: %v\n", err)
+	}
+
+	// Punctuation, language, turn detection and endpoint changes need a new
+	// connection; drop the current one so the next press reconnects with the new settings
+	if (proxyChanged || endpointChanged || punctuationChanged || languageChanged || turnsChanged) && d.transcriptClient.IsConnected() {
+		d.transcriptClient.Close()
+	}
+}
+
+// configureSounds passes the sound settings to the beeper, warning about files it can't play
+func (d *Daemon) configureSounds(cfg *config.Config) {
+	sounds, ok := d.beeper.(SoundConfigurer)
+	if !ok {
+		return
+	}
+
+	for beepType, file := range cfg.BeepSounds {
+		if !slices.Contains(audio.BeepTypes, beepType) {
+			terminal.Printf("⚠️  Warning: Ignoring beep_sounds entry %q (use %s)\n", beepType, strings.Join(audio.BeepTypes, ", "))
+		} else if _, err := os.Stat(file); err != nil {
+			terminal.Printf("⚠️  Warning: Can't use %s sound: %v\n", beepType, err)
+		}
+	}
+	feedback := cfg.Feedback
+	if feedback != "" && !slices.Contains(audio.FeedbackModes, feedback) {
+		terminal.Printf("⚠️  Warning: Ignoring feedback %q (use %s)\n", feedback, strings.Join(audio.FeedbackModes, ", "))
+		feedback = ""
+	}
+	if cfg.BeepVolume < 0 || cfg.BeepVolume > 100 {
+		terminal.Printf("⚠️  Warning: beep_volume should be between 1 and 100, got %d\n", cfg.BeepVolume)
+	}
+
+	sounds.Configure(audio.SoundSettings{
+		Disabled: cfg.DisableBeeps,
+		Feedback: feedback,
+		Volume:   cfg.BeepVolume,
+		Files:    cfg.BeepSounds,
+	})
+}
+
+// formattingApp returns the frontmost app to pick formatting options for.
+// It's only looked up if number or entity formatting differs per app, as
+// every lookup starts osascript; callers do it once per session.
+func (d *Daemon) formattingApp() string {
+	d.configMutex.Lock()
+	perApp := len(d.config.NumberStyleApps) > 0 || len(d.config.FormatEntitiesApps) > 0
+	d.configMutex.Unlock()
+	if !perApp {
+		return ""
+	}
+	return d.clipboard.FrontmostApp()
+}
+
+// formattingOptions returns how to format a transcript dictated into app
+func (d *Daemon) formattingOptions(app string) formatting.Options {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return formatting.Options{
+		Lowercase:       d.config.Lowercase,
+		LowercaseFirst:  d.config.LowercaseFirst,
+		FilterProfanity: d.config.FilterProfanity,
+		RedactPII:       d.config.RedactPII,
+		Numbers:         d.config.NumberStyleFor(app),
+		Entities:        d.config.EntitiesFor(app),
+		Locale:          d.config.FormatLocale,
+	}
+}
+
+func (d *Daemon) audioArchiveLimits() (int, int) {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.AudioRetentionDays, d.config.AudioMaxMB
+}
+
+func (d *Daemon) maxSessionLength() time.Duration {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	if d.config.MaxSessionSeconds > 0 {
+		return time.Duration(d.config.MaxSessionSeconds) * time.Second
+	}
+	return defaultMaxSessionLength
+}
+
+// reportSettings returns report_time, normalized to "15:04" ("" when off or
+// invalid), and report_period
+func (d *Daemon) reportSettings() (string, string) {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	reportTime, err := time.Parse("15:04", d.config.ReportTime)
+	if err != nil {
+		return "", ""
+	}
+	return reportTime.Format("15:04"), d.config.ReportPeriod
+}
+
+// focusBlocks returns the valid focus_blocks
+func (d *Daemon) focusBlocks() []focusBlock {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	var blocks []focusBlock
+	for _, text := range d.config.FocusBlocks {
+		if block, err := parseFocusBlock(text); err == nil {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// trailingSuffix is what processors append to a consumed transcript
+func (d *Daemon) trailingSuffix() string {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.TrailingSuffix()
+}
+
+// releaseGrace is how long recording continues after the hotkey is released
+func (d *Daemon) releaseGrace() time.Duration {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return time.Duration(d.config.ReleaseGraceMs) * time.Millisecond
+}
+
+func (d *Daemon) neverPasteAction() string {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.NeverPasteAction
+}
+
+func (d *Daemon) liveTyping() bool {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.LiveTyping
+}
+
+func (d *Daemon) richPaste() bool {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.RichPaste
+}
+
+func (d *Daemon) clipboardOnly() bool {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.ClipboardOnly
+}
+
+func (d *Daemon) smartJoin() bool {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.SmartJoin
+}
+
+func (d *Daemon) composeMode() bool {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.ComposeMode
+}
+
+func (d *Daemon) standbyEnabled() bool {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.StandbyConnection
+}
+
+func (d *Daemon) voiceCommandPhrases() map[string]string {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+
+	if !d.config.VoiceCommands {
+		return nil
+	}
+
+	phrases := make(map[string]string)
+	for phrase, action := range formatting.DefaultCommandPhrases {
+		phrases[phrase] = action
+	}
+	for phrase, action := range d.config.VoiceCommandPhrases {
+		phrases[strings.ToLower(phrase)] = action
+	}
+	return phrases
+}
+
+func (d *Daemon) retentionDays() int {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.RetentionDays
+}
+
+// translateTarget returns the language to translate into, "" when translation is off
+func (d *Daemon) translateTarget() string {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.TranslateTo
+}
+
+func (d *Daemon) translateAlways() bool {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.TranslateTo != "" && d.config.TranslateAlways
+}
+
+func (d *Daemon) llmClient() (*llm.Client, error) {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return llm.NewClient(d.config.LLMProvider, d.config.LLMModel, d.config.LLMAPIKey)
+}
+
+func (d *Daemon) snippets() map[string]string {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.Snippets
+}
+
+func (d *Daemon) sessionTag() string {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.SessionTag
+}
+
+func (d *Daemon) wakeWord() string {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.WakeWord
+}
+
+func (d *Daemon) persistentSession() bool {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.PersistentSession
+}
+
+func (d *Daemon) minConfidence() float64 {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.MinConfidence
+}
+
+func (d *Daemon) autoEnterFor(app string) bool {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.config.ShouldAutoEnter(app)
+}
+
+func (d *Daemon) quickPressSettings() (time.Duration, string) {
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	return d.quickPressThreshold, d.quickPressMode
+}
+
+// ReloadConfig re-reads config.json and typing speed settings and applies them
+// in place, keeping the current AssemblyAI connection. The config watcher and
+// SIGHUP both call it, so reloads run one at a time.
+func (d *Daemon) ReloadConfig() {
+	d.reloadMutex.Lock()
+	defer d.reloadMutex.Unlock()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		terminal.Printf("⚠️  Warning: Failed to reload config, keeping current settings: %v\n", err)
+		return
+	}
+
+	d.configMutex.Lock()
+	previous := d.config
+	d.configMutex.Unlock()
+
+	d.applyConfig(cfg)
+
+	// Typing speed lives in the metrics settings; keep the current value if they're unreadable
+	d.metricsManager.ReloadSettings()
+
+	// The commit hotkey only exists in compose mode
+	if d.hotkeyManager != nil {
+		d.hotkeyManager.SetCommitEnabled(cfg.ComposeMode)
+	}
+	if !cfg.ComposeMode {
+		d.dropDraft()
+	}
+
+	if cfg.Locale != previous.Locale {
+		if err := i18n.SetLocale(cfg.Locale); err != nil {
+			terminal.Printf("⚠️  Warning: Ignoring locale: %v\n", err)
+		}
+	}
+	if cfg.Timezone != previous.Timezone {
+		if err := metrics.SetTimezone(cfg.Timezone); err != nil {
+			terminal.Printf("⚠️  Warning: Ignoring timezone: %v\n", err)
+		}
+	}
+
+	// Toggle the standby connection to match
+	if cfg.StandbyConnection && !previous.StandbyConnection {
+		d.transcriptClient.EnableStandby(d.apiKey)
+	} else if !cfg.StandbyConnection && previous.StandbyConnection {
+		d.transcriptClient.DisableStandby()
+	}
+
+	// Restart the remote trigger if its address or token changed
+	if cfg.RemoteListenAddr != previous.RemoteListenAddr || cfg.RemoteToken != previous.RemoteToken {
+		if d.remoteServer != nil {
+			d.remoteServer.Stop()
+			d.remoteServer = nil
+		}
+		if err := d.startRemoteServer(d.runCtx); err != nil {
+			terminal.Printf("⚠️  Warning: Remote trigger disabled: %v\n", err)
+		}
+	}
+
+	terminal.Println("🔄 Configuration reloaded")
+	terminal.Println()
+}
+
+// watchConfig reloads the config whenever config.json's modification time changes
+func (d *Daemon) watchConfig(ctx context.Context) {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return
+	}
+
+	lastModified := modTime(configPath)
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if modified := modTime(configPath); !modified.Equal(lastModified) {
+				lastModified = modified
+				d.ReloadConfig()
+			}
+		}
+	}
+}
+
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
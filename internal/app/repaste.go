@@ -0,0 +1,39 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// setLastTranscript remembers the most recently pasted transcript and adds it
+// to the history for t2 pick
+func (d *Daemon) setLastTranscript(text string) {
+	d.lastMutex.Lock()
+	d.lastTranscript = text
+	d.lastMutex.Unlock()
+
+	if err := d.history.Add(text); err != nil {
+		terminal.Printf("⚠️  Warning: Failed to save transcript history: %v\n", err)
+	}
+}
+
+// LastTranscript returns the most recently pasted transcript, empty if none
+func (d *Daemon) LastTranscript() string {
+	d.lastMutex.Lock()
+	defer d.lastMutex.Unlock()
+	return d.lastTranscript
+}
+
+// Repaste pastes the last transcript again, e.g. after switching apps.
+// It implements remote.Repaster.
+func (d *Daemon) Repaste() error {
+	text := d.LastTranscript()
+	if text == "" {
+		return fmt.Errorf("nothing to paste yet")
+	}
+	if bundleID := d.protectedApp(); bundleID != "" {
+		return fmt.Errorf("pasting into %s is disabled by never_paste_apps", bundleID)
+	}
+	return d.pasteText(text)
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/metrics"
+)
+
+const (
+	// reportCheckInterval is how often the scheduler checks whether report_time has come
+	reportCheckInterval = 30 * time.Second
+
+	// reportWeekday is when weekly reports are sent, at the end of the work week
+	reportWeekday = time.Friday
+)
+
+// watchReports posts a notification summarizing the day or week at
+// report_time, once per day or week, until ctx is cancelled
+func (d *Daemon) watchReports(ctx context.Context) {
+	ticker := time.NewTicker(reportCheckInterval)
+	defer ticker.Stop()
+
+	var lastSent string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			// report_time is in the time zone statistics are counted in
+			now = now.In(metrics.Location())
+			reportTime, period := d.reportSettings()
+			if reportTime == "" || now.Format("15:04") != reportTime {
+				continue
+			}
+			if period == config.ReportWeekly && now.Weekday() != reportWeekday {
+				continue
+			}
+			if today := now.Format("2006-01-02"); today != lastSent {
+				lastSent = today
+				d.sendReport(period)
+			}
+		}
+	}
+}
+
+// sendReport posts the words, sessions and time saved of today or the past week
+func (d *Daemon) sendReport(period string) {
+	days := 1
+	label := "Today"
+	if period == config.ReportWeekly {
+		days = 7
+		label = "This week"
+	}
+
+	recent, err := d.metricsManager.GetRecentDays(days)
+	if err != nil {
+		log.Printf("[REPORT] Error reading statistics: %v", err)
+		return
+	}
+	var words, sessions int
+	var saved time.Duration
+	for _, day := range recent {
+		words += day.TotalWords
+		sessions += day.SessionCount
+		saved += day.TotalSaved
+	}
+
+	message := fmt.Sprintf("%s: %d words in %d sessions, %s saved", label, words, sessions,
+		metrics.NewTimeFormatter().FormatDurationShort(saved))
+	log.Printf("[REPORT] %s", message)
+	d.notifier.Notify(message)
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/audio"
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/history"
+	"github.com/bezmoradi/t2/internal/metrics"
+)
+
+// retentionCheckInterval is how often data past retention_days is purged
+const retentionCheckInterval = time.Hour
+
+// Purged counts what a purge deleted
+type Purged struct {
+	Days        int // Days of statistics
+	Transcripts int // Transcripts in the history
+	Recordings  int // Saved audio recordings
+}
+
+// PurgeBefore deletes statistics, transcript history and saved audio from
+// before cutoff. It backs t2 purge; the daemon applies retention_days itself.
+func PurgeBefore(cutoff time.Time) (Purged, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+
+	metricsDir, err := config.GetMetricsDir()
+	if err != nil {
+		return Purged{}, fmt.Errorf("failed to get metrics directory: %v", err)
+	}
+	manager, err := metrics.NewMetricsManager(metricsDir)
+	if err != nil {
+		return Purged{}, fmt.Errorf("failed to initialize metrics manager: %v", err)
+	}
+
+	historyPath, err := config.GetHistoryPath()
+	if err != nil {
+		return Purged{}, fmt.Errorf("failed to get history path: %v", err)
+	}
+	transcripts := history.New(historyPath)
+	if err := transcripts.Configure(cfg.HistorySize, cfg.HistoryIdentity); err != nil {
+		return Purged{}, err
+	}
+
+	recordingsDir, err := config.GetRecordingsDir()
+	if err != nil {
+		return Purged{}, fmt.Errorf("failed to get recordings directory: %v", err)
+	}
+	archive := audio.NewArchive(recordingsDir, cfg.AudioRetentionDays, cfg.AudioMaxMB)
+
+	return purgeBefore(cutoff, manager, transcripts, archive)
+}
+
+func purgeBefore(cutoff time.Time, manager *metrics.MetricsManager, transcripts *history.History, archive *audio.Archive) (Purged, error) {
+	var purged Purged
+	var err error
+	if purged.Days, err = manager.PurgeBefore(cutoff); err != nil {
+		return purged, fmt.Errorf("failed to purge statistics: %v", err)
+	}
+	if purged.Transcripts, err = transcripts.PurgeBefore(cutoff); err != nil {
+		return purged, fmt.Errorf("failed to purge transcript history: %v", err)
+	}
+	if purged.Recordings, err = archive.PurgeBefore(cutoff); err != nil {
+		return purged, fmt.Errorf("failed to purge recordings: %v", err)
+	}
+	return purged, nil
+}
+
+// watchRetention purges data older than retention_days on startup and every
+// hour after, so a daemon that runs for weeks keeps to it too
+func (d *Daemon) watchRetention(ctx context.Context) {
+	ticker := time.NewTicker(retentionCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		d.purgeExpired()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Daemon) purgeExpired() {
+	days := d.retentionDays()
+	if days <= 0 {
+		return
+	}
+
+	dir, err := config.GetRecordingsDir()
+	if err != nil {
+		log.Printf("[RETENTION] Error getting recordings directory: %v", err)
+		return
+	}
+	retentionDays, maxMB := d.audioArchiveLimits()
+	archive := audio.NewArchive(dir, retentionDays, maxMB)
+
+	purged, err := purgeBefore(time.Now().AddDate(0, 0, -days), d.metricsManager, d.history, archive)
+	if err != nil {
+		log.Printf("[RETENTION] %v", err)
+		return
+	}
+	if purged != (Purged{}) {
+		log.Printf("[RETENTION] Purged %d days of statistics, %d transcripts and %d recordings older than %d days",
+			purged.Days, purged.Transcripts, purged.Recordings, days)
+	}
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/audio"
+	"github.com/bezmoradi/t2/internal/formatting"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// simulateTimeout is how long to wait for the provider to finish after the
+// last chunk; there's no one waiting to paste, so this is generous
+const simulateTimeout = 10 * time.Second
+
+// simulation is what a WAV file transcribed through the pipeline
+type simulation struct {
+	raw        string // Transcript before formatting
+	text       string // What would be pasted
+	isFinal    bool   // false when only a partial transcript arrived
+	confidence float64
+	language   string        // Detected language, with detect_language
+	latency    time.Duration // End of audio to termination
+	commands   formatting.Commands
+	diagnosis  sessionDiagnosis // Why raw is empty, if it is
+}
+
+// Simulate feeds a 16 kHz mono WAV file through the same pipeline as a
+// dictation - provider, processor and formatting - and prints the result
+// instead of pasting it. Audio is sent in real time, just like the microphone.
+func (d *Daemon) Simulate(path string) error {
+	pcm, err := audio.ReadWAV(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	terminal.Printf("🎧 Streaming %.1fs of audio from %s\n", audio.PCMDuration(int64(len(pcm))), path)
+	result, err := d.transcribePCM(pcm)
+	if err != nil {
+		return err
+	}
+
+	if result.raw == "" {
+		result.diagnosis.print()
+		return nil
+	}
+
+	terminal.Printf("📝 Raw transcript: %q\n", result.raw)
+	if !result.isFinal {
+		terminal.Println("⚠️  Warning: No final transcript, showing the best partial one")
+	}
+	terminal.Printf("🎯 Confidence: %.2f, latency after end of audio: %v\n", result.confidence, result.latency.Round(time.Millisecond))
+	if result.confidence < d.minConfidence() {
+		terminal.Printf("🤔 Below min_confidence (%.2f) - this would be held for confirmation\n", d.minConfidence())
+	}
+	if result.language != "" {
+		terminal.Printf("🌍 Detected language: %s\n", result.language)
+	}
+	if result.commands.Undo {
+		terminal.Println("🗣️  Voice command: undo")
+	}
+	if result.commands.Send {
+		terminal.Println("🗣️  Voice command: send")
+	}
+	terminal.Printf("✅ Would paste: %q\n", result.text)
+	return nil
+}
+
+// transcribePCM sets up the pipeline, streams pcm in real time and returns
+// the transcript it produced
+func (d *Daemon) transcribePCM(pcm []byte) (*simulation, error) {
+	// Nothing is pasted, so the history is left alone; compare runs two of
+	// these at once, which would both rewrite it
+	d.dryRun = true
+	if _, err := d.initPipeline(); err != nil {
+		return nil, err
+	}
+
+	if err := d.transcriptClient.Connect(d.runCtx, d.apiKey); err != nil {
+		return nil, fmt.Errorf("failed to connect to AssemblyAI streaming API: %v", err)
+	}
+	defer d.transcriptClient.Close()
+
+	processor := d.startProcessor()
+	d.transcriptClient.ResetSessionStats()
+
+	chunkDuration := d.recorder.ChunkDuration()
+	chunkSize := int(chunkDuration.Seconds()*audio.SampleRate) * 2
+
+	ticker := time.NewTicker(chunkDuration)
+	defer ticker.Stop()
+	for offset := 0; offset < len(pcm); offset += chunkSize {
+		chunk := pcm[offset:min(offset+chunkSize, len(pcm))]
+		if err := d.transcriptClient.SendAudio(chunk); err != nil {
+			return nil, fmt.Errorf("failed to send audio: %v", err)
+		}
+		<-ticker.C
+	}
+
+	releaseTime := time.Now()
+	d.transcriptClient.Terminate()
+	select {
+	case <-processor.WaitForTermination():
+	case <-time.After(simulateTimeout):
+		terminal.Println("⚠️  Warning: No termination from AssemblyAI, using the transcript so far")
+	}
+
+	result := &simulation{
+		latency:    time.Since(releaseTime),
+		confidence: processor.GetConfidence(),
+		language:   d.transcriptClient.SessionLanguage(),
+	}
+	transcriptCount, terminated := processor.SessionStats()
+	result.raw, result.isFinal = processor.ConsumeTranscriptWithFallback()
+	result.text, result.commands = d.formatTranscript(result.raw, d.translateAlways(), d.formattingOptions(d.formattingApp()))
+	result.diagnosis = sessionDiagnosis{
+		chunksSent:  d.transcriptClient.SessionChunks(),
+		transcripts: transcriptCount,
+		terminated:  terminated,
+		connected:   d.transcriptClient.IsConnected(),
+	}
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"io"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/config"
+	"github.com/bezmoradi/t2/internal/metrics"
+	"github.com/bezmoradi/t2/internal/remote"
+	"github.com/bezmoradi/t2/internal/transcription"
+)
+
+// Status implements remote.StatusReporter
+func (d *Daemon) Status() remote.Status {
+	status := remote.Status{
+		Recording:     d.recorder.IsRecording(),
+		Connected:     d.transcriptClient.IsConnected(),
+		Connection:    d.transcriptClient.State().String(),
+		Provider:      transcription.ProviderName,
+		Profile:       config.GetProfile(),
+		UptimeSeconds: time.Since(d.startTime).Seconds(),
+	}
+	if today, err := d.metricsManager.GetTodayMetrics(); err == nil {
+		status.SessionsToday = today.SessionCount
+		status.WordsToday = today.TotalWords
+	}
+
+	d.errorMutex.Lock()
+	defer d.errorMutex.Unlock()
+	status.LastError = d.lastError
+	status.LastErrorAt = d.lastErrorAt
+	return status
+}
+
+// noteError remembers the latest failure for t2 status
+func (d *Daemon) noteError(message string) {
+	d.errorMutex.Lock()
+	defer d.errorMutex.Unlock()
+	d.lastError = message
+	d.lastErrorAt = time.Now()
+}
+
+// Stats implements remote.StatsReporter
+func (d *Daemon) Stats() (*metrics.Report, error) {
+	return d.metricsManager.GetReport()
+}
+
+// WriteMetrics implements remote.MetricsWriter
+func (d *Daemon) WriteMetrics(w io.Writer) {
+	d.counters.WritePrometheus(w)
+}
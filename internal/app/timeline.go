@@ -0,0 +1,32 @@
+package app
+
+import "github.com/bezmoradi/t2/internal/session"
+
+// beginSessionLog gives the new session an ID and tags the recorder and
+// client logs with it, and those of the processor started for it next, so
+// `t2 logs --session` can rebuild the timeline
+func (d *Daemon) beginSessionLog() {
+	d.setSessionID(session.NewID())
+}
+
+// setSessionID makes id the current session's and tags the logs with it
+func (d *Daemon) setSessionID(id string) {
+	d.sessionIDMutex.Lock()
+	d.sessionID = id
+	d.sessionIDMutex.Unlock()
+	d.recorder.SetSessionID(id)
+	d.transcriptClient.SetSessionID(id)
+}
+
+// currentSessionID returns the ID of the latest session. Sessions finished in
+// the background carry their own, see stoppedSession.
+func (d *Daemon) currentSessionID() string {
+	d.sessionIDMutex.Lock()
+	defer d.sessionIDMutex.Unlock()
+	return d.sessionID
+}
+
+// logSession logs a daemon event for the current session
+func (d *Daemon) logSession(format string, args ...any) {
+	session.Printf(d.currentSessionID(), "[SESSION] "+format, args...)
+}
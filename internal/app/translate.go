@@ -0,0 +1,47 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// translateTimeout keeps a slow model from holding up the paste for long
+const translateTimeout = 15 * time.Second
+
+// translateInstructions asks the model for a bare translation into %s
+const translateInstructions = `Translate the user's dictation into %[1]s.
+Reply with only the translation - no quotes, notes or explanations - keeping its meaning, tone, punctuation and line breaks.
+If it's already in %[1]s, reply with it unchanged.`
+
+// translate translates text into translate_to with the configured LLM. If
+// that fails the original is pasted, since losing the dictation would be worse.
+func (d *Daemon) translate(text string) string {
+	target := d.translateTarget()
+	if target == "" || text == "" {
+		return text
+	}
+
+	client, err := d.llmClient()
+	if err != nil {
+		terminal.Printf("⚠️  Warning: Not translating: %v\n", err)
+		return text
+	}
+	client.SetTimeout(translateTimeout)
+
+	start := time.Now()
+	translated, err := client.Complete(fmt.Sprintf(translateInstructions, target), text)
+	if err != nil || translated == "" {
+		d.logSession("Translation failed: %v", err)
+		terminal.Printf("⚠️  Warning: Translation failed, pasting the original: %v\n", err)
+		return text
+	}
+	d.logSession("Translated to %s in %v", target, time.Since(start).Round(time.Millisecond))
+	terminal.Printf("🌐 Translated to %s\n", target)
+
+	// Keep the trailing space or newline the processor added
+	return translated + text[len(strings.TrimRightFunc(text, unicode.IsSpace)):]
+}
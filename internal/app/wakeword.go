@@ -0,0 +1,52 @@
+package app
+
+import (
+	"time"
+
+	"github.com/bezmoradi/t2/internal/audio"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// startWakeWordMonitor starts always-listening mode if a wake word is configured
+func (d *Daemon) startWakeWordMonitor() error {
+	wakeWord := d.wakeWord()
+	if wakeWord == "" {
+		return nil
+	}
+
+	d.monitor = audio.NewMonitor(d.onWakeSpeech, d.onWakePause)
+	if err := d.monitor.Start(); err != nil {
+		d.monitor = nil
+		return err
+	}
+
+	terminal.Printf("👂 Always listening - start with \"%s\"\n", wakeWord)
+	return nil
+}
+
+// onWakeSpeech starts a session when the monitor hears speech
+func (d *Daemon) onWakeSpeech() {
+	if d.focusBlockEnd(time.Now()) != "" {
+		return
+	}
+
+	d.sessionMutex.Lock()
+	defer d.sessionMutex.Unlock()
+	if d.recorder.IsRecording() {
+		return
+	}
+	d.wakeSession = true
+	d.press()
+	if !d.recorder.IsRecording() {
+		d.wakeSession = false
+	}
+}
+
+// onWakePause ends a wake-word session once the speaker pauses
+func (d *Daemon) onWakePause() {
+	d.sessionMutex.Lock()
+	defer d.sessionMutex.Unlock()
+	if d.wakeSession {
+		d.release()
+	}
+}
@@ -0,0 +1,183 @@
+package audio
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// Recordings are named with milliseconds, so two sessions in the same
+	// second don't share a file. Parsing with archiveTimeFormat also reads
+	// the milliseconds, and older names without them.
+	archiveTimeFormat = "2006-01-02T15-04-05"
+	archiveNameFormat = archiveTimeFormat + ".000"
+	archiveExtension  = ".wav"
+
+	// Defaults when retention or size limits aren't configured
+	DefaultArchiveRetentionDays = 7
+	DefaultArchiveMaxMB         = 500
+)
+
+// Recording is a session saved to the archive
+type Recording struct {
+	Name     string
+	Path     string
+	Time     time.Time
+	Size     int64
+	Duration time.Duration
+}
+
+// Archive keeps each session's audio as a timestamped WAV file, pruning old
+// files by age and total size
+type Archive struct {
+	dir       string
+	retention time.Duration
+	maxBytes  int64
+}
+
+// NewArchive returns an archive in dir; zero limits use the defaults
+func NewArchive(dir string, retentionDays int, maxMB int) *Archive {
+	if retentionDays <= 0 {
+		retentionDays = DefaultArchiveRetentionDays
+	}
+	if maxMB <= 0 {
+		maxMB = DefaultArchiveMaxMB
+	}
+
+	return &Archive{
+		dir:       dir,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+		maxBytes:  int64(maxMB) * 1024 * 1024,
+	}
+}
+
+// Save writes pcm as a WAV named after the current time and prunes the archive
+func (a *Archive) Save(pcm []byte) (string, error) {
+	if err := os.MkdirAll(a.dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create recordings directory: %v", err)
+	}
+
+	path := filepath.Join(a.dir, time.Now().Format(archiveNameFormat)+archiveExtension)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create recording: %v", err)
+	}
+
+	if err := WriteWAV(file, pcm); err != nil {
+		file.Close()
+		os.Remove(path)
+		return "", fmt.Errorf("failed to write recording: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write recording: %v", err)
+	}
+
+	return path, a.Prune()
+}
+
+// List returns the archived recordings, oldest first
+func (a *Archive) List() ([]Recording, error) {
+	entries, err := os.ReadDir(a.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var recordings []Recording
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, archiveExtension) {
+			continue
+		}
+
+		recorded, err := time.ParseInLocation(archiveTimeFormat, strings.TrimSuffix(name, archiveExtension), time.Local)
+		if err != nil {
+			continue // Not one of ours
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		recordings = append(recordings, Recording{
+			Name:     name,
+			Path:     filepath.Join(a.dir, name),
+			Time:     recorded,
+			Size:     info.Size(),
+			Duration: time.Duration(PCMDuration(wavDataSize(info.Size())) * float64(time.Second)),
+		})
+	}
+
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].Time.Before(recordings[j].Time)
+	})
+	return recordings, nil
+}
+
+// Prune deletes recordings older than the retention period, then the oldest
+// ones until the archive fits within its size limit
+func (a *Archive) Prune() error {
+	recordings, err := a.List()
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-a.retention)
+	var total int64
+	for _, recording := range recordings {
+		total += recording.Size
+	}
+
+	for _, recording := range recordings {
+		if !recording.Time.Before(cutoff) && total <= a.maxBytes {
+			break
+		}
+		if err := os.Remove(recording.Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %v", recording.Name, err)
+		}
+		total -= recording.Size
+	}
+	return nil
+}
+
+// PurgeBefore deletes recordings made before cutoff and returns how many were removed
+func (a *Archive) PurgeBefore(cutoff time.Time) (int, error) {
+	recordings, err := a.List()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, recording := range recordings {
+		if !recording.Time.Before(cutoff) {
+			break
+		}
+		if err := os.Remove(recording.Path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to remove %s: %v", recording.Name, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Purge deletes every archived recording and returns how many were removed
+func (a *Archive) Purge() (int, error) {
+	recordings, err := a.List()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, recording := range recordings {
+		if err := os.Remove(recording.Path); err != nil && !os.IsNotExist(err) {
+			return i, fmt.Errorf("failed to remove %s: %v", recording.Name, err)
+		}
+	}
+	return len(recordings), nil
+}
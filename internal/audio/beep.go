@@ -1,11 +1,86 @@
 package audio
 
 import (
+	"fmt"
+	"log"
 	"os/exec"
+	"sync"
 
 	"github.com/gen2brain/beeep"
 )
 
+// BeepTypes lists the feedback sounds that can be replaced with a sound file
+var BeepTypes = []string{"start", "stop", "skipped", "warning", "error"}
+
+// systemSounds are played instead of the tones when a volume is set, since
+// the tones can't be made quieter
+var systemSounds = map[string]string{
+	"start":   "/System/Library/Sounds/Tink.aiff",
+	"stop":    "/System/Library/Sounds/Pop.aiff",
+	"skipped": "/System/Library/Sounds/Bottle.aiff",
+	"warning": "/System/Library/Sounds/Basso.aiff",
+	"error":   "/System/Library/Sounds/Basso.aiff",
+}
+
+// SoundSettings controls how audio feedback is played
+type SoundSettings struct {
+	Disabled bool              // Play nothing
+	Feedback string            // One of the Feedback* constants; "" plays sounds
+	Volume   int               // Percent, 1-100; 0 keeps the default tones at full volume
+	Files    map[string]string // Sound file per beep type, played with afplay
+}
+
+// Sounds plays audio feedback according to the current SoundSettings
+type Sounds struct {
+	mu       sync.Mutex
+	settings SoundSettings
+}
+
+// NewSounds creates a player that uses the default tones until configured
+func NewSounds() *Sounds {
+	return &Sounds{}
+}
+
+// Configure replaces the sound settings
+func (s *Sounds) Configure(settings SoundSettings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings = settings
+}
+
+// PlayBeep plays the configured sound for beepType
+func (s *Sounds) PlayBeep(beepType string) {
+	s.mu.Lock()
+	settings := s.settings
+	s.mu.Unlock()
+
+	if settings.Disabled {
+		return
+	}
+	if settings.Feedback != "" && settings.Feedback != FeedbackSound {
+		giveFeedback(settings.Feedback, beepType)
+		return
+	}
+
+	file := settings.Files[beepType]
+	if file == "" && settings.Volume > 0 {
+		file = systemSounds[beepType]
+	}
+	if file == "" {
+		PlayBeep(beepType)
+		return
+	}
+
+	volume := 100
+	if settings.Volume > 0 && settings.Volume < 100 {
+		volume = settings.Volume
+	}
+	if err := exec.Command("afplay", "-v", fmt.Sprintf("%.2f", float64(volume)/100), file).Run(); err != nil {
+		log.Printf("[AUDIO] Failed to play %s sound %s: %v", beepType, file, err)
+		PlayBeep(beepType)
+	}
+}
+
 // PlayBeep plays system beep sound for audio feedback
 func PlayBeep(beepType string) {
 	// Play system beep sound for audio feedback
@@ -24,5 +99,21 @@ func PlayBeep(beepType string) {
 			// Fallback to system beep command
 			exec.Command("osascript", "-e", "beep 2").Run()
 		}
+	case "skipped":
+		// Two quick low tones so an empty session doesn't sound like a normal stop
+		for i := 0; i < 2; i++ {
+			err := beeep.Beep(beeep.DefaultFreq/2, beeep.DefaultDuration/4)
+			if err != nil {
+				// Fallback to system beep command
+				exec.Command("osascript", "-e", "beep 1").Run()
+			}
+		}
+	case "warning", "error":
+		// Low, longer tone so it can't be mistaken for a normal stop
+		err := beeep.Beep(beeep.DefaultFreq/2, beeep.DefaultDuration)
+		if err != nil {
+			// Fallback to system beep command
+			exec.Command("osascript", "-e", "beep 3").Run()
+		}
 	}
 }
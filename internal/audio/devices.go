@@ -0,0 +1,140 @@
+package audio
+
+/*
+#cgo LDFLAGS: -framework CoreAudio
+#include <CoreAudio/CoreAudio.h>
+
+// audioProperty reads a global property of the system audio object into data
+static int audioProperty(AudioObjectPropertySelector selector, void *data, UInt32 *size) {
+    AudioObjectPropertyAddress address = {
+        selector,
+        kAudioObjectPropertyScopeGlobal,
+        0 // kAudioObjectPropertyElementMain
+    };
+    return AudioObjectGetPropertyData(kAudioObjectSystemObject, &address, 0, NULL, size, data) == noErr;
+}
+
+static int defaultInputDevice() {
+    AudioDeviceID device = kAudioObjectUnknown;
+    UInt32 size = sizeof(device);
+    if (!audioProperty(kAudioHardwarePropertyDefaultInputDevice, &device, &size)) {
+        return -1;
+    }
+    return (int)device;
+}
+
+static int audioDeviceCount() {
+    AudioObjectPropertyAddress address = {
+        kAudioHardwarePropertyDevices,
+        kAudioObjectPropertyScopeGlobal,
+        0
+    };
+    UInt32 size = 0;
+    if (AudioObjectGetPropertyDataSize(kAudioObjectSystemObject, &address, 0, NULL, &size) != noErr) {
+        return -1;
+    }
+    return (int)(size / sizeof(AudioDeviceID));
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/gordonklaus/portaudio"
+)
+
+// DeviceState identifies the current audio hardware, so changes such as
+// AirPods connecting can be noticed by comparing two states
+type DeviceState struct {
+	DefaultInput int // CoreAudio ID of the default input device
+	Devices      int // Number of audio devices
+}
+
+// CurrentDevices reads the default input device and device count from CoreAudio
+func CurrentDevices() DeviceState {
+	if runtime.GOOS != "darwin" {
+		return DeviceState{}
+	}
+	return DeviceState{
+		DefaultInput: int(C.defaultInputDevice()),
+		Devices:      int(C.audioDeviceCount()),
+	}
+}
+
+// InputDevice is an audio input as listed by t2 audio devices
+type InputDevice struct {
+	Name     string
+	Channels int
+	Default  bool
+}
+
+// InputDevices lists the devices that can be recorded from
+func InputDevices() ([]InputDevice, error) {
+	devices, err := portaudio.Devices()
+	if err != nil {
+		return nil, err
+	}
+
+	// No default input is fine, e.g. a Mac mini without a microphone
+	var defaultName string
+	if device, err := portaudio.DefaultInputDevice(); err == nil {
+		defaultName = device.Name
+	}
+
+	var inputs []InputDevice
+	for _, device := range devices {
+		if device.MaxInputChannels > 0 {
+			inputs = append(inputs, InputDevice{
+				Name:     device.Name,
+				Channels: device.MaxInputChannels,
+				Default:  device.Name == defaultName,
+			})
+		}
+	}
+	return inputs, nil
+}
+
+// FindInputDevice returns the first input device whose name contains name,
+// ignoring case, so "blackhole" finds "BlackHole 2ch"
+func FindInputDevice(name string) (*portaudio.DeviceInfo, error) {
+	devices, err := portaudio.Devices()
+	if err != nil {
+		return nil, err
+	}
+
+	var inputs []string
+	for _, device := range devices {
+		if device.MaxInputChannels == 0 {
+			continue
+		}
+		if strings.Contains(strings.ToLower(device.Name), strings.ToLower(name)) {
+			return device, nil
+		}
+		inputs = append(inputs, device.Name)
+	}
+	return nil, fmt.Errorf("no input device matching %q (available: %s)", name, strings.Join(inputs, ", "))
+}
+
+// openInputStream opens a stream reading channels interleaved channels from
+// device into in; CoreAudio converts from the device's own sample rate
+func openInputStream(device *portaudio.DeviceInfo, channels int, in []int32) (*portaudio.Stream, error) {
+	params := portaudio.LowLatencyParameters(device, nil)
+	params.Input.Channels = channels
+	params.SampleRate = SampleRate
+	params.FramesPerBuffer = len(in) / channels
+	return portaudio.OpenStream(params, in)
+}
+
+// inputChannel returns the 1-based channel to record from the named device,
+// from channels keyed by any part of a device name; 1 when none is set
+func inputChannel(name string, channels map[string]int) int {
+	for key, channel := range channels {
+		if channel > 1 && strings.Contains(strings.ToLower(name), strings.ToLower(key)) {
+			return channel
+		}
+	}
+	return 1
+}
@@ -0,0 +1,49 @@
+package audio
+
+import (
+	"github.com/bezmoradi/t2/internal/notify"
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// Ways to give feedback instead of sounds, for open-plan offices
+const (
+	FeedbackSound        = "sound"        // Beeps (default)
+	FeedbackFlash        = "flash"        // Flash the terminal window
+	FeedbackNotification = "notification" // A desktop notification
+	FeedbackHaptic       = "haptic"       // Tap the Force Touch trackpad, felt while a finger rests on it
+)
+
+// FeedbackModes lists the valid feedback settings
+var FeedbackModes = []string{FeedbackSound, FeedbackFlash, FeedbackNotification, FeedbackHaptic}
+
+// feedbackMessages are the notifications shown with FeedbackNotification
+var feedbackMessages = map[string]string{
+	"start":   "Recording",
+	"stop":    "Transcribing",
+	"skipped": "Nothing heard - skipped",
+	"warning": "Check the terminal",
+	"error":   "Something went wrong - check the terminal",
+}
+
+// feedbackTaps is how many flashes or trackpad taps each beep type gets, so
+// problems can be told apart from a normal start and stop without looking
+var feedbackTaps = map[string]int{
+	"start":   1,
+	"stop":    1,
+	"skipped": 2,
+	"warning": 3,
+	"error":   3,
+}
+
+// giveFeedback signals beepType silently in the given mode
+func giveFeedback(mode string, beepType string) {
+	taps := max(feedbackTaps[beepType], 1)
+	switch mode {
+	case FeedbackFlash:
+		terminal.NewControl().Flash(taps)
+	case FeedbackNotification:
+		notify.Send(feedbackMessages[beepType])
+	case FeedbackHaptic:
+		hapticTap(taps)
+	}
+}
@@ -0,0 +1,36 @@
+package audio
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework AppKit
+#import <AppKit/AppKit.h>
+
+// performHaptic taps the Force Touch trackpad. macOS only delivers it while a
+// finger rests on the trackpad, so it's silently dropped otherwise.
+static void performHaptic() {
+    [[NSHapticFeedbackManager defaultPerformer] performFeedbackPattern:NSHapticFeedbackPatternGeneric
+                                                      performanceTime:NSHapticFeedbackPerformanceTimeNow];
+}
+*/
+import "C"
+
+import (
+	"runtime"
+	"time"
+)
+
+// hapticGap separates taps so they're felt as distinct
+const hapticGap = 120 * time.Millisecond
+
+// hapticTap taps the trackpad times times
+func hapticTap(times int) {
+	if runtime.GOOS != "darwin" {
+		return
+	}
+	for i := 0; i < times; i++ {
+		if i > 0 {
+			time.Sleep(hapticGap)
+		}
+		C.performHaptic()
+	}
+}
@@ -0,0 +1,134 @@
+package audio
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gordonklaus/portaudio"
+)
+
+const (
+	monitorSpeechThreshold = 400.0 // RMS a chunk must reach to count as speech
+	monitorSpeechChunks    = 2     // ~130ms of speech starts a session
+	monitorPauseChunks     = 20    // ~1.3s of silence ends it
+	monitorMaxSession      = 60 * time.Second
+	monitorCooldown        = 1 * time.Second // Ignore our own stop beep
+)
+
+// Monitor listens to the microphone continuously and reports when speech
+// starts and pauses, so sessions can begin without touching the keyboard
+type Monitor struct {
+	onSpeech func()
+	onPause  func()
+	stream   *portaudio.Stream
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewMonitor creates a monitor calling onSpeech when someone starts talking
+// and onPause once they stop
+func NewMonitor(onSpeech func(), onPause func()) *Monitor {
+	return &Monitor{
+		onSpeech: onSpeech,
+		onPause:  onPause,
+	}
+}
+
+// Start opens the input stream and begins monitoring in the background
+func (m *Monitor) Start() error {
+	in := make([]int32, Frames)
+
+	var err error
+	m.stream, err = portaudio.OpenDefaultStream(1, 0, SampleRate, len(in), in)
+	if err != nil {
+		return err
+	}
+	if err := m.stream.Start(); err != nil {
+		m.stream.Close()
+		m.stream = nil
+		return err
+	}
+
+	m.stopChan = make(chan struct{})
+	m.wg.Add(1)
+	go m.loop(in)
+	return nil
+}
+
+// Stop ends monitoring and closes the input stream
+func (m *Monitor) Stop() {
+	if m.stream == nil {
+		return
+	}
+
+	close(m.stopChan)
+	m.wg.Wait()
+
+	m.stream.Stop()
+	m.stream.Close()
+	m.stream = nil
+}
+
+func (m *Monitor) loop(in []int32) {
+	defer m.wg.Done()
+
+	active := false
+	loudChunks, quietChunks := 0, 0
+	var sessionStart, cooldownUntil time.Time
+	samples := make([]int16, len(in))
+
+	for {
+		select {
+		case <-m.stopChan:
+			if active {
+				m.onPause()
+			}
+			return
+		default:
+		}
+
+		// Callbacks block the loop while a transcript is processed, so the
+		// buffer overflowing afterwards is expected
+		if err := m.stream.Read(); err != nil && err != portaudio.InputOverflowed {
+			log.Printf("Error reading from monitor stream: %v", err)
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		for i, sample := range in {
+			samples[i] = int16(sample >> 16)
+		}
+		rms := calculateRMS(samples)
+
+		if !active {
+			if rms >= monitorSpeechThreshold && time.Now().After(cooldownUntil) {
+				loudChunks++
+			} else {
+				loudChunks = 0
+			}
+
+			if loudChunks >= monitorSpeechChunks {
+				active = true
+				quietChunks = 0
+				sessionStart = time.Now()
+				m.onSpeech()
+			}
+			continue
+		}
+
+		if rms < monitorSpeechThreshold/2 {
+			quietChunks++
+		} else {
+			quietChunks = 0
+		}
+
+		// Background music or a running tap would otherwise record forever
+		if quietChunks >= monitorPauseChunks || time.Since(sessionStart) > monitorMaxSession {
+			active = false
+			loudChunks = 0
+			m.onPause()
+			cooldownUntil = time.Now().Add(monitorCooldown)
+		}
+	}
+}
@@ -1,18 +1,52 @@
 package audio
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"math"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/bezmoradi/t2/internal/session"
+	"github.com/bezmoradi/t2/internal/supervisor"
 	"github.com/gordonklaus/portaudio"
 )
 
 const (
 	SampleRate = 16000
 	Frames     = 1024
+
+	// maxOpenFailures is how many consecutive stream failures we tolerate before
+	// assuming PortAudio itself is wedged (e.g. after a device change)
+	maxOpenFailures = 2
+
+	// audioQueueSize is how many chunks may wait for the network before capture
+	// starts dropping them (~2s at the default chunk size)
+	audioQueueSize = 32
+
+	// AssemblyAI accepts chunks between 50ms and 1s of audio
+	minChunkDuration = 50 * time.Millisecond
+	maxChunkDuration = 1 * time.Second
+
+	// clippedSamplesPercent of a chunk's samples at full scale means the input is clipping
+	clippedSamplesPercent = 1
+
+	// The silence threshold adapts to the room: the quietest chunk in the first
+	// ambientWindow of a recording times ambientThresholdFactor, within bounds
+	DefaultSilenceThreshold = 150.0
+	ambientWindow           = 200 * time.Millisecond
+	ambientThresholdFactor  = 3.0
+	minSilenceThreshold     = 75.0
+	maxSilenceThreshold     = 1500.0
+)
+
+// What capture does when the send queue is full
+const (
+	QueuePolicyDrop       = "drop"        // Drop the new chunk (default)
+	QueuePolicyDropOldest = "drop_oldest" // Drop the oldest queued chunk to make room
+	QueuePolicyBlock      = "block"       // Wait for the network; PortAudio buffers in the meantime
 )
 
 // SpeechState represents the current state of speech detection
@@ -33,19 +67,143 @@ type Recorder struct {
 	streamWg         sync.WaitGroup
 	maxRMS           float64
 	silenceThreshold float64
+	fixedThreshold   float64             // Configured silence threshold, 0 to adapt to ambient noise
+	ambientChunks    int                 // Chunks at the start of this recording used to measure ambient noise
+	ambientRMS       float64             // Quietest chunk RMS during calibration
 	silenceChunks    int                 // Count of consecutive silent chunks
 	maxSilenceChunks int                 // Max silent chunks before triggering callback
+	endOfSpeechAfter time.Duration       // Pause after speech that ends the recording, 0 to keep recording
+	endOfSpeechFunc  func()              // Called once per recording when speech is followed by that pause
+	pauseChunks      int                 // endOfSpeechAfter in chunks for this recording
+	endOfSpeechFired bool                // endOfSpeechFunc was called this recording
 	speechState      SpeechState         // Track current speech detection state
 	prolongedSilence bool                // Flag to track if we've had prolonged silence without speech
+	openFailures     int                 // Consecutive stream open/start failures
+	chunkFrames      int                 // Frames per chunk read from the microphone
+	audioQueue       chan []byte         // Chunks waiting to be sent, so the network never blocks capture
+	bufferPool       sync.Pool           // Reused PCM buffers
+	queuePolicy      string              // One of the QueuePolicy* constants
+	queueMaxDepth    int                 // Deepest the send queue got this recording
+	droppedChunks    int                 // Chunks dropped this recording because the queue was full
+	peakLevel        int                 // Loudest absolute sample this recording
+	chunksRead       int                 // Chunks read from the stream this recording
+	firstSpeechChunk int                 // Index of the first chunk above the silence threshold, -1 before speech
+	lastSpeechChunk  int                 // Index of the last chunk above the silence threshold
+	clippedChunks    int                 // Chunks this recording with clippedSamplesPercent or more samples at full scale
+	captureEnabled   bool                // Keep a copy of the recording's PCM for the archive
+	captured         []byte              // PCM captured this recording when captureEnabled is set
+	sessionID        string              // Tags log lines with the current recording
+	inputDevice      string              // Name of the input device to record from, "" for the default
+	inputChannels    map[string]int      // 1-based channel to record, keyed by part of a device name
+	streamChannels   int                 // Interleaved channels the open stream reads
+	channel          int                 // 0-based channel of the open stream that's recorded
 }
 
 func NewRecorder(audioCallback func([]byte) error) *Recorder {
 	return &Recorder{
 		audioCallback:    audioCallback,
 		stopChan:         make(chan struct{}),
-		silenceThreshold: 150.0, // Threshold for silence detection (lowered to match daemon)
+		silenceThreshold: DefaultSilenceThreshold,
 		maxSilenceChunks: 20,     // ~500ms of silence at 40ms chunks (20*25ms per chunk)
+		chunkFrames:      Frames,
+		queuePolicy:      QueuePolicyDrop,
+	}
+}
+
+// SetQueuePolicy sets what happens to audio when the network falls behind
+func (r *Recorder) SetQueuePolicy(policy string) {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+
+	switch policy {
+	case QueuePolicyDropOldest, QueuePolicyBlock:
+		r.queuePolicy = policy
+	default:
+		r.queuePolicy = QueuePolicyDrop
+	}
+}
+
+// SetCapture sets whether each recording's audio is kept for TakeCapture
+func (r *Recorder) SetCapture(enabled bool) {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+	r.captureEnabled = enabled
+	if !enabled {
+		r.captured = nil
+	}
+}
+
+// TakeCapture returns the last recording's PCM16 audio and releases it
+func (r *Recorder) TakeCapture() []byte {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+	pcm := r.captured
+	r.captured = nil
+	return pcm
+}
+
+// QueueStats returns the deepest the send queue got and how many chunks were
+// dropped during the last recording
+func (r *Recorder) QueueStats() (int, int) {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+	return r.queueMaxDepth, r.droppedChunks
+}
+
+// Levels returns the loudest absolute sample and how many chunks clipped
+// during the last recording
+func (r *Recorder) Levels() (int, int) {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+	return r.peakLevel, r.clippedChunks
+}
+
+// SpeechDuration returns how long the last recording lasted from the first
+// to the last chunk of speech, leaving out the silence before and after it;
+// 0 when no speech was heard
+func (r *Recorder) SpeechDuration() time.Duration {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+	if r.firstSpeechChunk < 0 {
+		return 0
 	}
+	chunks := r.lastSpeechChunk - r.firstSpeechChunk + 1
+	return time.Duration(chunks*r.chunkFrames) * time.Second / SampleRate
+}
+
+// SetChunkDuration sets how much audio is read and sent per chunk. Smaller
+// chunks reach the provider sooner at the cost of more messages. Takes effect
+// on the next recording.
+func (r *Recorder) SetChunkDuration(duration time.Duration) {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+
+	frames := Frames
+	if duration > 0 {
+		duration = min(max(duration, minChunkDuration), maxChunkDuration)
+		frames = int(duration * SampleRate / time.Second)
+	}
+	r.chunkFrames = frames
+}
+
+// ChunkDuration returns how much audio is sent per chunk
+func (r *Recorder) ChunkDuration() time.Duration {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+	return time.Duration(r.chunkFrames) * time.Second / SampleRate
+}
+
+// getBuffer returns a PCM16 buffer for one chunk, reusing a pooled one when possible
+func (r *Recorder) getBuffer(size int) []byte {
+	if buf, ok := r.bufferPool.Get().(*[]byte); ok && cap(*buf) >= size {
+		return (*buf)[:size]
+	}
+	return make([]byte, size)
+}
+
+// putBuffer returns a chunk's buffer to the pool, which holds *[]byte
+func (r *Recorder) putBuffer(buf []byte) {
+	r.bufferPool.Put(&buf)
 }
 
 // SetSilenceCallback sets the callback function for silence detection
@@ -86,7 +244,89 @@ func calculateRMS(samples []int16) float64 {
 	return math.Sqrt(sum / float64(len(samples)))
 }
 
-func (r *Recorder) Start() error {
+// SetInputDevice records from the input device whose name contains name, e.g.
+// "BlackHole" for system audio, instead of the default input. Takes effect on
+// the next recording; "" goes back to the default input.
+func (r *Recorder) SetInputDevice(name string) {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+	r.inputDevice = name
+}
+
+// SetInputChannels picks which channel of a multi-channel audio interface is
+// recorded instead of the first, keyed by any part of the device's name, e.g.
+// {"Scarlett": 2}. Takes effect on the next recording.
+func (r *Recorder) SetInputChannels(channels map[string]int) {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+	r.inputChannels = channels
+}
+
+// peakLevel returns the loudest absolute sample and how many samples are at full scale
+func peakLevel(samples []int16) (int, int) {
+	peak, clipped := 0, 0
+	for _, sample := range samples {
+		level := int(sample)
+		if level < 0 {
+			level = -level
+		}
+		peak = max(peak, level)
+		if level >= math.MaxInt16 {
+			clipped++
+		}
+	}
+	return peak, clipped
+}
+
+// SetEndOfSpeech calls callback once speech has been followed by a pause of
+// after, so a session can end without releasing the hotkey; 0 disables it.
+// The callback runs on its own goroutine, so it may stop the recorder. Takes
+// effect on the next recording.
+func (r *Recorder) SetEndOfSpeech(after time.Duration, callback func()) {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+	r.endOfSpeechAfter = max(after, 0)
+	r.endOfSpeechFunc = callback
+}
+
+// SetSilenceThreshold fixes the RMS below which audio counts as silence;
+// 0 adapts it to each recording's ambient noise. Takes effect on the next recording.
+func (r *Recorder) SetSilenceThreshold(threshold float64) {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+	r.fixedThreshold = max(threshold, 0)
+}
+
+// SilenceThreshold returns the RMS below which the last recording counted as silence
+func (r *Recorder) SilenceThreshold() float64 {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+	return r.silenceThreshold
+}
+
+// calibrate tracks the quietest chunk while measuring ambient noise and, on
+// the last calibration chunk, sets the silence threshold relative to it. The
+// quietest chunk is used so speech starting right away doesn't inflate it.
+// Must be called with recordingMutex held.
+func (r *Recorder) calibrate(chunkRMS float64) {
+	if r.chunksRead == 0 || chunkRMS < r.ambientRMS {
+		r.ambientRMS = chunkRMS
+	}
+	if r.chunksRead == r.ambientChunks-1 {
+		r.silenceThreshold = min(max(r.ambientRMS*ambientThresholdFactor, minSilenceThreshold), maxSilenceThreshold)
+		session.Printf(r.sessionID, "[RECORDER] Ambient RMS %.0f, silence threshold %.0f", r.ambientRMS, r.silenceThreshold)
+	}
+}
+
+// SetSessionID tags the recorder's log lines with the session it records next
+func (r *Recorder) SetSessionID(id string) {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
+	r.sessionID = id
+}
+
+// Start records until Stop is called or ctx is cancelled
+func (r *Recorder) Start(ctx context.Context) error {
 	r.recordingMutex.Lock()
 	defer r.recordingMutex.Unlock()
 
@@ -99,40 +339,129 @@ func (r *Recorder) Start() error {
 	// Reset audio level tracking for new session
 	r.maxRMS = 0.0
 
-	// Reset silence detection for new session
+	// Reset silence detection for new session, measuring the room again unless the threshold is fixed
 	r.silenceChunks = 0
+	r.ambientChunks = 0
+	r.endOfSpeechFired = false
+	r.pauseChunks = int((r.endOfSpeechAfter*SampleRate/time.Second + time.Duration(r.chunkFrames) - 1) / time.Duration(r.chunkFrames))
+	if r.fixedThreshold > 0 {
+		r.silenceThreshold = r.fixedThreshold
+	} else {
+		r.silenceThreshold = DefaultSilenceThreshold
+		r.ambientChunks = int((ambientWindow*SampleRate/time.Second + time.Duration(r.chunkFrames) - 1) / time.Duration(r.chunkFrames))
+	}
 	r.speechState = WaitingForSpeech
 	r.prolongedSilence = false
 
+	// Reset queue statistics
+	r.queueMaxDepth = 0
+	r.droppedChunks = 0
+	r.captured = nil
+
+	// Reset input level tracking
+	r.peakLevel = 0
+	r.clippedChunks = 0
+	r.chunksRead = 0
+	r.firstSpeechChunk = -1
+	r.lastSpeechChunk = -1
+
 	// Create new stop channel for this session
 	r.stopChan = make(chan struct{})
 
-	// Setup audio buffer for streaming (PCM16 format for AssemblyAI)
-	in := make([]int32, Frames)
+	// Open PortAudio stream, restarting the audio subsystem if it keeps failing
+	in, err := r.openStream()
+	if err != nil {
+		r.openFailures++
+		if r.openFailures < maxOpenFailures {
+			r.recording = false
+			return err
+		}
 
-	// Open PortAudio stream
-	var err error
-	r.stream, err = portaudio.OpenDefaultStream(1, 0, SampleRate, len(in), in)
+		log.Printf("Audio stream failed %d times in a row, restarting PortAudio", r.openFailures)
+		if restartErr := restartPortAudio(); restartErr != nil {
+			log.Printf("Error restarting PortAudio: %v", restartErr)
+			r.recording = false
+			return err
+		}
+
+		if in, err = r.openStream(); err != nil {
+			r.recording = false
+			return err
+		}
+	}
+	r.openFailures = 0
+	session.Printf(r.sessionID, "[RECORDER] Recording started (%d frames per chunk, channel %d)", r.chunkFrames, r.channel+1)
+
+	// Capture and send on separate goroutines so a slow network can't stall the microphone
+	r.audioQueue = make(chan []byte, audioQueueSize)
+	r.streamWg.Add(2)
+	go r.audioStreamLoop(in, r.audioQueue)
+	go r.sendLoop(r.audioQueue)
+
+	// Ctrl+C stops the recording like a release would
+	stopChan := r.stopChan
+	go func() {
+		select {
+		case <-ctx.Done():
+			r.Stop()
+		case <-stopChan:
+		}
+	}()
+
+	return nil
+}
+
+// openStream opens and starts the input stream and returns the buffer it
+// reads into (PCM32, interleaved when recording a channel other than the
+// first). Must be called with recordingMutex held.
+func (r *Recorder) openStream() ([]int32, error) {
+	// The device only needs looking up when it's named or a channel may be set for it
+	var (
+		device *portaudio.DeviceInfo
+		err    error
+	)
+	if r.inputDevice != "" {
+		device, err = FindInputDevice(r.inputDevice)
+	} else if len(r.inputChannels) > 0 {
+		device, err = portaudio.DefaultInputDevice()
+	}
 	if err != nil {
-		log.Printf("Error opening PortAudio stream: %v", err)
-		r.recording = false
-		return err
+		session.Printf(r.sessionID, "Error finding input device: %v", err)
+		return nil, err
+	}
+
+	// Channels are interleaved, so reaching channel n means reading the n-1 before it
+	r.streamChannels, r.channel = 1, 0
+	if device != nil {
+		channel := inputChannel(device.Name, r.inputChannels)
+		if channel > device.MaxInputChannels {
+			session.Printf(r.sessionID, "[RECORDER] %s has %d input channels, recording channel 1 instead of %d",
+				device.Name, device.MaxInputChannels, channel)
+			channel = 1
+		}
+		r.streamChannels, r.channel = channel, channel-1
+	}
+	in := make([]int32, r.chunkFrames*r.streamChannels)
+
+	if device == nil {
+		r.stream, err = portaudio.OpenDefaultStream(1, 0, SampleRate, r.chunkFrames, in)
+	} else {
+		r.stream, err = openInputStream(device, r.streamChannels, in)
+	}
+	if err != nil {
+		session.Printf(r.sessionID, "Error opening PortAudio stream: %v", err)
+		r.stream = nil
+		return nil, err
 	}
 
-	// Start the stream
 	if err := r.stream.Start(); err != nil {
-		log.Printf("Error starting PortAudio stream: %v", err)
-		r.recording = false
+		session.Printf(r.sessionID, "Error starting PortAudio stream: %v", err)
 		r.stream.Close()
 		r.stream = nil
-		return err
+		return nil, err
 	}
 
-	// Start streaming audio in a goroutine with proper synchronization
-	r.streamWg.Add(1)
-	go r.audioStreamLoop(in)
-
-	return nil
+	return in, nil
 }
 
 func (r *Recorder) Stop() {
@@ -162,16 +491,31 @@ func (r *Recorder) Stop() {
 		r.stream.Close()
 		r.stream = nil
 	}
+	session.Printf(r.sessionID, "[RECORDER] Recording stopped (max RMS %.0f, speech detected: %v, queue depth %d, %d chunks dropped)",
+		r.maxRMS, r.speechState == SpeechDetected, r.queueMaxDepth, r.droppedChunks)
 }
 
-func (r *Recorder) audioStreamLoop(in []int32) {
+func (r *Recorder) audioStreamLoop(in []int32, queue chan []byte) {
 	defer func() {
-		if r := recover(); r != nil {
-			log.Printf("Audio streaming goroutine recovered from panic: %v", r)
-		}
+		close(queue)      // Let the sender drain what's left and exit
 		r.streamWg.Done() // Signal that the goroutine has finished
 	}()
 
+	// Capture holds recordingMutex for each chunk, so it can't be restarted
+	supervisor.RunOrExit("audio capture", func() {
+		r.captureLoop(in, queue)
+	})
+}
+
+// captureLoop reads chunks from the stream until the recording stops
+func (r *Recorder) captureLoop(in []int32, queue chan []byte) {
+	r.recordingMutex.Lock()
+	stride, channel := r.streamChannels, r.channel
+	r.recordingMutex.Unlock()
+
+	frames := len(in) / stride
+	samples16 := make([]int16, frames) // For RMS calculation, reused every chunk
+
 	for {
 		// Check if we should stop using the stop channel
 		select {
@@ -201,22 +545,26 @@ func (r *Recorder) audioStreamLoop(in []int32) {
 			default:
 				r.recordingMutex.Lock()
 				stillRecording := r.recording
+				sessionID := r.sessionID
+				if stillRecording {
+					// A stream dying mid-recording counts towards a PortAudio restart
+					r.openFailures++
+				}
 				r.recordingMutex.Unlock()
 
 				if stillRecording {
-					log.Printf("Error reading from stream: %v", err)
+					session.Printf(sessionID, "Error reading from stream: %v", err)
 				}
 				return
 			}
 		}
 
 		// Convert int32 to PCM16 bytes for AssemblyAI (little-endian)
-		pcmBytes := make([]byte, len(in)*2) // 2 bytes per int16
-		samples16 := make([]int16, len(in))  // For RMS calculation
+		pcmBytes := r.getBuffer(frames * 2) // 2 bytes per int16
 
-		for i, sample := range in {
-			// Convert int32 to int16 (PCM16)
-			sample16 := int16(sample >> 16)
+		for i := range frames {
+			// Convert int32 to int16 (PCM16), keeping only the recorded channel
+			sample16 := int16(in[i*stride+channel] >> 16)
 			samples16[i] = sample16
 			pcmBytes[i*2] = byte(sample16)        // Low byte
 			pcmBytes[i*2+1] = byte(sample16 >> 8) // High byte
@@ -224,11 +572,24 @@ func (r *Recorder) audioStreamLoop(in []int32) {
 
 		// Calculate RMS for this chunk and update maximum
 		chunkRMS := calculateRMS(samples16)
+		peak, clipped := peakLevel(samples16)
 		r.recordingMutex.Lock()
+		r.peakLevel = max(r.peakLevel, peak)
+		if clipped*100 >= len(samples16)*clippedSamplesPercent {
+			r.clippedChunks++
+		}
+		if r.captureEnabled {
+			r.captured = append(r.captured, pcmBytes...)
+		}
 		if chunkRMS > r.maxRMS {
 			r.maxRMS = chunkRMS
 		}
 
+		// Measure the room before deciding what counts as silence
+		if r.chunksRead < r.ambientChunks {
+			r.calibrate(chunkRMS)
+		}
+
 		// Real-time silence detection
 		isSilent := chunkRMS < r.silenceThreshold
 		if isSilent {
@@ -236,66 +597,133 @@ func (r *Recorder) audioStreamLoop(in []int32) {
 		} else {
 			// Speech detected - reset silence counter and update state
 			r.silenceChunks = 0
+			if r.firstSpeechChunk < 0 {
+				r.firstSpeechChunk = r.chunksRead
+			}
+			r.lastSpeechChunk = r.chunksRead
 
 			// Transition from WaitingForSpeech to SpeechDetected
 			if r.speechState == WaitingForSpeech {
 				r.speechState = SpeechDetected
+				session.Printf(r.sessionID, "[RECORDER] Speech detected")
 			}
 		}
+		r.chunksRead++
+
+		// A long enough pause after speech ends the session on its own
+		if r.speechState == SpeechDetected && r.pauseChunks > 0 && r.silenceChunks >= r.pauseChunks &&
+			!r.endOfSpeechFired && r.endOfSpeechFunc != nil {
+			r.endOfSpeechFired = true
+			session.Printf(r.sessionID, "[RECORDER] Paused for %v after speech", r.endOfSpeechAfter)
+			go r.endOfSpeechFunc()
+		}
 
 		// Mark prolonged silence but don't stop recording yet
 		// Let user decide when to release keys
 		if r.speechState == WaitingForSpeech && r.silenceChunks >= r.maxSilenceChunks {
 			if !r.prolongedSilence {
 				r.prolongedSilence = true
+				session.Printf(r.sessionID, "[RECORDER] Prolonged silence, holding back audio until speech")
 			}
 		}
+
+		// Only send audio to API if speech has been detected or we haven't hit prolonged silence yet
+		// This avoids unnecessary API calls during prolonged silence periods
+		shouldSendAudio := r.speechState == SpeechDetected || !r.prolongedSilence
+		policy := r.queuePolicy
 		r.recordingMutex.Unlock()
 
-		// Check again if we should stop before sending audio
+		if !shouldSendAudio {
+			r.putBuffer(pcmBytes)
+			continue
+		}
+
+		r.enqueue(queue, pcmBytes, policy)
+	}
+}
+
+// enqueue hands a chunk to the sender, applying policy if the queue is full
+func (r *Recorder) enqueue(queue chan []byte, pcmBytes []byte, policy string) {
+	dropped := 0
+
+	switch policy {
+	case QueuePolicyBlock:
 		select {
+		case queue <- pcmBytes:
 		case <-r.stopChan:
-			return
+			r.putBuffer(pcmBytes)
+			dropped++
+		}
+
+	case QueuePolicyDropOldest:
+		for sent := false; !sent; {
+			select {
+			case queue <- pcmBytes:
+				sent = true
+			default:
+				// Make room by discarding the oldest chunk (unless the sender just took it)
+				select {
+				case old := <-queue:
+					r.putBuffer(old)
+					dropped++
+				default:
+				}
+			}
+		}
+
+	default:
+		select {
+		case queue <- pcmBytes:
 		default:
+			r.putBuffer(pcmBytes)
+			dropped++
 		}
+	}
 
-		// Only send audio to API if speech has been detected or we haven't hit prolonged silence yet
-		// This avoids unnecessary API calls during prolonged silence periods
-		r.recordingMutex.Lock()
-		shouldSendAudio := r.speechState == SpeechDetected || !r.prolongedSilence
-		r.recordingMutex.Unlock()
+	r.recordingMutex.Lock()
+	r.droppedChunks += dropped
+	if depth := len(queue); depth > r.queueMaxDepth {
+		r.queueMaxDepth = depth
+	}
+	r.recordingMutex.Unlock()
+}
 
-		if r.audioCallback != nil && shouldSendAudio {
-			// Send audio chunk to callback
+// sendLoop delivers queued chunks to the audio callback until the capture loop
+// closes the queue, so everything captured before Stop is still sent
+func (r *Recorder) sendLoop(queue <-chan []byte) {
+	defer r.streamWg.Done()
+
+	connectionClosed := false
+	for pcmBytes := range queue {
+		if r.audioCallback != nil && !connectionClosed {
 			if err := r.audioCallback(pcmBytes); err != nil {
-				// Check if stop was called before logging error
-				select {
-				case <-r.stopChan:
-					return
-				default:
-					r.recordingMutex.Lock()
-					stillRecording := r.recording
-					r.recordingMutex.Unlock()
-
-					if stillRecording {
-						// Check if it's a WebSocket close error - if so, stop sending
-						errStr := err.Error()
-						if strings.Contains(errStr, "websocket: close sent") ||
-							strings.Contains(errStr, "use of closed network connection") ||
-							strings.Contains(errStr, "connection reset by peer") {
-							// WebSocket is closed, stop the audio stream
-							return
-						}
-						log.Printf("Error in audio callback: %v", err)
-					}
-					// Continue trying to send, don't break the loop (unless WebSocket is closed)
+				// Check if it's a WebSocket close error - if so, stop sending
+				errStr := err.Error()
+				if strings.Contains(errStr, "websocket: close sent") ||
+					strings.Contains(errStr, "use of closed network connection") ||
+					strings.Contains(errStr, "connection reset by peer") {
+					connectionClosed = true
+				} else {
+					log.Printf("Error in audio callback: %v", err)
 				}
+				// Continue trying to send, don't break the loop (unless WebSocket is closed)
 			}
 		}
+		r.putBuffer(pcmBytes)
+	}
+}
+
+// RestartAudio re-initializes PortAudio so the next recording uses the current
+// default input device. It fails while recording.
+func (r *Recorder) RestartAudio() error {
+	r.recordingMutex.Lock()
+	defer r.recordingMutex.Unlock()
 
-		// Reduce delay to improve real-time performance
-		time.Sleep(10 * time.Millisecond)
+	if r.recording {
+		return fmt.Errorf("can't restart audio while recording")
 	}
+	r.openFailures = 0
+	return restartPortAudio()
 }
 
 // Initialize initializes PortAudio - should be called at application startup
@@ -307,3 +735,19 @@ func Initialize() error {
 func Terminate() {
 	portaudio.Terminate()
 }
+
+// restartPortAudio tears PortAudio down and brings it back up so the device
+// list (and the default input device) is enumerated from scratch
+func restartPortAudio() error {
+	portaudio.Terminate()
+	if err := portaudio.Initialize(); err != nil {
+		return err
+	}
+
+	devices, err := portaudio.Devices()
+	if err != nil {
+		return err
+	}
+	log.Printf("PortAudio restarted, %d audio devices found", len(devices))
+	return nil
+}
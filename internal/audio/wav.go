@@ -0,0 +1,94 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// wavHeaderSize is the size of the canonical 44-byte PCM WAV header
+const wavHeaderSize = 44
+
+// WriteWAV writes 16 kHz mono PCM16 audio as a WAV file
+func WriteWAV(w io.Writer, pcm []byte) error {
+	header := make([]byte, wavHeaderSize)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(36+len(pcm)))
+	copy(header[8:], "WAVE")
+	copy(header[12:], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)           // fmt chunk size
+	binary.LittleEndian.PutUint16(header[20:], 1)            // PCM
+	binary.LittleEndian.PutUint16(header[22:], 1)            // Mono
+	binary.LittleEndian.PutUint32(header[24:], SampleRate)   // Sample rate
+	binary.LittleEndian.PutUint32(header[28:], SampleRate*2) // Byte rate
+	binary.LittleEndian.PutUint16(header[32:], 2)            // Block align
+	binary.LittleEndian.PutUint16(header[34:], 16)           // Bits per sample
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], uint32(len(pcm)))
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(pcm)
+	return err
+}
+
+// PCMDuration returns how long PCM16 audio at SampleRate lasts
+func PCMDuration(pcmBytes int64) float64 {
+	return float64(pcmBytes) / float64(SampleRate*2)
+}
+
+// wavDataSize returns the PCM size of a WAV file written by WriteWAV
+func wavDataSize(fileSize int64) int64 {
+	if fileSize < wavHeaderSize {
+		return 0
+	}
+	return fileSize - wavHeaderSize
+}
+
+// ReadWAV reads the PCM16 samples of a 16 kHz mono WAV file
+func ReadWAV(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("not a WAV file")
+	}
+
+	// Walk the chunks: the format must match what the microphone produces
+	formatOK := false
+	for offset := 12; offset+8 <= len(data); {
+		id := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4:]))
+		body := data[offset+8:]
+		if size > len(body) {
+			size = len(body) // Tolerate a truncated final chunk
+		}
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, fmt.Errorf("invalid WAV format chunk")
+			}
+			audioFormat := binary.LittleEndian.Uint16(body[0:])
+			channels := binary.LittleEndian.Uint16(body[2:])
+			sampleRate := binary.LittleEndian.Uint32(body[4:])
+			bitsPerSample := binary.LittleEndian.Uint16(body[14:])
+			if audioFormat != 1 || channels != 1 || sampleRate != SampleRate || bitsPerSample != 16 {
+				return nil, fmt.Errorf("unsupported WAV format (%d Hz, %d channels, %d-bit): must be %d Hz mono 16-bit PCM", sampleRate, channels, bitsPerSample, SampleRate)
+			}
+			formatOK = true
+		case "data":
+			if !formatOK {
+				return nil, fmt.Errorf("WAV data chunk before format chunk")
+			}
+			return body[:size&^1], nil
+		}
+
+		offset += 8 + size + size%2 // Chunks are word aligned
+	}
+
+	return nil, fmt.Errorf("WAV file has no audio data")
+}
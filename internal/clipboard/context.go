@@ -0,0 +1,89 @@
+package clipboard
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework ApplicationServices
+#include <ApplicationServices/ApplicationServices.h>
+
+// characterBeforeCaret returns the UTF-16 unit offset+1 characters before the
+// caret in the focused text field, 0 past the start of the field and -1 if
+// the field can't be read
+static int characterBeforeCaret(int offset) {
+    AXUIElementRef systemWide = AXUIElementCreateSystemWide();
+    AXUIElementRef focused = NULL;
+    if (AXUIElementCopyAttributeValue(systemWide, kAXFocusedUIElementAttribute, (CFTypeRef *)&focused) != kAXErrorSuccess || focused == NULL) {
+        CFRelease(systemWide);
+        return -1;
+    }
+
+    int result = -1;
+    AXValueRef rangeValue = NULL;
+    CFRange selection;
+    if (AXUIElementCopyAttributeValue(focused, kAXSelectedTextRangeAttribute, (CFTypeRef *)&rangeValue) == kAXErrorSuccess && rangeValue != NULL &&
+        AXValueGetValue(rangeValue, kAXValueCFRangeType, &selection)) {
+        CFIndex index = selection.location - 1 - offset;
+        if (index < 0) {
+            result = 0;
+        } else {
+            // Ask for just that character, falling back to the whole value for
+            // apps that don't support parameterized attributes
+            CFRange charRange = CFRangeMake(index, 1);
+            AXValueRef charRangeValue = AXValueCreate(kAXValueCFRangeType, &charRange);
+            CFTypeRef text = NULL;
+            if (AXUIElementCopyParameterizedAttributeValue(focused, kAXStringForRangeParameterizedAttribute, charRangeValue, &text) == kAXErrorSuccess && text != NULL &&
+                CFGetTypeID(text) == CFStringGetTypeID() && CFStringGetLength(text) > 0) {
+                result = CFStringGetCharacterAtIndex(text, 0);
+            } else {
+                if (text != NULL) {
+                    CFRelease(text);
+                    text = NULL;
+                }
+                if (AXUIElementCopyAttributeValue(focused, kAXValueAttribute, &text) == kAXErrorSuccess && text != NULL &&
+                    CFGetTypeID(text) == CFStringGetTypeID() && CFStringGetLength(text) > index) {
+                    result = CFStringGetCharacterAtIndex(text, index);
+                }
+            }
+            if (text != NULL) {
+                CFRelease(text);
+            }
+            CFRelease(charRangeValue);
+        }
+    }
+
+    if (rangeValue != NULL) {
+        CFRelease(rangeValue);
+    }
+    CFRelease(focused);
+    CFRelease(systemWide);
+    return result;
+}
+*/
+import "C"
+
+import "unicode"
+
+// maxLookBehind bounds how far TextBeforeCursor walks back over whitespace
+const maxLookBehind = 8
+
+// TextBeforeCursor returns the text in front of the cursor in the focused
+// field, back to and including the last non-space character. It returns ""
+// at the start of a field, and false if the field can't be read (e.g. no
+// Accessibility permission or an app that doesn't expose its text).
+func TextBeforeCursor() (string, bool) {
+	var chars []rune
+	for offset := 0; offset < maxLookBehind; offset++ {
+		char := int(C.characterBeforeCaret(C.int(offset)))
+		if char < 0 {
+			return "", false
+		}
+		if char == 0 {
+			break // Start of the field
+		}
+
+		chars = append([]rune{rune(char)}, chars...)
+		if !unicode.IsSpace(rune(char)) {
+			break
+		}
+	}
+	return string(chars), true
+}
@@ -13,20 +13,22 @@ func PasteTextSafely(text string) error {
 		return fmt.Errorf("empty text")
 	}
 
-	// Copy text to clipboard using macOS pbcopy command
-	cmd := exec.Command("pbcopy")
-	cmd.Stdin = strings.NewReader(text)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to copy to clipboard: %v", err)
+	if err := CopyText(text); err != nil {
+		return err
 	}
 
+	return sendPaste()
+}
+
+// sendPaste presses Cmd+V in the active application
+func sendPaste() error {
 	// Small delay to ensure clipboard is set
 	time.Sleep(200 * time.Millisecond)
 
 	// Try different paste methods for better compatibility
 	// Method 1: Try simple AppleScript paste first
 	script := `tell application "System Events" to keystroke "v" using command down`
-	cmd = exec.Command("osascript", "-e", script)
+	cmd := exec.Command("osascript", "-e", script)
 	if err := cmd.Run(); err != nil {
 		// Method 2: If that fails, try with longer delay and error handling
 		time.Sleep(500 * time.Millisecond)
@@ -50,3 +52,52 @@ func PasteTextSafely(text string) error {
 
 	return nil
 }
+
+// CopyText places text on the clipboard without pasting it
+func CopyText(text string) error {
+	// Copy text to clipboard using macOS pbcopy command
+	cmd := exec.Command("pbcopy")
+	cmd.Stdin = strings.NewReader(text)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %v", err)
+	}
+	return nil
+}
+
+// FrontmostBundleID returns the bundle identifier of the application that currently has focus
+func FrontmostBundleID() string {
+	script := `tell application "System Events" to get bundle identifier of first application process whose frontmost is true`
+	output, err := exec.Command("osascript", "-e", script).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// FrontmostApp returns the name of the application that currently has focus
+func FrontmostApp() string {
+	script := `tell application "System Events" to get name of first application process whose frontmost is true`
+	output, err := exec.Command("osascript", "-e", script).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// Undo sends Cmd+Z to the active application
+func Undo() error {
+	script := `tell application "System Events" to keystroke "z" using command down`
+	if err := exec.Command("osascript", "-e", script).Run(); err != nil {
+		return fmt.Errorf("failed to undo: %v", err)
+	}
+	return nil
+}
+
+// PressReturn sends a Return keystroke to the active application
+func PressReturn() error {
+	script := `tell application "System Events" to key code 36`
+	if err := exec.Command("osascript", "-e", script).Run(); err != nil {
+		return fmt.Errorf("failed to press Return: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package clipboard
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// richScript sets plain text, HTML and RTF flavors on the general pasteboard
+// in one go, taking them as arguments
+const richScript = `
+ObjC.import('AppKit');
+function run(argv) {
+	var pasteboard = $.NSPasteboard.generalPasteboard;
+	pasteboard.clearContents;
+	pasteboard.setStringForType($(argv[0]), $.NSPasteboardTypeString);
+	pasteboard.setStringForType($(argv[1]), $.NSPasteboardTypeHTML);
+	if (argv[2] !== '') {
+		pasteboard.setDataForType($(argv[2]).dataUsingEncoding($.NSUTF8StringEncoding), $.NSPasteboardTypeRTF);
+	}
+}`
+
+// CopyRich places text on the clipboard along with HTML and RTF renderings of
+// it, so apps like Notes, Word and Gmail keep the formatting while plain text
+// editors still get text
+func CopyRich(text string, html string) error {
+	// RTF is a bonus for apps that don't read HTML; go on without it if textutil fails
+	rtf, err := htmlToRTF(html)
+	if err != nil {
+		rtf = ""
+	}
+
+	output, err := exec.Command("osascript", "-l", "JavaScript", "-e", richScript, text, html, rtf).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to copy rich text to clipboard: %v (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// PasteRichText copies text with its HTML rendering and pastes it
+func PasteRichText(text string, html string) error {
+	if text == "" {
+		return fmt.Errorf("empty text")
+	}
+
+	if err := CopyRich(text, html); err != nil {
+		return err
+	}
+
+	return sendPaste()
+}
+
+// htmlToRTF converts an HTML fragment to RTF with macOS textutil
+func htmlToRTF(html string) (string, error) {
+	cmd := exec.Command("textutil", "-stdin", "-stdout", "-format", "html", "-convert", "rtf", "-inputencoding", "UTF-8")
+	cmd.Stdin = strings.NewReader(`<meta charset="utf-8">` + html)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to convert to RTF: %v", err)
+	}
+	return string(output), nil
+}
@@ -0,0 +1,68 @@
+package clipboard
+
+/*
+#cgo LDFLAGS: -framework ApplicationServices
+#include <ApplicationServices/ApplicationServices.h>
+
+// postKey posts a key down/up pair with no modifiers, so a held hotkey
+// doesn't turn typed text into shortcuts
+static void postKey(CGKeyCode keyCode, UniChar *chars, UniCharCount length) {
+    CGEventRef down = CGEventCreateKeyboardEvent(NULL, keyCode, true);
+    CGEventRef up = CGEventCreateKeyboardEvent(NULL, keyCode, false);
+    if (length > 0) {
+        CGEventKeyboardSetUnicodeString(down, length, chars);
+        CGEventKeyboardSetUnicodeString(up, length, chars);
+    }
+    CGEventSetFlags(down, 0);
+    CGEventSetFlags(up, 0);
+    CGEventPost(kCGHIDEventTap, down);
+    CGEventPost(kCGHIDEventTap, up);
+    CFRelease(down);
+    CFRelease(up);
+}
+
+// typeCharacter types one character given as one or two UTF-16 units
+static void typeCharacter(int first, int second) {
+    UniChar chars[2] = {(UniChar)first, (UniChar)second};
+    postKey(0, chars, second ? 2 : 1);
+}
+
+static void pressDelete() {
+    postKey(51, NULL, 0); // kVK_Delete
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"unicode/utf16"
+)
+
+// TypeText types text into the focused app as synthesized key presses
+func TypeText(text string) error {
+	if runtime.GOOS != "darwin" {
+		return fmt.Errorf("typing is only supported on macOS")
+	}
+
+	for _, r := range text {
+		first, second := r, rune(0)
+		if r >= 0x10000 {
+			first, second = utf16.EncodeRune(r)
+		}
+		C.typeCharacter(C.int(first), C.int(second))
+	}
+	return nil
+}
+
+// Backspace deletes count characters before the cursor in the focused app
+func Backspace(count int) error {
+	if runtime.GOOS != "darwin" {
+		return fmt.Errorf("typing is only supported on macOS")
+	}
+
+	for i := 0; i < count; i++ {
+		C.pressDelete()
+	}
+	return nil
+}
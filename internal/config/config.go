@@ -10,38 +10,320 @@ import (
 	"strings"
 
 	"github.com/joho/godotenv"
+
+	"github.com/bezmoradi/t2/internal/terminal"
 )
 
 const (
 	configFileName = "config.json"
 	configDirName  = "t2"
 	metricsSubDir  = "metrics"
+	audioSubDir    = "recordings"
+	historyFile    = "history.json"
+
+	versionCacheFileName = "version-check.json"
+	lockFileName         = "t2.pid"
+	logFileName          = "t2.log"
+	goroutineDumpName    = "goroutines.txt"
+
+	profilesDirName = "profiles"
+
+	// ProfileEnvVar selects a profile when --profile isn't given
+	ProfileEnvVar = "T2_PROFILE"
+
+	// ConfigDirEnvVar keeps all of T2's files (config and data) in one directory
+	ConfigDirEnvVar = "T2_CONFIG_DIR"
 )
 
+// activeProfile is the selected profile name, empty for the default profile
+var activeProfile string
+
 // Config represents the application configuration
 type Config struct {
 	AssemblyAIKey string `json:"assemblyai_key"`
 	TypingSpeed   int    `json:"typing_speed,omitempty"` // User's typing speed in WPM
+
+	StandbyConnection bool `json:"standby_connection,omitempty"` // Keep a spare WebSocket ready for instant reconnects
+	PersistentSession bool `json:"persistent_session,omitempty"` // Keep one streaming session open between recordings
+
+	EndOfTurnConfidence float64 `json:"end_of_turn_confidence,omitempty"` // Confidence (0-1) the provider needs to end a turn early
+	MinTurnSilenceMs    int     `json:"min_turn_silence_ms,omitempty"`    // Silence before a confident turn ends
+	MaxTurnSilenceMs    int     `json:"max_turn_silence_ms,omitempty"`    // Silence that always ends a turn
+
+	AudioChunkMs     int    `json:"audio_chunk_ms,omitempty"`     // Audio per chunk sent to the provider (default 64)
+	AudioQueuePolicy string `json:"audio_queue_policy,omitempty"` // "drop" (default), "drop_oldest" or "block" when the network falls behind
+
+	InputChannels    map[string]int `json:"input_channels,omitempty"`    // Channel (1-based) to record per audio interface, keyed by part of its name
+	SilenceThreshold float64        `json:"silence_threshold,omitempty"` // Fixed RMS below which audio is silence; 0 adapts to ambient noise
+
+	SaveAudio          bool `json:"save_audio,omitempty"`           // Keep each session's audio as a WAV file
+	AudioRetentionDays int  `json:"audio_retention_days,omitempty"` // Delete saved audio older than this (default 7)
+	AudioMaxMB         int  `json:"audio_max_mb,omitempty"`         // Delete the oldest saved audio beyond this size (default 500)
+
+	LoopbackDevice string `json:"loopback_device,omitempty"` // Input device t2 capture records system audio from (default "BlackHole")
+
+	DisableBeeps bool              `json:"disable_beeps,omitempty"` // Play no start/stop/error sounds
+	BeepVolume   int               `json:"beep_volume,omitempty"`   // Sound volume in percent, 1-100 (default: full-volume system beep)
+	BeepSounds   map[string]string `json:"beep_sounds,omitempty"`   // Sound file per "start", "stop", "skipped", "warning" or "error"
+	Feedback     string            `json:"feedback,omitempty"`      // "sound" (default), "flash", "notification" or "haptic"
+
+	MaxSessionSeconds int `json:"max_session_seconds,omitempty"`  // Stop and transcribe sessions after this long (default 300)
+	AutoStopSilenceMs int `json:"auto_stop_silence_ms,omitempty"` // End a session after this pause following speech, even with the hotkey held
+	ReleaseGraceMs    int `json:"release_grace_ms,omitempty"`     // Keep recording this long after the hotkey is released
+
+	QuickPressMode        string `json:"quick_press_mode,omitempty"`         // "duration" (default) or "transcript"
+	QuickPressThresholdMs int    `json:"quick_press_threshold_ms,omitempty"` // Presses shorter than this are skipped in duration mode
+
+	RemoteListenAddr string `json:"remote_listen_addr,omitempty"` // e.g. ":7766" to accept remote start/stop triggers
+	RemoteToken      string `json:"remote_token,omitempty"`       // Shared secret required by remote triggers
+
+	AutoEnter     bool     `json:"auto_enter,omitempty"`      // Press Return after every paste
+	AutoEnterApps []string `json:"auto_enter_apps,omitempty"` // Press Return after pasting into these apps only
+
+	ClipboardOnly    bool     `json:"clipboard_only,omitempty"`     // Copy transcripts to the clipboard without pressing Cmd+V
+	RichPaste        bool     `json:"rich_paste,omitempty"`         // Paste Markdown as formatted text where the app supports it
+	NeverPasteApps   []string `json:"never_paste_apps,omitempty"`   // Bundle IDs T2 must never paste into, e.g. banking or screen-sharing apps
+	NeverPasteAction string   `json:"never_paste_action,omitempty"` // "copy" (default) puts the transcript on the clipboard, "hold" keeps it for review
+
+	TrailingWhitespace string `json:"trailing_whitespace,omitempty"` // "space" (default), "none" or "newline"
+
+	Lowercase          bool `json:"lowercase,omitempty"`           // Lowercase all output
+	LowercaseFirst     bool `json:"lowercase_first,omitempty"`     // Don't capitalize the first letter
+	DisablePunctuation bool `json:"disable_punctuation,omitempty"` // Ask the provider not to format turns
+	DetectLanguage     bool `json:"detect_language,omitempty"`     // Detect the spoken language per session (multilingual model)
+	SmartJoin          bool `json:"smart_join,omitempty"`          // Match spacing and capitalization to the text before the cursor
+	ComposeMode        bool `json:"compose_mode,omitempty"`        // Collect dictations into a draft and paste it with the commit hotkey
+	LiveTyping         bool `json:"live_typing,omitempty"`         // Type the transcript into the app while speaking
+
+	FilterProfanity bool     `json:"filter_profanity,omitempty"` // Mask profanity before pasting
+	RedactPII       []string `json:"redact_pii,omitempty"`       // "email", "phone", "credit_card", "ssn" or "all"
+	NumberStyle     string   `json:"number_style,omitempty"`     // "digits" or "words"; unset leaves numbers as transcribed
+	FormatEntities  []string `json:"format_entities,omitempty"`  // "currency", "dates", "phone", "email" or "all"
+	FormatLocale    string   `json:"format_locale,omitempty"`    // Regional conventions for decimals, dates, amounts and units, e.g. "de-DE" or "en-GB"
+
+	NumberStyleApps    map[string]string   `json:"number_style_apps,omitempty"`    // number_style per app name, e.g. {"Numbers": "digits"}
+	FormatEntitiesApps map[string][]string `json:"format_entities_apps,omitempty"` // format_entities per app name; [] turns them off there
+
+	MinConfidence float64 `json:"min_confidence,omitempty"` // Hold transcripts below this confidence (0-1) for confirmation
+
+	VoiceCommands       bool              `json:"voice_commands,omitempty"`        // Handle "scratch that", "undo" and "send it" locally
+	VoiceCommandPhrases map[string]string `json:"voice_command_phrases,omitempty"` // Extra phrases mapped to "scratch", "undo" or "send" ("" disables one)
+
+	HistorySize     int    `json:"history_size,omitempty"`     // Recent transcripts kept for t2 pick (default 20, -1 turns history off)
+	HistoryIdentity string `json:"history_identity,omitempty"` // age identity file to encrypt the history with; see also T2_HISTORY_PASSPHRASE
+
+	RetentionDays int `json:"retention_days,omitempty"` // Delete statistics, history and saved audio older than this many days
+
+	ReportTime   string `json:"report_time,omitempty"`   // "HH:MM" to get a notification summarizing your dictation; unset turns it off
+	ReportPeriod string `json:"report_period,omitempty"` // "daily" (default) or "weekly", sent on Fridays
+
+	EventsURL    string   `json:"events_url,omitempty"`    // POST session events as JSON here, e.g. "http://127.0.0.1:7767/t2"
+	EventsScript string   `json:"events_script,omitempty"` // Run this shell command per session event, with the event as JSON on stdin
+	FocusBlocks  []string `json:"focus_blocks,omitempty"`  // Daily "HH:MM-HH:MM" spans during which T2 doesn't listen
+
+	ActivityWatchURL string `json:"activitywatch_url,omitempty"` // Export sessions to this ActivityWatch server, e.g. "http://localhost:5600"
+	RescueTimeKey    string `json:"rescuetime_key,omitempty"`    // Export sessions to RescueTime as offline time with this API key
+
+	TranslateTo     string `json:"translate_to,omitempty"`     // Language to translate into when releasing with Fn, e.g. "English"
+	TranslateAlways bool   `json:"translate_always,omitempty"` // Translate every dictation, not just Fn releases
+
+	LLMProvider string `json:"llm_provider,omitempty"` // "anthropic" or "openai", for t2 summarize and translation
+	LLMModel    string `json:"llm_model,omitempty"`    // Defaults to the provider's default model
+	LLMAPIKey   string `json:"llm_api_key,omitempty"`  // Defaults to ANTHROPIC_API_KEY or OPENAI_API_KEY
+
+	MetricsDir string `json:"metrics_dir,omitempty"` // Keep statistics here instead of the data directory, e.g. a synced folder shared by several Macs
+	SessionTag string `json:"session_tag,omitempty"` // Label recorded with each session, e.g. a project name
+
+	Snippets map[string]string `json:"snippets,omitempty"` // Spoken phrases expanded locally to stored text, e.g. {"insert signature": "Best,\nJane"}
+
+	WakeWord string `json:"wake_word,omitempty"` // Always listen and start a session when this is said, e.g. "hey t2"
+
+	MIDITrigger string `json:"midi_trigger,omitempty"` // Use a MIDI note or CC as the hotkey, e.g. "note 60" or "cc 64"
+
+	StreamingURL string `json:"streaming_url,omitempty"` // Override the streaming endpoint, e.g. a regional endpoint or gateway
+	ProxyURL     string `json:"proxy_url,omitempty"`     // http:// or socks5:// proxy; defaults to HTTPS_PROXY/HTTP_PROXY
+
+	Locale   string `json:"locale,omitempty"`   // Language for terminal output, e.g. "de"; defaults to LANG
+	Timezone string `json:"timezone,omitempty"` // Time zone statistics count days in, e.g. "Europe/Berlin"; defaults to the system's
 }
 
-// getConfigDir returns the user's config directory for T2
+// Trailing whitespace options appended after each transcript
+const (
+	TrailingSpace   = "space"
+	TrailingNone    = "none"
+	TrailingNewline = "newline"
+)
+
+// Clone returns a deep copy of c, so maps and slices can be changed in one
+// without affecting the other
+func (c *Config) Clone() (*Config, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	clone := &Config{}
+	if err := json.Unmarshal(data, clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// TrailingSuffix returns the text to append after each transcript
+func (c *Config) TrailingSuffix() string {
+	switch c.TrailingWhitespace {
+	case TrailingNone:
+		return ""
+	case TrailingNewline:
+		return "\n"
+	default:
+		return " "
+	}
+}
+
+// ShouldAutoEnter reports whether Return should follow a paste into app
+func (c *Config) ShouldAutoEnter(app string) bool {
+	if c.AutoEnter {
+		return true
+	}
+	for _, name := range c.AutoEnterApps {
+		if strings.EqualFold(name, app) {
+			return true
+		}
+	}
+	return false
+}
+
+// NumberStyleFor returns the number style for dictating into app:
+// number_style_apps if it names the app, else number_style
+func (c *Config) NumberStyleFor(app string) string {
+	for name, style := range c.NumberStyleApps {
+		if strings.EqualFold(name, app) {
+			return style
+		}
+	}
+	return c.NumberStyle
+}
+
+// EntitiesFor returns the entity types to write out when dictating into app:
+// format_entities_apps if it names the app, else format_entities
+func (c *Config) EntitiesFor(app string) []string {
+	for name, types := range c.FormatEntitiesApps {
+		if strings.EqualFold(name, app) {
+			return types
+		}
+	}
+	return c.FormatEntities
+}
+
+// NeverPasteInto reports whether bundleID is on never_paste_apps
+func (c *Config) NeverPasteInto(bundleID string) bool {
+	for _, id := range c.NeverPasteApps {
+		if strings.EqualFold(id, bundleID) {
+			return true
+		}
+	}
+	return false
+}
+
+// What happens to a transcript meant for an app on never_paste_apps
+const (
+	NeverPasteCopy = "copy" // Put it on the clipboard only
+	NeverPasteHold = "hold" // Keep it until the hotkey is quick-pressed in another app
+)
+
+// Quick-press modes decide how accidental short presses are filtered out
+const (
+	QuickPressDuration   = "duration"   // Skip presses shorter than the threshold
+	QuickPressTranscript = "transcript" // Always transcribe, skip only if nothing was said
+)
+
+// Report periods for report_period
+const (
+	ReportDaily  = "daily"
+	ReportWeekly = "weekly"
+)
+
+// getConfigDir returns the user's config directory for T2: $T2_CONFIG_DIR,
+// $XDG_CONFIG_HOME/t2 or ~/.config/t2
 func getConfigDir() (string, error) {
+	return xdgDir(os.Getenv("XDG_CONFIG_HOME"), ".config")
+}
+
+// getDataDir returns where T2 keeps user data such as metrics: $T2_CONFIG_DIR,
+// $XDG_DATA_HOME/t2 or ~/.local/share/t2
+func getDataDir() (string, error) {
+	return xdgDir(os.Getenv("XDG_DATA_HOME"), filepath.Join(".local", "share"))
+}
+
+// xdgDir resolves a T2 directory following the XDG base directory spec,
+// which only allows absolute paths in the environment variables
+func xdgDir(xdgHome string, homeFallback string) (string, error) {
+	if dir := os.Getenv(ConfigDirEnvVar); dir != "" {
+		return dir, nil
+	}
+
+	if xdgHome != "" && filepath.IsAbs(xdgHome) {
+		return filepath.Join(xdgHome, configDirName), nil
+	}
+
 	usr, err := user.Current()
 	if err != nil {
 		return "", err
 	}
 
-	configDir := filepath.Join(usr.HomeDir, ".config", configDirName)
-	return configDir, nil
+	return filepath.Join(usr.HomeDir, homeFallback, configDirName), nil
+}
+
+// legacyConfigDir is where every T2 file lived before config and data were split
+func legacyConfigDir() (string, error) {
+	usr, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(usr.HomeDir, ".config", configDirName), nil
+}
+
+// withProfile appends the active profile's subdirectory to dir.
+// The default profile lives directly in dir.
+func withProfile(dir string) string {
+	if activeProfile == "" {
+		return dir
+	}
+	return filepath.Join(dir, profilesDirName, activeProfile)
+}
+
+// getProfileDir returns the directory holding the active profile's config
+func getProfileDir() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return withProfile(configDir), nil
+}
+
+// SetProfile selects the profile used for config and metrics paths
+func SetProfile(name string) error {
+	if name != "" && (name == "." || name == ".." || strings.ContainsAny(name, `/\`)) {
+		return fmt.Errorf("invalid profile name %q", name)
+	}
+	activeProfile = name
+	return nil
+}
+
+// GetProfile returns the active profile name, empty for the default profile
+func GetProfile() string {
+	return activeProfile
 }
 
 // getConfigPath returns the full path to the config file
 func getConfigPath() (string, error) {
-	configDir, err := getConfigDir()
+	profileDir, err := getProfileDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(configDir, configFileName), nil
+	return filepath.Join(profileDir, configFileName), nil
 }
 
 // LoadConfig loads configuration from file
@@ -70,13 +352,13 @@ func LoadConfig() (*Config, error) {
 
 // SaveConfig saves configuration to file
 func SaveConfig(config *Config) error {
-	configDir, err := getConfigDir()
+	profileDir, err := getProfileDir()
 	if err != nil {
 		return err
 	}
 
 	// Create config directory if it doesn't exist
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	if err := os.MkdirAll(profileDir, 0755); err != nil {
 		return err
 	}
 
@@ -105,13 +387,13 @@ func GetConfigPath() (string, error) {
 
 // promptForAPIKey prompts user to enter their AssemblyAI API key
 func promptForAPIKey() (string, error) {
-	fmt.Println("🔑 AssemblyAI API key not found.")
-	fmt.Println("📋 To get your free API key:")
-	fmt.Println("   1. Visit: https://www.assemblyai.com/")
-	fmt.Println("   2. Sign up and get your API key from the dashboard")
-	fmt.Println("   3. You get 5 hours of free transcription monthly")
-	fmt.Println()
-	fmt.Print("🔐 Please enter your AssemblyAI API key: ")
+	terminal.Println("🔑 AssemblyAI API key not found.")
+	terminal.Println("📋 To get your free API key:")
+	terminal.Println("   1. Visit: https://www.assemblyai.com/")
+	terminal.Println("   2. Sign up and get your API key from the dashboard")
+	terminal.Println("   3. You get 5 hours of free transcription monthly")
+	terminal.Println()
+	terminal.Print("🔐 Please enter your AssemblyAI API key: ")
 
 	scanner := bufio.NewScanner(os.Stdin)
 	if !scanner.Scan() {
@@ -160,8 +442,8 @@ func GetAPIKey() (string, error) {
 
 	// Validate API key format
 	if !validateAPIKey(apiKey) {
-		fmt.Println("⚠️  Warning: API key format seems unusual (expected 30-50 characters)")
-		fmt.Print("🤔 Continue anyway? (y/n): ")
+		terminal.Println("⚠️  Warning: API key format seems unusual (expected 30-50 characters)")
+		terminal.Print("🤔 Continue anyway? (y/n): ")
 		scanner := bufio.NewScanner(os.Stdin)
 		if scanner.Scan() {
 			response := strings.ToLower(strings.TrimSpace(scanner.Text()))
@@ -176,22 +458,127 @@ func GetAPIKey() (string, error) {
 		AssemblyAIKey: apiKey,
 	}
 	if err := SaveConfig(newConfig); err != nil {
-		fmt.Printf("⚠️  Warning: Failed to save API key: %v\n", err)
-		fmt.Println("💡 You'll need to enter it again next time")
+		terminal.Printf("⚠️  Warning: Failed to save API key: %v\n", err)
+		terminal.Println("💡 You'll need to enter it again next time")
 	} else {
 		configPath, _ := getConfigPath()
-		fmt.Printf("✅ API key saved securely to %s\n", configPath)
+		terminal.Printf("✅ API key saved securely to %s\n", configPath)
 	}
 
 	return apiKey, nil
 }
 
-// GetMetricsDir returns the metrics directory path
+// GetMetricsDir returns the metrics directory path for the active profile,
+// or metrics_dir if it's set, e.g. to a synced folder
 func GetMetricsDir() (string, error) {
+	if cfg, err := LoadConfig(); err == nil && cfg.MetricsDir != "" {
+		return cfg.MetricsDir, nil
+	}
+
+	dataDir, err := getDataDir()
+	if err != nil {
+		return "", err
+	}
+
+	metricsDir := filepath.Join(withProfile(dataDir), metricsSubDir)
+	migrateLegacyMetrics(metricsDir)
+	return metricsDir, nil
+}
+
+// GetRecordingsDir returns where session audio is saved for the active profile
+func GetRecordingsDir() (string, error) {
+	dataDir, err := getDataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(withProfile(dataDir), audioSubDir), nil
+}
+
+// GetHistoryPath returns where recent transcripts are kept for the active profile
+func GetHistoryPath() (string, error) {
+	dataDir, err := getDataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(withProfile(dataDir), historyFile), nil
+}
+
+// migrateLegacyMetrics moves metrics from ~/.config/t2 to the data directory
+// the first time the data directory is used
+func migrateLegacyMetrics(metricsDir string) {
+	// An explicit T2_CONFIG_DIR is a separate setup, not an upgrade
+	if os.Getenv(ConfigDirEnvVar) != "" {
+		return
+	}
+
+	legacyDir, err := legacyConfigDir()
+	if err != nil {
+		return
+	}
+
+	legacyMetricsDir := filepath.Join(withProfile(legacyDir), metricsSubDir)
+	if legacyMetricsDir == metricsDir {
+		return
+	}
+
+	if _, err := os.Stat(legacyMetricsDir); err != nil {
+		return
+	}
+	if _, err := os.Stat(metricsDir); err == nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(metricsDir), 0755); err != nil {
+		return
+	}
+	if err := os.Rename(legacyMetricsDir, metricsDir); err == nil {
+		// stderr so --json output stays parseable
+		terminal.Fprintf(os.Stderr, "📦 Moved usage statistics to %s\n", metricsDir)
+	}
+}
+
+// GetVersionCachePath returns the path of the cached update check result
+func GetVersionCachePath() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, versionCacheFileName), nil
+}
+
+// GetLockPath returns the path of the single-instance lock (and PID) file
+func GetLockPath() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, lockFileName), nil
+}
+
+// GetLogPath returns the path the background daemon logs to
+func GetLogPath() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, logFileName), nil
+}
+
+// GetGoroutineDumpPath returns where the daemon writes goroutine dumps on SIGUSR1
+func GetGoroutineDumpPath() (string, error) {
 	configDir, err := getConfigDir()
 	if err != nil {
 		return "", err
 	}
 
-	return filepath.Join(configDir, metricsSubDir), nil
+	return filepath.Join(configDir, goroutineDumpName), nil
 }
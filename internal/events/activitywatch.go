@@ -0,0 +1,90 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// ActivityWatch exports finished sessions to a bucket of a local
+// ActivityWatch server, creating the bucket on first use
+type ActivityWatch struct {
+	client   *http.Client
+	url      string // As configured
+	baseURL  string
+	bucket   string
+	hostname string
+	created  bool
+}
+
+// NewActivityWatch returns an exporter for the ActivityWatch server at
+// baseURL, e.g. "http://localhost:5600"
+func NewActivityWatch(baseURL string) *ActivityWatch {
+	hostname, _ := os.Hostname()
+	return &ActivityWatch{
+		client:   &http.Client{Timeout: deliveryTimeout},
+		url:      baseURL,
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		bucket:   "t2_" + hostname,
+		hostname: hostname,
+	}
+}
+
+func (a *ActivityWatch) Name() string {
+	return "ActivityWatch"
+}
+
+// Export adds a finished session to the bucket as an event spanning the recording
+func (a *ActivityWatch) Export(event Event) error {
+	if event.Type != Finished {
+		return nil
+	}
+	if !a.created {
+		if err := a.createBucket(); err != nil {
+			return fmt.Errorf("failed to create bucket %s: %v", a.bucket, err)
+		}
+		a.created = true
+	}
+
+	duration := time.Duration(event.DurationMs) * time.Millisecond
+	return a.post("/events", []map[string]any{{
+		"timestamp": event.Time.Add(-duration),
+		"duration":  duration.Seconds(),
+		"data": map[string]any{
+			"words":      event.Words,
+			"app":        event.App,
+			"session_id": event.SessionID,
+		},
+	}})
+}
+
+// createBucket creates the bucket; ActivityWatch answers 304 if it exists
+func (a *ActivityWatch) createBucket() error {
+	return a.post("", map[string]string{
+		"client":   "t2",
+		"type":     "app.t2.dictation",
+		"hostname": a.hostname,
+	})
+}
+
+func (a *ActivityWatch) post(path string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	endpoint := a.baseURL + "/api/0/buckets/" + url.PathEscape(a.bucket) + path
+	resp, err := a.client.Post(endpoint, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotModified {
+		return fmt.Errorf("%s returned %s", endpoint, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// Package events tells focus and time-tracking tools about dictation sessions,
+// by posting them to a local URL or piping them to a script
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Event types
+const (
+	Started  = "started"  // Recording started
+	Finished = "finished" // A transcript was pasted or copied
+	Skipped  = "skipped"  // The session ended without a transcript
+)
+
+const (
+	// deliveryTimeout bounds each POST and script run, so a hung consumer
+	// can't hold up the events after it
+	deliveryTimeout = 10 * time.Second
+
+	// queueSize is how many events wait for delivery before new ones are dropped
+	queueSize = 64
+)
+
+// Event is one session event, sent as JSON
+type Event struct {
+	Type       string    `json:"type"`
+	Time       time.Time `json:"time"`
+	SessionID  string    `json:"session_id,omitempty"`
+	DurationMs int64     `json:"duration_ms,omitempty"` // Recording length of finished and skipped sessions
+	Words      int       `json:"words,omitempty"`
+	App        string    `json:"app,omitempty"`    // Where the transcript went
+	Reason     string    `json:"reason,omitempty"` // Why a session was skipped
+}
+
+// Exporter pushes events to a time-tracking service
+type Exporter interface {
+	Name() string
+	Export(event Event) error
+}
+
+// Sink delivers events in order on a goroutine of its own, so a slow
+// consumer never delays a paste
+type Sink struct {
+	client *http.Client
+	queue  chan Event
+
+	mutex         sync.Mutex // Guards url, script and the exporters
+	url           string
+	script        string
+	activityWatch *ActivityWatch
+	rescueTime    *RescueTime
+	exporters     []Exporter
+}
+
+// NewSink returns a sink that delivers nothing until it's configured
+func NewSink() *Sink {
+	s := &Sink{
+		client: &http.Client{Timeout: deliveryTimeout},
+		queue:  make(chan Event, queueSize),
+	}
+	go s.deliver()
+	return s
+}
+
+// Configure sets the URL events are posted to and the script they're piped
+// to; "" turns either off
+func (s *Sink) Configure(url string, script string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.url, s.script = url, script
+}
+
+// ConfigureTrackers sets the ActivityWatch server and RescueTime API key
+// sessions are exported to; "" turns either off. Unchanged settings keep
+// their exporter, so RescueTime doesn't lose the minute it's adding up.
+func (s *Sink) ConfigureTrackers(activityWatchURL string, rescueTimeKey string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if activityWatchURL == "" {
+		s.activityWatch = nil
+	} else if s.activityWatch == nil || s.activityWatch.url != activityWatchURL {
+		s.activityWatch = NewActivityWatch(activityWatchURL)
+	}
+	if rescueTimeKey == "" {
+		s.rescueTime = nil
+	} else if s.rescueTime == nil || s.rescueTime.key != rescueTimeKey {
+		s.rescueTime = NewRescueTime(rescueTimeKey)
+	}
+
+	s.exporters = nil
+	if s.activityWatch != nil {
+		s.exporters = append(s.exporters, s.activityWatch)
+	}
+	if s.rescueTime != nil {
+		s.exporters = append(s.exporters, s.rescueTime)
+	}
+}
+
+// Emit queues an event for delivery
+func (s *Sink) Emit(event Event) {
+	s.mutex.Lock()
+	enabled := s.url != "" || s.script != "" || len(s.exporters) > 0
+	s.mutex.Unlock()
+	if !enabled {
+		return
+	}
+
+	select {
+	case s.queue <- event:
+	default:
+		log.Printf("[EVENTS] Dropped %s event, delivery is falling behind", event.Type)
+	}
+}
+
+func (s *Sink) deliver() {
+	for event := range s.queue {
+		data, err := json.Marshal(event)
+		if err != nil {
+			log.Printf("[EVENTS] Failed to encode %s event: %v", event.Type, err)
+			continue
+		}
+
+		s.mutex.Lock()
+		url, script, exporters := s.url, s.script, s.exporters
+		s.mutex.Unlock()
+
+		if url != "" {
+			if err := s.post(url, data); err != nil {
+				log.Printf("[EVENTS] Failed to post %s event: %v", event.Type, err)
+			}
+		}
+		if script != "" {
+			if err := runScript(script, event.Type, data); err != nil {
+				log.Printf("[EVENTS] events_script failed for %s event: %v", event.Type, err)
+			}
+		}
+		for _, exporter := range exporters {
+			if err := exporter.Export(event); err != nil {
+				log.Printf("[EVENTS] Failed to export %s event to %s: %v", event.Type, exporter.Name(), err)
+			}
+		}
+	}
+}
+
+func (s *Sink) post(url string, data []byte) error {
+	resp, err := s.client.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+// runScript runs script through the shell with the event on stdin and its
+// type in T2_EVENT
+func runScript(script string, eventType string, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", script)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Env = append(os.Environ(), "T2_EVENT="+eventType)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%v: %s", err, bytes.TrimSpace(output))
+	}
+	return nil
+}
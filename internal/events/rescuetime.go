@@ -0,0 +1,97 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// rescueTimeOfflineURL is RescueTime's offline time API
+const rescueTimeOfflineURL = "https://www.rescuetime.com/anapi/offline_time_post"
+
+// RescueTime exports finished sessions to RescueTime as offline time. It
+// only takes whole minutes, so sessions are added up until a minute is full.
+type RescueTime struct {
+	client *http.Client
+	key    string
+
+	// Dictation not posted yet, owned by the sink's delivery goroutine
+	pendingStart    time.Time
+	pending         time.Duration
+	pendingSessions int
+	pendingWords    int
+}
+
+// NewRescueTime returns an exporter using a RescueTime API key
+func NewRescueTime(key string) *RescueTime {
+	return &RescueTime{
+		client: &http.Client{Timeout: deliveryTimeout},
+		key:    key,
+	}
+}
+
+func (r *RescueTime) Name() string {
+	return "RescueTime"
+}
+
+// Export adds a finished session to the pending dictation and logs the whole
+// minutes of it as offline time; the rest waits for the next session
+func (r *RescueTime) Export(event Event) error {
+	if event.Type != Finished {
+		return nil
+	}
+
+	duration := time.Duration(event.DurationMs) * time.Millisecond
+	if r.pending == 0 {
+		r.pendingStart = event.Time.Add(-duration)
+	}
+	r.pending += duration
+	r.pendingSessions++
+	r.pendingWords += event.Words
+
+	minutes := int(r.pending / time.Minute)
+	if minutes == 0 {
+		return nil
+	}
+	if err := r.post(minutes); err != nil {
+		return err // Kept pending, so the next session retries
+	}
+
+	r.pending -= time.Duration(minutes) * time.Minute
+	r.pendingStart = event.Time.Add(-r.pending)
+	r.pendingSessions, r.pendingWords = 0, 0
+	return nil
+}
+
+// post logs the pending dictation as minutes of offline time
+func (r *RescueTime) post(minutes int) error {
+	details := fmt.Sprintf("%d sessions, %d words", r.pendingSessions, r.pendingWords)
+	data, err := json.Marshal(map[string]any{
+		"start_time":       r.pendingStart.Local().Format("2006-01-02 15:04:05"),
+		"duration":         minutes,
+		"activity_name":    "T2 dictation",
+		"activity_details": details,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.client.Post(rescueTimeOfflineURL+"?key="+url.QueryEscape(r.key), "application/json", bytes.NewReader(data))
+	if err != nil {
+		// The error would include the URL, and with it the key
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to reach RescueTime: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("RescueTime returned %s", resp.Status)
+	}
+	return nil
+}
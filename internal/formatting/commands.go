@@ -0,0 +1,119 @@
+package formatting
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Voice command actions
+const (
+	CommandScratch = "scratch" // Discard the previous sentence
+	CommandUndo    = "undo"    // Undo the last paste in the active app
+	CommandSend    = "send"    // Press Return after pasting
+)
+
+// DefaultCommandPhrases are the spoken phrases recognized out of the box
+var DefaultCommandPhrases = map[string]string{
+	"scratch that": CommandScratch,
+	"undo":         CommandUndo,
+	"undo that":    CommandUndo,
+	"send it":      CommandSend,
+}
+
+// Commands are the actions requested by voice commands in a transcript
+type Commands struct {
+	Scratch bool // Something was discarded
+	Undo    bool // Undo the previous paste before pasting
+	Send    bool // Press Return after pasting
+}
+
+// Any reports whether any command was spoken
+func (c Commands) Any() bool {
+	return c.Scratch || c.Undo || c.Send
+}
+
+var sentencePattern = regexp.MustCompile(`[^.!?]+[.!?]*`)
+
+// ExtractCommands strips voice commands out of text and returns the actions
+// they ask for. A command must be its own sentence or follow a comma at the end
+// of one ("Thanks, send it."), so ordinary words like "undo" aren't swallowed.
+func ExtractCommands(text string, phrases map[string]string) (string, Commands) {
+	var commands Commands
+
+	trimmed := strings.TrimRightFunc(text, unicode.IsSpace)
+	suffix := trailingSpace(text)
+	ordered := orderPhrases(phrases)
+
+	var kept []string
+	for _, sentence := range sentencePattern.FindAllString(trimmed, -1) {
+		body, action := splitCommand(strings.TrimSpace(sentence), ordered)
+		switch action {
+		case CommandScratch:
+			// A bare "scratch that" drops the previous sentence,
+			// a trailing one drops its own sentence
+			if body == "" && len(kept) > 0 {
+				kept = kept[:len(kept)-1]
+			}
+			commands.Scratch = true
+			continue
+		case CommandUndo:
+			commands.Undo = true
+		case CommandSend:
+			commands.Send = true
+		}
+		if body != "" {
+			kept = append(kept, body)
+		}
+	}
+
+	if !commands.Any() {
+		return text, commands
+	}
+
+	result := strings.Join(kept, " ")
+	if result == "" {
+		return "", commands
+	}
+	return result + suffix, commands
+}
+
+// commandPhrase is a normalized phrase and the action it triggers
+type commandPhrase struct {
+	phrase string
+	action string
+}
+
+// orderPhrases normalizes enabled phrases, longest first so "undo that" wins over "undo"
+func orderPhrases(phrases map[string]string) []commandPhrase {
+	ordered := make([]commandPhrase, 0, len(phrases))
+	for phrase, action := range phrases {
+		if action != "" {
+			ordered = append(ordered, commandPhrase{strings.ToLower(strings.TrimSpace(phrase)), action})
+		}
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return len(ordered[i].phrase) > len(ordered[j].phrase)
+	})
+	return ordered
+}
+
+// splitCommand separates a trailing command from a sentence, returning what's
+// left of the sentence and the command's action
+func splitCommand(sentence string, ordered []commandPhrase) (string, string) {
+	core := strings.TrimRight(sentence, ".!?")
+	punctuation := sentence[len(core):]
+	lower := strings.ToLower(core)
+
+	for _, c := range ordered {
+		if lower == c.phrase {
+			return "", c.action
+		}
+		if strings.HasSuffix(lower, ", "+c.phrase) {
+			body := strings.TrimSpace(core[:len(core)-len(c.phrase)-2])
+			return body + punctuation, c.action
+		}
+	}
+	return sentence, ""
+}
@@ -0,0 +1,272 @@
+package formatting
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Entity types that can be formatted
+const (
+	EntityCurrency = "currency" // "five dollars" -> "$5"
+	EntityDates    = "dates"    // "march third twenty twenty six" -> "March 3, 2026"
+	EntityPhone    = "phone"    // "five five five one two three four" -> "555-1234"
+	EntityEmail    = "email"    // "jane dot doe at example dot com" -> "jane.doe@example.com"
+	EntityAll      = "all"
+)
+
+// entityOrder runs emails before phones and dates so their digits stay put
+var entityOrder = []string{EntityEmail, EntityPhone, EntityCurrency, EntityDates}
+
+// amount matches a number in digits or words, e.g. "5", "4.99" or "twenty five"
+const amount = `(\d+(?:\.\d+)?|(?:` + numberWord + `)(?:(?:[\s-]+|\s+and\s+)(?:` + numberWord + `))*)`
+
+var currencySymbols = map[string]string{
+	"dollar": "$", "dollars": "$", "buck": "$", "bucks": "$",
+	"euro": "€", "euros": "€",
+	"pound": "£", "pounds": "£",
+}
+
+var (
+	// money matches "five dollars", "5 dollars and 25 cents" or "twelve euros fifty cents"
+	money = regexp.MustCompile(`(?i)\b` + amount + `\s+(dollars?|bucks?|euros?|pounds?)(?:(?:\s+and)?\s+` + amount + `\s+cents?)?\b`)
+
+	// numericDay matches "3" or "3rd"
+	numericDay = regexp.MustCompile(`(?i)^(\d{1,2})(?:st|nd|rd|th)?$`)
+
+	// spokenDate matches "march third", "March 3rd" or "march third twenty twenty six"
+	spokenDate = regexp.MustCompile(`(?i)\b(january|february|march|april|may|june|july|august|september|october|november|december)\s+` +
+		`(?:the\s+)?(\d{1,2}(?:st|nd|rd|th)?|(?:(?:twenty|thirty)[\s-]+)?(?:` + ordinalWord + `))\b` +
+		`(?:,?\s+(\d{4}|(?:` + numberWord + `)(?:[\s-]+(?:` + numberWord + `|oh))*))?`)
+
+	// spokenPhone matches seven to eleven spoken digits in a row
+	spokenPhone = regexp.MustCompile(`(?i)\b(?:` + digitWord + `|\d)(?:[\s,-]+(?:` + digitWord + `|\d)){6,10}\b`)
+
+	// spokenEmail matches "jane dot doe at example dot com"
+	spokenEmail = regexp.MustCompile(`(?i)\b([a-z0-9]+(?:\s+(?:dot|underscore|dash)\s+[a-z0-9]+)*)\s+at\s+` +
+		`([a-z0-9]+(?:\s+(?:dot|dash)\s+[a-z0-9]+)*\s+dot\s+(?:com|org|net|edu|gov|io|co|dev|app|me|us|uk|de|fr|ca|au))\b`)
+
+	// emailCue matches the word that introduces an address, e.g. "email jane at ..."
+	emailCue = regexp.MustCompile(`(?i)\b(?:email|e-mail|mail|to|is|address|cc|contact)\s*$`)
+)
+
+// wordMonths are months that are also everyday words, e.g. "you may first"
+var wordMonths = map[string]bool{"may": true, "march": true}
+
+// notLocalParts are words that can't be an address on their own, e.g. in
+// "write to us at example dot com"
+var notLocalParts = map[string]bool{
+	"me": true, "us": true, "you": true, "him": true, "her": true, "them": true, "it": true,
+	"i": true, "we": true, "they": true, "he": true, "she": true, "this": true, "that": true,
+}
+
+// ordinalWord matches a spelled-out day of the month, first to thirtieth
+const ordinalWord = `first|second|third|fourth|fifth|sixth|seventh|eighth|ninth|tenth|eleventh|twelfth|thirteenth|fourteenth|fifteenth|sixteenth|seventeenth|eighteenth|nineteenth|twentieth|thirtieth`
+
+var ordinals = map[string]int{
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5,
+	"sixth": 6, "seventh": 7, "eighth": 8, "ninth": 9, "tenth": 10,
+	"eleventh": 11, "twelfth": 12, "thirteenth": 13, "fourteenth": 14, "fifteenth": 15,
+	"sixteenth": 16, "seventeenth": 17, "eighteenth": 18, "nineteenth": 19,
+	"twentieth": 20, "thirtieth": 30,
+}
+
+// FormatEntities rewrites spoken currencies, dates, phone numbers and emails
+// of the given types (see Entity* constants) in written form
+func FormatEntities(text string, types []string) string {
+	enabled := make(map[string]bool)
+	for _, t := range types {
+		if t == EntityAll {
+			for _, name := range entityOrder {
+				enabled[name] = true
+			}
+		}
+		enabled[t] = true
+	}
+
+	for _, name := range entityOrder {
+		if !enabled[name] {
+			continue
+		}
+		switch name {
+		case EntityEmail:
+			text = formatEmails(text)
+		case EntityPhone:
+			text = spokenPhone.ReplaceAllStringFunc(text, formatPhone)
+		case EntityCurrency:
+			text = money.ReplaceAllStringFunc(text, formatMoney)
+		case EntityDates:
+			text = spokenDate.ReplaceAllStringFunc(text, formatDate)
+		}
+	}
+	return text
+}
+
+// amountValue reads an amount in digits or words
+func amountValue(amount string) (string, bool) {
+	if amount == "" {
+		return "", false
+	}
+	if amount[0] >= '0' && amount[0] <= '9' {
+		return amount, true
+	}
+	n, ok := parseNumber(splitNumberWords(amount))
+	if !ok {
+		return "", false
+	}
+	return strconv.Itoa(n), true
+}
+
+func formatMoney(match string) string {
+	parts := money.FindStringSubmatch(match)
+	whole, ok := amountValue(parts[1])
+	if !ok {
+		return match
+	}
+	symbol := currencySymbols[strings.ToLower(parts[2])]
+	if parts[3] == "" {
+		return symbol + whole
+	}
+
+	cents, ok := amountValue(parts[3])
+	n, err := strconv.Atoi(cents)
+	if !ok || err != nil || n >= 100 || strings.Contains(whole, ".") {
+		return match
+	}
+	return symbol + whole + "." + twoDigits(n)
+}
+
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+func formatDate(match string) string {
+	parts := spokenDate.FindStringSubmatch(match)
+	month := strings.ToUpper(parts[1][:1]) + strings.ToLower(parts[1][1:])
+
+	// Lowercase "may" or "march" is usually the verb, unless the rest can
+	// only be a date: a year, a day in digits or "the third"
+	if wordMonths[parts[1]] && parts[3] == "" && !(parts[2][0] >= '0' && parts[2][0] <= '9') &&
+		!strings.EqualFold(strings.Fields(match)[1], "the") {
+		return match
+	}
+
+	day, ok := dayOfMonth(parts[2])
+	if !ok {
+		return match
+	}
+	date := month + " " + strconv.Itoa(day)
+
+	if parts[3] == "" {
+		return date
+	}
+	year, ok := yearValue(parts[3])
+	if !ok {
+		// Whatever followed the day wasn't a year - keep it as it was
+		dayEnd := len(parts[1]) + strings.Index(match[len(parts[1]):], parts[2]) + len(parts[2])
+		return date + match[dayEnd:]
+	}
+	return date + ", " + strconv.Itoa(year)
+}
+
+// dayOfMonth reads "3", "3rd", "third" or "twenty first"
+func dayOfMonth(day string) (int, bool) {
+	if digits := numericDay.FindStringSubmatch(day); digits != nil {
+		n, _ := strconv.Atoi(digits[1])
+		return n, n >= 1 && n <= 31
+	}
+
+	words := splitNumberWords(strings.ToLower(day))
+	n := 0
+	if len(words) == 2 {
+		n = tensWords[words[0]]
+		words = words[1:]
+	}
+	if len(words) != 1 {
+		return 0, false
+	}
+	n += ordinals[words[0]]
+	return n, n >= 1 && n <= 31
+}
+
+// yearValue reads "2026", "two thousand twenty six" or "twenty twenty six"
+func yearValue(year string) (int, bool) {
+	if n, err := strconv.Atoi(year); err == nil {
+		return n, true
+	}
+
+	words := splitNumberWords(year)
+	if n, ok := parseNumber(words); ok && n >= 1000 && n < 3000 {
+		return n, true
+	}
+
+	// Said as two pairs of digits: "nineteen eighty four", "twenty oh five"
+	for split := 1; split < len(words); split++ {
+		century, ok := parseNumber(words[:split])
+		if !ok || century < 10 || century > 29 {
+			continue
+		}
+		rest := words[split:]
+		if strings.EqualFold(rest[0], "oh") && len(rest) == 2 {
+			if n, ok := unitWords[strings.ToLower(rest[1])]; ok {
+				return century*100 + n, true
+			}
+			continue
+		}
+		if n, ok := parseNumber(rest); ok && n >= 10 && n < 100 {
+			return century*100 + n, true
+		}
+	}
+	return 0, false
+}
+
+func formatPhone(match string) string {
+	digits := ""
+	for _, word := range strings.FieldsFunc(match, func(r rune) bool { return r == ' ' || r == ',' || r == '-' }) {
+		digits += digitOf(word)
+	}
+
+	switch {
+	case len(digits) == 7:
+		return digits[:3] + "-" + digits[3:]
+	case len(digits) == 10:
+		return digits[:3] + "-" + digits[3:6] + "-" + digits[6:]
+	case len(digits) == 11 && digits[0] == '1':
+		return "+1 " + digits[1:4] + "-" + digits[4:7] + "-" + digits[7:]
+	default:
+		return match
+	}
+}
+
+// formatEmails writes out spoken addresses. A one-word name before "at"
+// only counts after a cue like "email" or "to", so "meet me at example dot
+// com" is left alone.
+func formatEmails(text string) string {
+	var result strings.Builder
+	last := 0
+	for _, m := range spokenEmail.FindAllStringSubmatchIndex(text, -1) {
+		local := text[m[2]:m[3]]
+		if !strings.Contains(local, " ") {
+			if notLocalParts[strings.ToLower(local)] || !emailCue.MatchString(text[:m[0]]) {
+				continue
+			}
+		}
+		result.WriteString(text[last:m[0]])
+		result.WriteString(formatEmail(text[m[0]:m[1]]))
+		last = m[1]
+	}
+	result.WriteString(text[last:])
+	return result.String()
+}
+
+func formatEmail(match string) string {
+	parts := spokenEmail.FindStringSubmatch(match)
+	symbols := strings.NewReplacer(" dot ", ".", " underscore ", "_", " dash ", "-")
+	squash := func(s string) string {
+		return symbols.Replace(strings.ToLower(strings.Join(strings.Fields(s), " ")))
+	}
+	return squash(parts[1]) + "@" + squash(parts[2])
+}
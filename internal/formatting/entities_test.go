@@ -0,0 +1,30 @@
+package formatting
+
+import "testing"
+
+func TestFormatEntities(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"It costs five dollars", "It costs $5"},
+		{"on march third twenty twenty six", "on March 3, 2026"},
+		{"by March third", "by March 3"},
+		{"by march the third", "by March 3"},
+		{"due may 3rd", "due May 3"},
+		{"you may first check the logs", "you may first check the logs"},
+		{"we march second in line", "we march second in line"},
+		{"call five five five one two three four", "call 555-1234"},
+		{"email jane dot doe at example dot com", "email jane.doe@example.com"},
+		{"send it to jane at example dot com", "send it to jane@example.com"},
+		{"write to us at example dot com", "write to us at example dot com"},
+		{"meet me at example dot com", "meet me at example dot com"},
+		{"the team at example dot com", "the team at example dot com"},
+	}
+
+	for _, test := range tests {
+		if got := FormatEntities(test.text, []string{EntityAll}); got != test.want {
+			t.Errorf("FormatEntities(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
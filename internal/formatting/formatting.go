@@ -0,0 +1,26 @@
+package formatting
+
+// Options controls how transcripts are rewritten before they are pasted
+type Options struct {
+	Lowercase       bool     // Lowercase the whole transcript
+	LowercaseFirst  bool     // Only lowercase the first letter, for chat-style writing
+	FilterProfanity bool     // Mask profanity
+	RedactPII       []string // PII types to redact (see PII* constants)
+	Numbers         string   // Number style (see Numbers* constants); "" leaves numbers as transcribed
+	Entities        []string // Entity types to write out (see Entity* constants)
+	Locale          string   // Regional conventions for decimals, dates, amounts and units, e.g. "de-DE" (see Localize)
+}
+
+// Apply runs every enabled rewrite over text. Spoken numbers, phones and
+// emails are written out first so redaction can catch them, and redaction
+// runs before the rest so nothing sensitive survives into later steps.
+func Apply(text string, opts Options) string {
+	text = FormatEntities(text, opts.Entities)
+	text = formatNumbers(text, opts.Numbers)
+	text = Localize(text, opts.Locale)
+	text = RedactPII(text, opts.RedactPII)
+	if opts.FilterProfanity {
+		text = FilterProfanity(text)
+	}
+	return applyCase(text, opts)
+}
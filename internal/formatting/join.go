@@ -0,0 +1,80 @@
+package formatting
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// JoinAfter adjusts the start of text so it continues naturally after before,
+// the text in front of the cursor: a space is added or dropped, and the first
+// letter is capitalized at the start of a sentence. Mid-sentence only common
+// words are lowercased, since any other capitalized word may be a name.
+func JoinAfter(text string, before string, opts Options) string {
+	text = strings.TrimLeft(text, " ")
+	if text == "" {
+		return text
+	}
+
+	// Capitalization follows the last thing written, ignoring whitespace
+	previous := strings.TrimRight(before, " \t")
+	last, _ := utf8.DecodeLastRuneInString(previous)
+	switch {
+	case previous == "" || strings.HasSuffix(before, "\n") || strings.ContainsRune(".!?", last):
+		if !opts.Lowercase && !opts.LowercaseFirst {
+			text = capitalizeFirst(text)
+		}
+	case unicode.IsLetter(last) || unicode.IsDigit(last) || strings.ContainsRune(",;:", last):
+		if commonWords[strings.ToLower(firstWord(text))] {
+			text = lowercaseFirst(text)
+		}
+	}
+
+	// Spacing depends on the character right before the cursor
+	char, _ := utf8.DecodeLastRuneInString(before)
+	if before == "" || unicode.IsSpace(char) || strings.ContainsRune("([{\"'/-", char) {
+		return text
+	}
+	return " " + text
+}
+
+// commonWords are capitalized only because they start the transcript, so they
+// can be lowercased mid-sentence. Words that double as names, like "will" or
+// "may", are left out.
+var commonWords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "but": true, "or": true, "so": true, "nor": true,
+	"because": true, "if": true, "then": true, "than": true, "when": true, "while": true, "since": true,
+	"until": true, "although": true, "though": true, "unless": true, "whether": true,
+	"that": true, "this": true, "these": true, "those": true, "there": true, "here": true,
+	"which": true, "who": true, "whom": true, "whose": true, "what": true, "where": true, "why": true, "how": true,
+	"it": true, "it's": true, "its": true, "we": true, "you": true, "they": true, "he": true, "she": true,
+	"me": true, "us": true, "them": true, "him": true, "her": true, "my": true, "our": true, "your": true,
+	"their": true, "his": true, "to": true, "for": true, "of": true, "in": true, "on": true, "at": true,
+	"with": true, "from": true, "by": true, "as": true, "about": true, "into": true, "over": true,
+	"after": true, "before": true, "like": true, "is": true, "are": true, "was": true, "were": true,
+	"be": true, "been": true, "do": true, "does": true, "did": true, "have": true, "has": true, "had": true,
+	"can": true, "could": true, "would": true, "should": true, "might": true, "must": true,
+	"not": true, "no": true, "yes": true, "also": true, "just": true, "only": true, "even": true,
+	"still": true, "maybe": true, "please": true, "thanks": true, "all": true, "some": true,
+	"any": true, "every": true, "each": true, "both": true, "now": true, "well": true,
+}
+
+// firstWord returns the letters and apostrophes text starts with
+func firstWord(text string) string {
+	end := strings.IndexFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if end < 0 {
+		return text
+	}
+	return text[:end]
+}
+
+// capitalizeFirst uppercases the first letter
+func capitalizeFirst(text string) string {
+	first, size := utf8.DecodeRuneInString(text)
+	if first == utf8.RuneError || !unicode.IsLower(first) {
+		return text
+	}
+	return string(unicode.ToUpper(first)) + text[size:]
+}
@@ -0,0 +1,57 @@
+package formatting
+
+import "testing"
+
+func TestJoinAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		before string
+		want   string
+	}{
+		{
+			name:   "start of a sentence is capitalized",
+			text:   "thanks for the update.",
+			before: "Got it. ",
+			want:   "Thanks for the update.",
+		},
+		{
+			name:   "common word is lowercased mid-sentence",
+			text:   "The meeting moved to Friday.",
+			before: "I think",
+			want:   " the meeting moved to Friday.",
+		},
+		{
+			name:   "name keeps its capital mid-sentence",
+			text:   "Sarah will send it.",
+			before: "I talked to Tom and",
+			want:   " Sarah will send it.",
+		},
+		{
+			name:   "place keeps its capital after a comma",
+			text:   "Berlin is next.",
+			before: "First Paris, ",
+			want:   "Berlin is next.",
+		},
+		{
+			name:   "word that doubles as a name is left alone",
+			text:   "Will is coming too.",
+			before: "and",
+			want:   " Will is coming too.",
+		},
+		{
+			name:   "pronoun I stays uppercase",
+			text:   "I agree.",
+			before: "Honestly,",
+			want:   " I agree.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JoinAfter(tt.text, tt.before, Options{}); got != tt.want {
+				t.Errorf("JoinAfter(%q, %q) = %q, want %q", tt.text, tt.before, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package formatting
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Conventions are the regional writing conventions of a locale
+type Conventions struct {
+	DecimalComma    bool // "3,14" instead of "3.14"
+	DayFirst        bool // "3 March 2026" instead of "March 3, 2026"
+	SymbolAfter     bool // "5,50 €" instead of "€5.50"
+	BritishSpelling bool // "5 metres" instead of "5 meters"
+}
+
+// locales maps locales, or just their language, to their conventions.
+// English defaults to the US way.
+var locales = map[string]Conventions{
+	"en":    {},
+	"en-ca": {BritishSpelling: true},
+	"en-gb": {DayFirst: true, BritishSpelling: true},
+	"en-au": {DayFirst: true, BritishSpelling: true},
+	"en-nz": {DayFirst: true, BritishSpelling: true},
+	"en-ie": {DayFirst: true, BritishSpelling: true},
+	"en-in": {DayFirst: true, BritishSpelling: true},
+	"en-za": {DayFirst: true, BritishSpelling: true},
+	"de":    {DecimalComma: true, DayFirst: true, SymbolAfter: true},
+	"fr":    {DecimalComma: true, DayFirst: true, SymbolAfter: true},
+	"es":    {DecimalComma: true, DayFirst: true, SymbolAfter: true},
+	"it":    {DecimalComma: true, DayFirst: true, SymbolAfter: true},
+	"nl":    {DecimalComma: true, DayFirst: true},
+	"pt":    {DecimalComma: true, DayFirst: true, SymbolAfter: true},
+	"sv":    {DecimalComma: true, DayFirst: true, SymbolAfter: true},
+	"da":    {DecimalComma: true, DayFirst: true, SymbolAfter: true},
+	"nb":    {DecimalComma: true, DayFirst: true, SymbolAfter: true},
+	"fi":    {DecimalComma: true, DayFirst: true, SymbolAfter: true},
+	"pl":    {DecimalComma: true, DayFirst: true, SymbolAfter: true},
+}
+
+var (
+	// decimalNumber matches "3.14"; versions and addresses are skipped by localizeDecimals
+	decimalNumber = regexp.MustCompile(`\d+\.\d+`)
+
+	// versionPrefix matches "version " or "v" right before a number
+	versionPrefix = regexp.MustCompile(`(?i)\b(?:version\s+|v)$`)
+
+	// prefixedAmount matches "$5", "€5.50" or "£1,5"
+	prefixedAmount = regexp.MustCompile(`([$€£])(\d+(?:[.,]\d+)?)`)
+
+	// writtenDate matches "March 3, 2026" or "March 3" as written by FormatEntities
+	writtenDate = regexp.MustCompile(`\b(January|February|March|April|May|June|July|August|September|October|November|December) (\d{1,2})(?:, (\d{4}))?\b`)
+
+	// americanUnit matches "5 meters" or "two liters"
+	americanUnit = regexp.MustCompile(`(?i)\b(\d+(?:[.,]\d+)?|` + numberWord + `)(\s+)((?:kilo|centi|milli)?)(met|lit)(er)(s?)\b`)
+)
+
+// LookupConventions returns the conventions of a locale like "de", "de-DE",
+// "en_GB" or "en_GB.UTF-8"; false if it isn't known
+func LookupConventions(locale string) (Conventions, bool) {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if conventions, ok := locales[locale]; ok {
+		return conventions, true
+	}
+	language, _, _ := strings.Cut(locale, "-")
+	conventions, ok := locales[language]
+	return conventions, ok
+}
+
+// Localize rewrites decimals, dates, amounts and units written the US way in
+// the conventions of locale; "" or an unknown locale leaves text alone
+func Localize(text string, locale string) string {
+	if locale == "" {
+		return text
+	}
+	conventions, _ := LookupConventions(locale)
+
+	if conventions.BritishSpelling {
+		text = americanUnit.ReplaceAllString(text, "$1$2$3${4}re$6")
+	}
+	if conventions.DayFirst {
+		text = writtenDate.ReplaceAllStringFunc(text, func(date string) string {
+			parts := writtenDate.FindStringSubmatch(date)
+			if parts[3] == "" {
+				return parts[2] + " " + parts[1]
+			}
+			return parts[2] + " " + parts[1] + " " + parts[3]
+		})
+	}
+	if conventions.DecimalComma {
+		text = localizeDecimals(text)
+	}
+	if conventions.SymbolAfter {
+		text = prefixedAmount.ReplaceAllString(text, "$2 $1")
+	}
+	return text
+}
+
+// localizeDecimals writes "3.14" as "3,14", leaving numbers with more than
+// one dot, like IP addresses, numbers with thousands separators and versions
+// like "v2.1" as they are
+func localizeDecimals(text string) string {
+	matches := decimalNumber.FindAllStringIndex(text, -1)
+	for i := len(matches) - 1; i >= 0; i-- {
+		start, end := matches[i][0], matches[i][1]
+		if start > 0 && (text[start-1] == '.' || text[start-1] == ',') {
+			continue
+		}
+		if versionPrefix.MatchString(text[:start]) {
+			continue
+		}
+		if end+1 < len(text) && text[end] == '.' && text[end+1] >= '0' && text[end+1] <= '9' {
+			continue
+		}
+		number := text[start:end]
+		text = text[:start] + strings.Replace(number, ".", ",", 1) + text[end:]
+	}
+	return text
+}
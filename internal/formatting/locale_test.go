@@ -0,0 +1,63 @@
+package formatting
+
+import "testing"
+
+func TestLocalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		locale string
+		want   string
+	}{
+		{
+			name:   "decimal comma",
+			text:   "Pi is 3.14.",
+			locale: "de-DE",
+			want:   "Pi is 3,14.",
+		},
+		{
+			name:   "thousands separator is left alone",
+			text:   "It costs 1,234.56 in total.",
+			locale: "de",
+			want:   "It costs 1,234.56 in total.",
+		},
+		{
+			name:   "versions are left alone",
+			text:   "Update to v2.1 or version 3.5 today.",
+			locale: "fr",
+			want:   "Update to v2.1 or version 3.5 today.",
+		},
+		{
+			name:   "IP address is left alone",
+			text:   "Connect to 192.168.1.10 now.",
+			locale: "de",
+			want:   "Connect to 192.168.1.10 now.",
+		},
+		{
+			name:   "amount moves its symbol",
+			text:   "That's €5.50 each.",
+			locale: "de",
+			want:   "That's 5,50 € each.",
+		},
+		{
+			name:   "British dates and units",
+			text:   "Run 5 kilometers on March 3, 2026.",
+			locale: "en_GB.UTF-8",
+			want:   "Run 5 kilometres on 3 March 2026.",
+		},
+		{
+			name:   "US English is unchanged",
+			text:   "Pi is 3.14 on March 3.",
+			locale: "en-US",
+			want:   "Pi is 3.14 on March 3.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Localize(tt.text, tt.locale); got != tt.want {
+				t.Errorf("Localize(%q, %q) = %q, want %q", tt.text, tt.locale, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package formatting
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	// plainTags are the only tags plain text renders to
+	plainTags = regexp.MustCompile(`</?p>`)
+)
+
+// RenderMarkdown renders text as HTML and reports whether it contains any
+// Markdown formatting, i.e. anything besides plain paragraphs
+func RenderMarkdown(text string) (string, bool) {
+	var html bytes.Buffer
+	if err := markdown.Convert([]byte(text), &html); err != nil {
+		return "", false
+	}
+
+	rendered := html.String()
+	if !bytes.ContainsRune(plainTags.ReplaceAll(html.Bytes(), nil), '<') {
+		return rendered, false
+	}
+	return rendered, true
+}
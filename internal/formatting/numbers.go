@@ -0,0 +1,234 @@
+package formatting
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Number styles
+const (
+	NumbersDigits = "digits" // "twenty five" -> "25"
+	NumbersWords  = "words"  // "25" -> "twenty-five", for numbers below 100
+)
+
+var (
+	unitWords = map[string]int{
+		"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4,
+		"five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9,
+	}
+	teenWords = map[string]int{
+		"ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14,
+		"fifteen": 15, "sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+	}
+	tensWords = map[string]int{
+		"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+		"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+	}
+	scaleWords = map[string]int{
+		"thousand": 1_000, "million": 1_000_000, "billion": 1_000_000_000,
+	}
+
+	smallNames = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+	tensNames = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+)
+
+// digitWord matches a single spoken digit
+const digitWord = `zero|oh|one|two|three|four|five|six|seven|eight|nine`
+
+// numberWord matches any single word a spelled-out number is made of
+const numberWord = `zero|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve|thirteen|fourteen|fifteen|sixteen|seventeen|eighteen|nineteen|twenty|thirty|forty|fifty|sixty|seventy|eighty|ninety|hundred|thousand|million|billion`
+
+var (
+	// numberRun matches a run of number words, e.g. "two hundred and forty-five"
+	numberRun = regexp.MustCompile(`(?i)\b(?:` + numberWord + `)(?:(?:[\s-]+|\s+and\s+)(?:` + numberWord + `))*\b`)
+
+	// decimalRun matches "three point one four"
+	decimalRun = regexp.MustCompile(`(?i)\b(\d+|` + digitWord + `) point((?:\s+(?:` + digitWord + `|\d))+)\b`)
+
+	// percentWord matches "25 percent"
+	percentWord = regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?)\s+percent\b`)
+
+	// andSeparator splits number runs that aren't a single number
+	andSeparator = regexp.MustCompile(`(?i)\s+and\s+`)
+
+	// bareNumber matches whole numbers written in digits
+	bareNumber = regexp.MustCompile(`\d+(?:[.,:/]\d+)*`)
+)
+
+// parseNumber reads a spelled-out number like "two hundred and forty-five".
+// It returns false for word runs that aren't one number, e.g. "five five".
+func parseNumber(words []string) (int, bool) {
+	const (
+		none = iota
+		unit
+		teen
+		ten
+		hundred
+		scale
+		and
+	)
+
+	total, group := 0, 0
+	last := none
+	lastScale := 0
+	for _, word := range words {
+		word = strings.ToLower(word)
+		if n, ok := unitWords[word]; ok {
+			if (last != none && last != ten && last != hundred && last != scale && last != and) || (n == 0 && (last != none || len(words) > 1)) {
+				return 0, false
+			}
+			group += n
+			last = unit
+		} else if n, ok := teenWords[word]; ok {
+			if last != none && last != hundred && last != scale && last != and {
+				return 0, false
+			}
+			group += n
+			last = teen
+		} else if n, ok := tensWords[word]; ok {
+			if last != none && last != hundred && last != scale && last != and {
+				return 0, false
+			}
+			group += n
+			last = ten
+		} else if word == "hundred" {
+			if (last != unit && last != teen) || group >= 100 {
+				return 0, false
+			}
+			group *= 100
+			last = hundred
+		} else if n, ok := scaleWords[word]; ok {
+			if last == none || last == scale || last == and || (lastScale != 0 && n >= lastScale) {
+				return 0, false
+			}
+			total += group * n
+			group = 0
+			lastScale = n
+			last = scale
+		} else if word == "and" {
+			if last != hundred && last != scale {
+				return 0, false
+			}
+			last = and
+		} else {
+			return 0, false
+		}
+	}
+	if last == none || last == and {
+		return 0, false
+	}
+	return total + group, true
+}
+
+// splitNumberWords splits a number run into words, dropping hyphens
+func splitNumberWords(run string) []string {
+	return strings.Fields(strings.ReplaceAll(run, "-", " "))
+}
+
+// spelledToDigits writes spelled-out numbers as digits. Single words below
+// ten are left alone, since "one" is usually a pronoun ("the one I want").
+func spelledToDigits(text string) string {
+	text = numberRun.ReplaceAllStringFunc(text, numberRunToDigits)
+
+	text = decimalRun.ReplaceAllStringFunc(text, func(match string) string {
+		parts := decimalRun.FindStringSubmatch(match)
+		digits := ""
+		for _, word := range strings.Fields(parts[2]) {
+			digits += digitOf(word)
+		}
+		return digitOf(parts[1]) + "." + digits
+	})
+
+	return percentWord.ReplaceAllString(text, "$1%")
+}
+
+// numberRunToDigits converts one run of number words. A run that isn't one
+// number, like "five and six", is converted piece by piece around the "and"s.
+func numberRunToDigits(run string) string {
+	words := splitNumberWords(run)
+	n, ok := parseNumber(words)
+	if ok {
+		if len(words) == 1 && n < 10 {
+			return run
+		}
+		return strconv.Itoa(n)
+	}
+
+	parts := andSeparator.Split(run, -1)
+	if len(parts) == 1 {
+		return run
+	}
+	for i, part := range parts {
+		parts[i] = numberRunToDigits(part)
+	}
+	return strings.Join(parts, " and ")
+}
+
+// digitOf returns the digit a single spoken digit stands for
+func digitOf(word string) string {
+	word = strings.ToLower(word)
+	if word == "oh" {
+		return "0"
+	}
+	if n, ok := unitWords[word]; ok {
+		return strconv.Itoa(n)
+	}
+	return word
+}
+
+// digitsToWords spells out whole numbers below 100 that stand on their own,
+// leaving amounts, decimals, times and numbers inside codes as digits
+func digitsToWords(text string) string {
+	matches := bareNumber.FindAllStringIndex(text, -1)
+	for i := len(matches) - 1; i >= 0; i-- {
+		start, end := matches[i][0], matches[i][1]
+		n, err := strconv.Atoi(text[start:end])
+		if err != nil || n >= 100 || !standsAlone(text, start, end) {
+			continue
+		}
+		text = text[:start] + numberName(n) + text[end:]
+	}
+	return text
+}
+
+// standsAlone reports whether the number at text[start:end] is a word of its
+// own rather than part of an amount, code or measurement like "$5" or "5%"
+func standsAlone(text string, start, end int) bool {
+	if start > 0 && !strings.ContainsRune(" \t\n(\"'", rune(text[start-1])) {
+		return false
+	}
+	if end < len(text) {
+		next := text[end]
+		if next == '%' || next == '-' || (next >= 'A' && next <= 'Z') || (next >= 'a' && next <= 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// numberName spells out n, which must be below 100
+func numberName(n int) string {
+	if n < 20 {
+		return smallNames[n]
+	}
+	if n%10 == 0 {
+		return tensNames[n/10]
+	}
+	return tensNames[n/10] + "-" + smallNames[n%10]
+}
+
+// formatNumbers rewrites numbers in the given style; "" leaves them as transcribed
+func formatNumbers(text string, style string) string {
+	switch style {
+	case NumbersDigits:
+		return spelledToDigits(text)
+	case NumbersWords:
+		return digitsToWords(text)
+	default:
+		return text
+	}
+}
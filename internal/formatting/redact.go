@@ -0,0 +1,63 @@
+package formatting
+
+import (
+	"regexp"
+	"strings"
+)
+
+// PII types that can be redacted
+const (
+	PIIEmail      = "email"
+	PIIPhone      = "phone"
+	PIICreditCard = "credit_card"
+	PIISSN        = "ssn"
+	PIIAll        = "all"
+)
+
+var piiOrder = []string{PIIEmail, PIICreditCard, PIISSN, PIIPhone}
+
+var piiPatterns = map[string]*regexp.Regexp{
+	PIIEmail:      regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+	PIICreditCard: regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`),
+	PIISSN:        regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+	PIIPhone:      regexp.MustCompile(`(?:\+\d{1,3}[ .\-]?)?\(?\b\d{3}\)?[ .\-]?\d{3}[ .\-]?\d{4}\b`),
+}
+
+var piiLabels = map[string]string{
+	PIIEmail:      "[EMAIL]",
+	PIICreditCard: "[CREDIT CARD]",
+	PIISSN:        "[SSN]",
+	PIIPhone:      "[PHONE]",
+}
+
+// profanity is intentionally short - it covers the words speech models most
+// often transcribe, not every possible insult
+var profanity = regexp.MustCompile(`(?i)\b(fuck(?:ing|ed|er|s)?|shit(?:ty|s)?|bitch(?:es)?|asshole(?:s)?|bastard(?:s)?|damn|crap|dick(?:s)?|piss(?:ed)?|cunt(?:s)?|motherfucker(?:s)?)\b`)
+
+// RedactPII replaces personal information of the given types with placeholders
+func RedactPII(text string, types []string) string {
+	enabled := make(map[string]bool)
+	for _, t := range types {
+		if t == PIIAll {
+			for _, name := range piiOrder {
+				enabled[name] = true
+			}
+		}
+		enabled[t] = true
+	}
+
+	// Fixed order so longer patterns (cards) win over shorter ones (phones)
+	for _, name := range piiOrder {
+		if enabled[name] {
+			text = piiPatterns[name].ReplaceAllString(text, piiLabels[name])
+		}
+	}
+	return text
+}
+
+// FilterProfanity masks profanity, keeping the first letter: "shit" -> "s***"
+func FilterProfanity(text string) string {
+	return profanity.ReplaceAllStringFunc(text, func(word string) string {
+		return word[:1] + strings.Repeat("*", len(word)-1)
+	})
+}
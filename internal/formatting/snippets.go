@@ -0,0 +1,97 @@
+package formatting
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ExpandSnippets replaces sentences that are exactly a snippet phrase
+// ("Insert signature.") with the stored text, and returns how many were
+// expanded. Matching ignores case and punctuation; a phrase inside a longer
+// sentence is left alone so ordinary speech isn't rewritten.
+func ExpandSnippets(text string, snippets map[string]string) (string, int) {
+	if len(snippets) == 0 {
+		return text, 0
+	}
+
+	expansions := make(map[string]string, len(snippets))
+	for phrase, expansion := range snippets {
+		if key := snippetKey(phrase); key != "" {
+			expansions[key] = expansion
+		}
+	}
+
+	// Only the matching sentences are replaced; the rest of the text is kept
+	// byte for byte
+	var b strings.Builder
+	last, expanded := 0, 0
+	for _, span := range sentencePattern.FindAllStringIndex(text, -1) {
+		start, end := trimSpan(text, span[0], span[1])
+		if !sentenceBoundary(text, start, end) {
+			continue
+		}
+		if expansion, ok := expansions[snippetKey(text[start:end])]; ok {
+			b.WriteString(text[last:start])
+			b.WriteString(expansion)
+			last = end
+			expanded++
+		}
+	}
+
+	if expanded == 0 {
+		return text, 0
+	}
+	b.WriteString(text[last:])
+	return b.String(), expanded
+}
+
+// CollapseSnippets turns snippet expansions in text back into their phrase,
+// e.g. "[insert signature]", so stored boilerplate isn't mistaken for
+// something that was said
+func CollapseSnippets(text string, snippets map[string]string) string {
+	phrases := make([]string, 0, len(snippets))
+	for phrase, expansion := range snippets {
+		if strings.TrimSpace(expansion) != "" {
+			phrases = append(phrases, phrase)
+		}
+	}
+	// Longest first, so an expansion that contains another is collapsed whole
+	sort.Slice(phrases, func(i, j int) bool {
+		return len(snippets[phrases[i]]) > len(snippets[phrases[j]])
+	})
+
+	for _, phrase := range phrases {
+		text = strings.ReplaceAll(text, snippets[phrase], "["+snippetKey(phrase)+"]")
+	}
+	return text
+}
+
+// trimSpan narrows text[start:end] to leave out surrounding whitespace
+func trimSpan(text string, start, end int) (int, int) {
+	sentence := text[start:end]
+	trimmed := strings.TrimLeftFunc(sentence, unicode.IsSpace)
+	start += len(sentence) - len(trimmed)
+	return start, start + len(strings.TrimRightFunc(trimmed, unicode.IsSpace))
+}
+
+// sentenceBoundary reports whether text[start:end] is a whole sentence, not a
+// piece of a number like "3.5" or an address like "jane.doe@example.com"
+// that the sentence pattern split at a period
+func sentenceBoundary(text string, start, end int) bool {
+	if start == end {
+		return false
+	}
+	before, _ := utf8.DecodeLastRuneInString(text[:start])
+	after, _ := utf8.DecodeRuneInString(text[end:])
+	return (start == 0 || unicode.IsSpace(before)) && (end == len(text) || unicode.IsSpace(after))
+}
+
+// snippetKey normalizes a phrase for matching: lowercase words without punctuation
+func snippetKey(phrase string) string {
+	words := strings.FieldsFunc(strings.ToLower(phrase), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,70 @@
+package formatting
+
+import "testing"
+
+func TestExpandSnippets(t *testing.T) {
+	snippets := map[string]string{
+		"insert signature": "Best,\nJane",
+		"com":              "should never match",
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		want     string
+		expanded int
+	}{
+		{
+			name:     "whole sentence",
+			text:     "Insert signature.",
+			want:     "Best,\nJane",
+			expanded: 1,
+		},
+		{
+			name:     "decimals and emails are kept as they are",
+			text:     "Version 3.5 ships today. Mail jane.doe@example.com. Insert signature. ",
+			want:     "Version 3.5 ships today. Mail jane.doe@example.com. Best,\nJane ",
+			expanded: 1,
+		},
+		{
+			name:     "piece of an address isn't a sentence",
+			text:     "Write to support@example.com. Thanks!",
+			want:     "Write to support@example.com. Thanks!",
+			expanded: 0,
+		},
+		{
+			name:     "phrase inside a longer sentence",
+			text:     "Please insert signature here.",
+			want:     "Please insert signature here.",
+			expanded: 0,
+		},
+		{
+			name:     "spacing around the expansion is kept",
+			text:     "Thanks!  Insert signature!\n",
+			want:     "Thanks!  Best,\nJane\n",
+			expanded: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, expanded := ExpandSnippets(test.text, snippets)
+			if got != test.want || expanded != test.expanded {
+				t.Errorf("ExpandSnippets(%q) = %q, %d; want %q, %d", test.text, got, expanded, test.want, test.expanded)
+			}
+		})
+	}
+}
+
+func TestCollapseSnippets(t *testing.T) {
+	snippets := map[string]string{
+		"insert signature": "Best,\nJane",
+		"insert address":   "1 Main St",
+		"nothing":          "",
+	}
+
+	text := "Thanks for the update. Best,\nJane"
+	if got, want := CollapseSnippets(text, snippets), "Thanks for the update. [insert signature]"; got != want {
+		t.Errorf("CollapseSnippets(%q) = %q, want %q", text, got, want)
+	}
+}
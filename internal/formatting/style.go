@@ -0,0 +1,39 @@
+package formatting
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// applyCase lowercases text according to opts
+func applyCase(text string, opts Options) string {
+	if opts.Lowercase {
+		return strings.ToLower(text)
+	}
+
+	if opts.LowercaseFirst {
+		return lowercaseFirst(text)
+	}
+
+	return text
+}
+
+// lowercaseFirst lowercases the first letter unless it starts an acronym
+// or the word "I", which look wrong in lowercase
+func lowercaseFirst(text string) string {
+	first, size := utf8.DecodeRuneInString(text)
+	if first == utf8.RuneError || !unicode.IsUpper(first) {
+		return text
+	}
+
+	rest := text[size:]
+	if next, _ := utf8.DecodeRuneInString(rest); unicode.IsUpper(next) {
+		return text // Acronym like "API"
+	}
+	if first == 'I' && (rest == "" || !unicode.IsLetter([]rune(rest)[0])) {
+		return text
+	}
+
+	return string(unicode.ToLower(first)) + rest
+}
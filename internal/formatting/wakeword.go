@@ -0,0 +1,68 @@
+package formatting
+
+import (
+	"strings"
+	"unicode"
+)
+
+// wakeWordSearchWords is how far into a transcript the wake word may appear.
+// The first syllables are often clipped before the session starts.
+const wakeWordSearchWords = 4
+
+// StripWakeWord looks for wakeWord near the start of text and returns what was
+// said after it. ok is false if the wake word wasn't spoken.
+func StripWakeWord(text string, wakeWord string) (string, bool) {
+	wake := normalizeWords(wakeWord)
+	if len(wake) == 0 {
+		return text, true
+	}
+
+	fields := strings.Fields(text)
+	words := normalizeWords(text)
+	if len(words) != len(fields) {
+		return "", false
+	}
+
+	for start := 0; start <= wakeWordSearchWords && start+len(wake) <= len(words); start++ {
+		if matchesAt(words, start, wake) {
+			rest := strings.Join(fields[start+len(wake):], " ")
+			rest = strings.TrimLeftFunc(rest, func(r rune) bool {
+				return unicode.IsPunct(r) || unicode.IsSpace(r)
+			})
+			if rest == "" {
+				return "", true
+			}
+			return upperFirst(rest) + trailingSpace(text), true
+		}
+	}
+	return "", false
+}
+
+// normalizeWords splits text into lowercase words without punctuation
+func normalizeWords(text string) []string {
+	fields := strings.Fields(text)
+	words := make([]string, len(fields))
+	for i, field := range fields {
+		words[i] = strings.ToLower(strings.TrimFunc(field, unicode.IsPunct))
+	}
+	return words
+}
+
+func matchesAt(words []string, start int, wake []string) bool {
+	for i, w := range wake {
+		if words[start+i] != w {
+			return false
+		}
+	}
+	return true
+}
+
+func upperFirst(text string) string {
+	runes := []rune(text)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func trailingSpace(text string) string {
+	return text[len(strings.TrimRightFunc(text, unicode.IsSpace)):]
+}
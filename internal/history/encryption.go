@@ -0,0 +1,99 @@
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"filippo.io/age"
+)
+
+// PassphraseEnvVar encrypts the history with a passphrase when no identity file is set
+const PassphraseEnvVar = "T2_HISTORY_PASSPHRASE"
+
+// encryptedPrefix starts every age-encrypted file
+const encryptedPrefix = "age-encryption.org/"
+
+// scryptWorkFactor keeps passphrase encryption fast enough to run after every
+// paste; age's default of 18 takes about a second
+const scryptWorkFactor = 15
+
+// Key encrypts and decrypts the history with age
+type Key struct {
+	identity  age.Identity
+	recipient age.Recipient
+}
+
+// LoadKey returns the key from an age identity file (as made by age-keygen),
+// falling back to the T2_HISTORY_PASSPHRASE passphrase. nil means the history
+// is stored in plaintext.
+func LoadKey(identityPath string) (*Key, error) {
+	if identityPath != "" {
+		return identityFileKey(identityPath)
+	}
+	if passphrase := os.Getenv(PassphraseEnvVar); passphrase != "" {
+		return passphraseKey(passphrase)
+	}
+	return nil, nil
+}
+
+func identityFileKey(path string) (*Key, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history identity: %v", err)
+	}
+	defer file.Close()
+
+	identities, err := age.ParseIdentities(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse history identity %s: %v", path, err)
+	}
+	for _, identity := range identities {
+		if x25519, ok := identity.(*age.X25519Identity); ok {
+			return &Key{identity: x25519, recipient: x25519.Recipient()}, nil
+		}
+	}
+	return nil, fmt.Errorf("no X25519 identity in %s", path)
+}
+
+func passphraseKey(passphrase string) (*Key, error) {
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid history passphrase: %v", err)
+	}
+	recipient.SetWorkFactor(scryptWorkFactor)
+
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid history passphrase: %v", err)
+	}
+	return &Key{identity: identity, recipient: recipient}, nil
+}
+
+func (k *Key) encrypt(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer, err := age.Encrypt(&buf, k.recipient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt history: %v", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to encrypt history: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encrypt history: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (k *Key) decrypt(data []byte) ([]byte, error) {
+	reader, err := age.Decrypt(bytes.NewReader(data), k.identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt history: %v", err)
+	}
+	plain, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt history: %v", err)
+	}
+	return plain, nil
+}
@@ -0,0 +1,177 @@
+// Package history keeps the most recent transcripts so they can be pasted again
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultLimit is how many transcripts are kept when history_size isn't set
+const DefaultLimit = 20
+
+// Entry is one pasted transcript
+type Entry struct {
+	Text string    `json:"text"`
+	Time time.Time `json:"time"`
+}
+
+// History is a bounded list of recent transcripts, saved to disk after every
+// change and encrypted when a key is configured
+type History struct {
+	path    string
+	limit   int
+	key     *Key    // Encrypts the file; nil stores plaintext
+	entries []Entry // Oldest first
+	loaded  bool    // entries were read from the file, so saving won't lose any
+	err     error   // Why the history can't be saved, e.g. a missing key
+	mutex   sync.Mutex
+}
+
+// New returns the history stored at path. Nothing is read or saved until
+// Configure is called.
+func New(path string) *History {
+	return &History{path: path, limit: DefaultLimit}
+}
+
+// Load reads the history stored at path, oldest first, decrypting it with key
+// if it was saved encrypted
+func Load(path string, key *Key) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %v", err)
+	}
+
+	if bytes.HasPrefix(data, []byte(encryptedPrefix)) {
+		if key == nil {
+			return nil, fmt.Errorf("history is encrypted - set history_identity or %s", PassphraseEnvVar)
+		}
+		if data, err = key.decrypt(data); err != nil {
+			return nil, err
+		}
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse history: %v", err)
+	}
+	return entries, nil
+}
+
+// Configure sets how many transcripts are kept and how they're encrypted.
+// A limit of 0 uses DefaultLimit and a negative one turns history off and
+// deletes what was saved. identityPath and T2_HISTORY_PASSPHRASE pick the key
+// (see LoadKey); the saved history is rewritten with it, so turning encryption
+// on also encrypts what was stored in plaintext. If the key can't be loaded,
+// nothing is saved until it can.
+func (h *History) Configure(limit int, identityPath string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	h.limit = limit
+	if limit < 0 {
+		h.entries, h.err = nil, nil
+		os.Remove(h.path)
+		return nil
+	}
+
+	key, err := LoadKey(identityPath)
+	if err != nil {
+		h.err = err
+		return err
+	}
+	if !h.loaded {
+		entries, err := Load(h.path, key)
+		if err != nil {
+			h.err = err
+			return err
+		}
+		// Keep anything added while the history couldn't be read
+		h.entries = append(entries, h.entries...)
+		h.loaded = true
+	}
+	h.key, h.err = key, nil
+
+	if len(h.entries) > limit {
+		h.entries = h.entries[len(h.entries)-limit:]
+	}
+	if len(h.entries) == 0 {
+		return nil
+	}
+	return h.save()
+}
+
+// Add records a transcript, dropping the oldest ones past the limit
+func (h *History) Add(text string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.limit < 0 || text == "" {
+		return nil
+	}
+
+	h.entries = append(h.entries, Entry{Text: text, Time: time.Now()})
+	if len(h.entries) > h.limit {
+		h.entries = h.entries[len(h.entries)-h.limit:]
+	}
+	if h.err != nil {
+		return h.err
+	}
+	if !h.loaded {
+		return nil
+	}
+	return h.save()
+}
+
+// PurgeBefore deletes transcripts pasted before cutoff and returns how many were removed
+func (h *History) PurgeBefore(cutoff time.Time) (int, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.err != nil {
+		return 0, h.err
+	}
+
+	kept := h.entries[:0]
+	for _, entry := range h.entries {
+		if !entry.Time.Before(cutoff) {
+			kept = append(kept, entry)
+		}
+	}
+	removed := len(h.entries) - len(kept)
+	h.entries = kept
+	if removed == 0 || !h.loaded {
+		return removed, nil
+	}
+	return removed, h.save()
+}
+
+// save writes the entries, readable only by the user since they hold dictated text
+func (h *History) save() error {
+	data, err := json.MarshalIndent(h.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode history: %v", err)
+	}
+	if h.key != nil {
+		if data, err = h.key.encrypt(data); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %v", err)
+	}
+	if err := os.WriteFile(h.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to save history: %v", err)
+	}
+	return nil
+}
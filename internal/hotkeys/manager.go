@@ -1,22 +1,57 @@
 package hotkeys
 
+import "context"
+
 type EventHandler interface {
 	OnPress()
 	OnRelease()
 }
 
+// LockHandler is optionally implemented by handlers that want to know when a
+// double-tap locks recording on
+type LockHandler interface {
+	OnLock()
+}
+
+// CommitHandler is optionally implemented by handlers that want the commit
+// hotkey, which pastes a draft built up in compose mode
+type CommitHandler interface {
+	OnCommit()
+}
+
+// MissedReleaseHandler is optionally implemented by handlers that want to know
+// when a session is stopped because the keys were found up without a release
+// event. OnRelease follows as usual.
+type MissedReleaseHandler interface {
+	OnMissedRelease()
+}
+
+// CommitDisplay is the commit hotkey as shown to users
+const CommitDisplay = "Ctrl+Option+P"
+
 type Manager struct {
-	simple *SimpleHotkeyManager
+	simple      *SimpleHotkeyManager
+	engineType  string
+	display     string
+	startEngine func() error // Prepares the trigger source before polling starts, if needed
 }
 
 func NewManager(handler EventHandler) *Manager {
 	return &Manager{
-		simple: NewSimpleManager(handler),
+		simple:     NewSimpleManager(handler),
+		engineType: "simple",
+		display:    "Ctrl+Shift",
 	}
 }
 
-func (m *Manager) Start() error {
-	return m.simple.Start()
+// Start begins polling the trigger, until ctx is cancelled or Stop is called
+func (m *Manager) Start(ctx context.Context) error {
+	if m.startEngine != nil {
+		if err := m.startEngine(); err != nil {
+			return err
+		}
+	}
+	return m.simple.Start(ctx)
 }
 
 func (m *Manager) Stop() {
@@ -27,17 +62,37 @@ func (m *Manager) Listen() {
 	m.simple.Listen()
 }
 
+// SetCommitEnabled turns the commit hotkey on or off
+func (m *Manager) SetCommitEnabled(enabled bool) {
+	m.simple.SetCommitEnabled(enabled)
+}
+
 func (m *Manager) UpdateConfig() error {
 	// No config needed - hotkey is hardcoded
 	return nil
 }
 
 func (m *Manager) GetHotkeyDisplay() string {
-	return "Ctrl+Shift"
+	return m.display
+}
+
+// ReleasedWithOption reports (once) whether Option was held when the hotkey was last released
+func (m *Manager) ReleasedWithOption() bool {
+	return m.simple.ReleasedWithOption()
+}
+
+// ReleasedWithCommand reports (once) whether Command was held when the hotkey was last released
+func (m *Manager) ReleasedWithCommand() bool {
+	return m.simple.ReleasedWithCommand()
+}
+
+// ReleasedWithFn reports (once) whether Fn was held when the hotkey was last released
+func (m *Manager) ReleasedWithFn() bool {
+	return m.simple.ReleasedWithFn()
 }
 
 func (m *Manager) GetEngineType() string {
-	return "simple"
+	return m.engineType
 }
 
 func (m *Manager) IsUsingPrimaryEngine() bool {
@@ -0,0 +1,154 @@
+package hotkeys
+
+/*
+#cgo LDFLAGS: -framework CoreMIDI -framework CoreFoundation
+#include <CoreMIDI/CoreMIDI.h>
+
+static volatile int midiPressed = 0;
+static int midiNumber = -1;
+static int midiIsControl = 0;
+
+static void handleMIDIMessage(Byte status, Byte data1, Byte data2) {
+    int type = status & 0xF0;
+    if (data1 != midiNumber) {
+        return;
+    }
+
+    if (midiIsControl) {
+        // Sustain pedals and buttons send 127 when down and 0 when up
+        if (type == 0xB0) {
+            midiPressed = data2 >= 64;
+        }
+    } else if (type == 0x90) {
+        // Note on with velocity 0 means note off
+        midiPressed = data2 > 0;
+    } else if (type == 0x80) {
+        midiPressed = 0;
+    }
+}
+
+static void midiReadProc(const MIDIPacketList *list, void *readRefCon, void *srcRefCon) {
+    const MIDIPacket *packet = &list->packet[0];
+    for (UInt32 i = 0; i < list->numPackets; i++) {
+        UInt16 j = 0;
+        while (j + 2 < packet->length) {
+            Byte type = packet->data[j] & 0xF0;
+            if (type == 0x80 || type == 0x90 || type == 0xB0) {
+                handleMIDIMessage(packet->data[j], packet->data[j + 1], packet->data[j + 2]);
+                j += 3;
+            } else {
+                j++;
+            }
+        }
+        packet = MIDIPacketNext(packet);
+    }
+}
+
+int startMIDIInput(int number, int isControl) {
+    midiNumber = number;
+    midiIsControl = isControl;
+
+    MIDIClientRef client;
+    MIDIPortRef port;
+    if (MIDIClientCreate(CFSTR("t2"), NULL, NULL, &client) != noErr) {
+        return -1;
+    }
+    if (MIDIInputPortCreate(client, CFSTR("t2 input"), midiReadProc, NULL, &port) != noErr) {
+        return -1;
+    }
+
+    ItemCount sources = MIDIGetNumberOfSources();
+    for (ItemCount i = 0; i < sources; i++) {
+        MIDIPortConnectSource(port, MIDIGetSource(i), NULL);
+    }
+    return (int)sources;
+}
+
+int midiTriggerPressed() {
+    return midiPressed;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// MIDITrigger is the MIDI note or control change that acts as the hotkey
+type MIDITrigger struct {
+	Control bool // Control change (e.g. a sustain pedal) rather than a note
+	Number  int  // Note or controller number, 0-127
+}
+
+// ParseMIDITrigger parses a trigger such as "note 60" or "cc 64"
+func ParseMIDITrigger(s string) (MIDITrigger, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) != 2 {
+		return MIDITrigger{}, fmt.Errorf("invalid MIDI trigger %q (expected e.g. \"note 60\" or \"cc 64\")", s)
+	}
+
+	number, err := strconv.Atoi(fields[1])
+	if err != nil || number < 0 || number > 127 {
+		return MIDITrigger{}, fmt.Errorf("invalid MIDI number %q (expected 0-127)", fields[1])
+	}
+
+	switch fields[0] {
+	case "note":
+		return MIDITrigger{Number: number}, nil
+	case "cc":
+		return MIDITrigger{Control: true, Number: number}, nil
+	default:
+		return MIDITrigger{}, fmt.Errorf("invalid MIDI trigger type %q (expected \"note\" or \"cc\")", fields[0])
+	}
+}
+
+func (t MIDITrigger) String() string {
+	if t.Control {
+		return fmt.Sprintf("MIDI CC %d", t.Number)
+	}
+	return fmt.Sprintf("MIDI note %d", t.Number)
+}
+
+// NewMIDIManager creates a manager triggered by a MIDI note or CC from any
+// controller connected when T2 starts. Holding, tapping and double-tapping
+// work the same as with the keyboard hotkey.
+func NewMIDIManager(handler EventHandler, trigger MIDITrigger) *Manager {
+	simple := NewSimpleManager(handler)
+	simple.detect = detectMIDITrigger
+
+	return &Manager{
+		simple:     simple,
+		engineType: "midi",
+		display:    trigger.String(),
+		startEngine: func() error {
+			return startMIDI(trigger)
+		},
+	}
+}
+
+func startMIDI(trigger MIDITrigger) error {
+	if runtime.GOOS != "darwin" {
+		return fmt.Errorf("MIDI triggers are only supported on macOS")
+	}
+
+	control := 0
+	if trigger.Control {
+		control = 1
+	}
+
+	sources := int(C.startMIDIInput(C.int(trigger.Number), C.int(control)))
+	if sources < 0 {
+		return fmt.Errorf("failed to open CoreMIDI input")
+	}
+	if sources == 0 {
+		return fmt.Errorf("no MIDI controllers connected")
+	}
+	return nil
+}
+
+func detectMIDITrigger() bool {
+	return int(C.midiTriggerPressed()) == 1
+}
@@ -12,74 +12,244 @@ int checkModifierKeys() {
     int shiftPressed = (flags & kCGEventFlagMaskShift) != 0;
     return ctrlPressed && shiftPressed;
 }
+
+int checkOptionKey() {
+    CGEventFlags flags = CGEventSourceFlagsState(kCGEventSourceStateHIDSystemState);
+    return (flags & kCGEventFlagMaskAlternate) != 0;
+}
+
+int checkCommandKey() {
+    CGEventFlags flags = CGEventSourceFlagsState(kCGEventSourceStateHIDSystemState);
+    return (flags & kCGEventFlagMaskCommand) != 0;
+}
+
+int checkFnKey() {
+    CGEventFlags flags = CGEventSourceFlagsState(kCGEventSourceStateHIDSystemState);
+    return (flags & kCGEventFlagMaskSecondaryFn) != 0;
+}
+
+// Ctrl+Option alone happens on the way to other shortcuts, so the commit
+// hotkey needs P as well
+int checkCommitKeys() {
+    CGEventFlags flags = CGEventSourceFlagsState(kCGEventSourceStateHIDSystemState);
+    int ctrlPressed = (flags & kCGEventFlagMaskControl) != 0;
+    int optionPressed = (flags & kCGEventFlagMaskAlternate) != 0;
+    int shiftPressed = (flags & kCGEventFlagMaskShift) != 0;
+    int pPressed = CGEventSourceKeyState(kCGEventSourceStateHIDSystemState, kVK_ANSI_P);
+    return ctrlPressed && optionPressed && !shiftPressed && pPressed;
+}
 */
 import "C"
 
 import (
+	"context"
 	"runtime"
+	"sync/atomic"
 	"time"
+
+	"github.com/bezmoradi/t2/internal/supervisor"
+)
+
+const (
+	tapMaxDuration  = 300 * time.Millisecond // Releases sooner than this are taps, not holds
+	doubleTapWindow = 400 * time.Millisecond // Max gap between the two taps of a double-tap
+
+	// A held hotkey is re-checked this often, and a session whose keys are found
+	// up this many times in a row without a release event is stopped
+	releaseCheckInterval = 1 * time.Second
+	missedReleaseChecks  = 2
+
+	// commitGuard ignores the commit keys right after the record hotkey, so
+	// letting go of Shift first while releasing with Option doesn't commit
+	commitGuard = 500 * time.Millisecond
 )
 
 type SimpleHotkeyManager struct {
 	handler   EventHandler
+	detect    func() bool // Reports whether the trigger is currently held
 	triggered chan bool
 	released  chan bool
-	done      chan bool
-	running   bool
+	committed chan bool
+	ctx       context.Context // Cancelled to stop polling and listening
+	cancel    context.CancelFunc
+	// commitEnabled turns on the Ctrl+Option+P commit hotkey
+	commitEnabled atomic.Bool
+	// releasedWithOption records whether Option was held when the hotkey was released
+	releasedWithOption atomic.Bool
+	// releasedWithCommand records whether Command was held when the hotkey was released
+	releasedWithCommand atomic.Bool
+	// releasedWithFn records whether Fn was held when the hotkey was released
+	releasedWithFn atomic.Bool
 }
 
 func NewSimpleManager(handler EventHandler) *SimpleHotkeyManager {
-	return &SimpleHotkeyManager{
+	s := &SimpleHotkeyManager{
 		handler:   handler,
 		triggered: make(chan bool, 1),
 		released:  make(chan bool, 1),
-		done:      make(chan bool, 1),
-		running:   false,
+		committed: make(chan bool, 1),
 	}
+	s.detect = s.detectCtrlShift
+	return s
 }
 
-func (s *SimpleHotkeyManager) Start() error {
-	s.running = true
+// Start polls the hotkey until ctx is cancelled or Stop is called
+func (s *SimpleHotkeyManager) Start(ctx context.Context) error {
+	s.ctx, s.cancel = context.WithCancel(ctx)
 
 	// Start simple polling approach
-	go s.pollKeyState()
+	go supervisor.Run("hotkey poller", s.pollKeyState)
 
 	return nil
 }
 
 func (s *SimpleHotkeyManager) Stop() {
-	s.running = false
-
-	select {
-	case s.done <- true:
-	default:
+	if s.cancel != nil {
+		s.cancel()
 	}
 }
 
+// Listen passes hotkey events to the handler until the manager is stopped
 func (s *SimpleHotkeyManager) Listen() {
 	for {
 		select {
 		case <-s.triggered:
-			if s.handler != nil {
-				s.handler.OnPress()
+			s.handleSession()
+		case <-s.committed:
+			s.notifyCommit()
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// SetCommitEnabled turns the commit hotkey on or off
+func (s *SimpleHotkeyManager) SetCommitEnabled(enabled bool) {
+	s.commitEnabled.Store(enabled)
+}
+
+// handleSession runs one recording through the hold / tap / double-tap state
+// machine: holding records until release, a single tap is passed on as a quick
+// press, and a double-tap locks recording on until the next tap
+func (s *SimpleHotkeyManager) handleSession() {
+	s.notifyPress()
+	pressedAt := time.Now()
+	if !s.waitForRelease() {
+		s.notifyMissedRelease()
+		s.notifyRelease()
+		return
+	}
+
+	// Hold to talk
+	if time.Since(pressedAt) >= tapMaxDuration {
+		s.notifyRelease()
+		return
+	}
+
+	// A tap - wait to see whether a second one follows
+	select {
+	case <-s.triggered:
+	case <-time.After(doubleTapWindow):
+		s.notifyRelease()
+		return
+	case <-s.ctx.Done():
+		return
+	}
+	s.waitForRelease()
+	s.notifyLock()
+
+	// Locked on - the next tap stops recording
+	select {
+	case <-s.triggered:
+	case <-s.ctx.Done():
+		return
+	}
+	s.waitForRelease()
+	s.notifyRelease()
+}
+
+// waitForRelease waits for the hotkey to be released. The keys are re-checked
+// periodically in case the poller missed the release, e.g. across sleep/wake;
+// it returns false if they were found up without a release event.
+func (s *SimpleHotkeyManager) waitForRelease() bool {
+	ticker := time.NewTicker(releaseCheckInterval)
+	defer ticker.Stop()
+
+	upChecks := 0
+	for {
+		select {
+		case <-s.released:
+			return true
+		case <-ticker.C:
+			if s.detect() {
+				upChecks = 0
+				continue
 			}
-			<-s.released // Wait for release
-			if s.handler != nil {
-				s.handler.OnRelease()
+			upChecks++
+			if upChecks >= missedReleaseChecks {
+				// Don't let a late release end the next session early
+				select {
+				case <-s.released:
+				default:
+				}
+				return false
 			}
-		case <-s.done:
-			return
 		}
 	}
 }
 
+func (s *SimpleHotkeyManager) notifyPress() {
+	if s.handler != nil {
+		s.handler.OnPress()
+	}
+}
+
+func (s *SimpleHotkeyManager) notifyRelease() {
+	if s.handler != nil {
+		s.handler.OnRelease()
+	}
+}
+
+func (s *SimpleHotkeyManager) notifyCommit() {
+	if handler, ok := s.handler.(CommitHandler); ok {
+		handler.OnCommit()
+	}
+}
+
+func (s *SimpleHotkeyManager) notifyMissedRelease() {
+	if handler, ok := s.handler.(MissedReleaseHandler); ok {
+		handler.OnMissedRelease()
+	}
+}
+
+func (s *SimpleHotkeyManager) notifyLock() {
+	if handler, ok := s.handler.(LockHandler); ok {
+		handler.OnLock()
+	}
+}
+
 func (s *SimpleHotkeyManager) pollKeyState() {
 	wasPressed := false
+	commitHeld := false
+	var lastHeld time.Time
 
-	for s.running {
+	for s.ctx.Err() == nil {
 		// Simple approach: trigger on any key combination that looks like Ctrl+Shift
 		// This is a basic implementation - for demo purposes
-		isPressed := s.detectCtrlShift()
+		isPressed := s.detect()
+		if isPressed {
+			lastHeld = time.Now()
+		}
+
+		// The commit hotkey fires once per press
+		commitPressed := s.commitEnabled.Load() && !isPressed && s.detectCommit()
+		if commitPressed && !commitHeld && time.Since(lastHeld) > commitGuard {
+			select {
+			case s.committed <- true:
+			default:
+			}
+		}
+		commitHeld = commitPressed
 
 		if isPressed && !wasPressed {
 			select {
@@ -88,6 +258,9 @@ func (s *SimpleHotkeyManager) pollKeyState() {
 			}
 			wasPressed = true
 		} else if !isPressed && wasPressed {
+			s.releasedWithOption.Store(s.detectOption())
+			s.releasedWithCommand.Store(s.detectCommand())
+			s.releasedWithFn.Store(s.detectFn())
 			select {
 			case s.released <- true:
 			default:
@@ -108,3 +281,49 @@ func (s *SimpleHotkeyManager) detectCtrlShift() bool {
 	// This could be extended with platform-specific implementations
 	return false
 }
+
+func (s *SimpleHotkeyManager) detectCommit() bool {
+	if runtime.GOOS == "darwin" {
+		return int(C.checkCommitKeys()) == 1
+	}
+	return false
+}
+
+func (s *SimpleHotkeyManager) detectOption() bool {
+	if runtime.GOOS == "darwin" {
+		return int(C.checkOptionKey()) == 1
+	}
+	return false
+}
+
+func (s *SimpleHotkeyManager) detectCommand() bool {
+	if runtime.GOOS == "darwin" {
+		return int(C.checkCommandKey()) == 1
+	}
+	return false
+}
+
+func (s *SimpleHotkeyManager) detectFn() bool {
+	if runtime.GOOS == "darwin" {
+		return int(C.checkFnKey()) == 1
+	}
+	return false
+}
+
+// ReleasedWithOption reports whether Option was held at the last release and
+// clears the flag so it only applies to one session
+func (s *SimpleHotkeyManager) ReleasedWithOption() bool {
+	return s.releasedWithOption.Swap(false)
+}
+
+// ReleasedWithCommand reports whether Command was held at the last release and
+// clears the flag so it only applies to one session
+func (s *SimpleHotkeyManager) ReleasedWithCommand() bool {
+	return s.releasedWithCommand.Swap(false)
+}
+
+// ReleasedWithFn reports whether Fn was held at the last release and
+// clears the flag so it only applies to one session
+func (s *SimpleHotkeyManager) ReleasedWithFn() bool {
+	return s.releasedWithFn.Swap(false)
+}
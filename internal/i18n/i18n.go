@@ -0,0 +1,104 @@
+// Package i18n translates user-facing messages using JSON catalogs keyed by
+// the English text, so adding a language only takes a new file in locales/
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed locales/*.json
+var catalogFiles embed.FS
+
+var (
+	mu       sync.RWMutex
+	messages map[string]string
+)
+
+// SetLocale switches to the catalog for locale, e.g. "de" or "pt_BR.UTF-8".
+// An empty locale follows LC_ALL, LC_MESSAGES and LANG, falling back to English
+// without an error when the environment names a language without a catalog.
+func SetLocale(locale string) error {
+	fromEnv := locale == ""
+	if fromEnv {
+		locale = envLocale()
+	}
+
+	catalog, err := loadCatalog(locale)
+	if err != nil && !fromEnv {
+		return err
+	}
+
+	mu.Lock()
+	messages = catalog
+	mu.Unlock()
+	return nil
+}
+
+// T returns the translation of msg for the current locale, or msg itself
+func T(msg string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if translated, ok := messages[msg]; ok && translated != "" {
+		return translated
+	}
+	return msg
+}
+
+// Available lists the locales that have a catalog
+func Available() []string {
+	entries, err := catalogFiles.ReadDir("locales")
+	if err != nil {
+		return nil
+	}
+
+	locales := []string{"en"}
+	for _, entry := range entries {
+		locales = append(locales, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+func envLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// loadCatalog finds the most specific catalog for locale, trying "pt_br" before "pt"
+func loadCatalog(locale string) (map[string]string, error) {
+	name := strings.ToLower(strings.ReplaceAll(locale, "-", "_"))
+	if i := strings.IndexAny(name, ".@"); i >= 0 {
+		name = name[:i]
+	}
+
+	language, _, _ := strings.Cut(name, "_")
+	if language == "" || language == "en" || language == "c" || language == "posix" {
+		return nil, nil
+	}
+
+	for _, candidate := range []string{name, language} {
+		data, err := catalogFiles.ReadFile(path.Join("locales", candidate+".json"))
+		if err != nil {
+			continue
+		}
+
+		var catalog map[string]string
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return nil, fmt.Errorf("invalid catalog for %s: %v", candidate, err)
+		}
+		return catalog, nil
+	}
+
+	return nil, fmt.Errorf("no translation for locale %q (available: %s)", locale, strings.Join(Available(), ", "))
+}
@@ -0,0 +1,144 @@
+package instance
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Running probes the lock by taking it for a moment, so Acquire retries a few
+// times before deciding another daemon holds it
+const (
+	acquireAttempts   = 5
+	acquireRetryDelay = 50 * time.Millisecond
+)
+
+// Lock is an exclusive lock ensuring only one daemon runs at a time.
+// The lock file also records the owner's PID so other commands can signal it.
+type Lock struct {
+	file *os.File
+}
+
+// AlreadyRunningError is returned when another daemon holds the lock
+type AlreadyRunningError struct {
+	PID int
+}
+
+func (e *AlreadyRunningError) Error() string {
+	return fmt.Sprintf("T2 is already running (PID %d)", e.PID)
+}
+
+// Acquire takes the lock at path, failing with *AlreadyRunningError if it's held
+func Acquire(path string) (*Lock, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %v", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil || attempt == acquireAttempts {
+			break
+		}
+		time.Sleep(acquireRetryDelay)
+	}
+	if err != nil {
+		file.Close()
+		pid, _ := ReadPID(path)
+		return nil, &AlreadyRunningError{PID: pid}
+	}
+
+	// Record our PID for other commands
+	if err := file.Truncate(0); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &Lock{file: file}, nil
+}
+
+// Takeover asks the running daemon to exit and acquires the lock once it has
+func Takeover(path string, timeout time.Duration) (*Lock, error) {
+	lock, err := Acquire(path)
+	if err == nil {
+		return lock, nil
+	}
+
+	if _, ok := err.(*AlreadyRunningError); !ok {
+		return nil, err
+	}
+
+	if _, err := Stop(path, timeout); err != nil {
+		return nil, err
+	}
+	return Acquire(path)
+}
+
+// Running reports whether a daemon currently holds the lock at path, and its PID
+func Running(path string) (int, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, false
+	}
+	defer file.Close()
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err == nil {
+		syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		return 0, false
+	}
+
+	pid, _ := ReadPID(path)
+	return pid, true
+}
+
+// Stop sends SIGTERM to the running daemon and waits for it to release the lock
+func Stop(path string, timeout time.Duration) (int, error) {
+	pid, running := Running(path)
+	if !running {
+		return 0, fmt.Errorf("T2 is not running")
+	}
+
+	if pid > 0 {
+		if err := syscall.Kill(pid, syscall.SIGTERM); err != nil && err != syscall.ESRCH {
+			return pid, fmt.Errorf("failed to signal PID %d: %v", pid, err)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+		if _, running := Running(path); !running {
+			return pid, nil
+		}
+	}
+
+	return pid, fmt.Errorf("PID %d did not exit within %s", pid, timeout)
+}
+
+// Release clears the recorded PID and drops the lock. The file itself is kept:
+// removing it would let a waiting process lock an inode nobody else can see.
+func (l *Lock) Release() {
+	if l == nil || l.file == nil {
+		return
+	}
+	l.file.Truncate(0)
+	syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	l.file.Close()
+	l.file = nil
+}
+
+// ReadPID returns the PID recorded in the lock file
+func ReadPID(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
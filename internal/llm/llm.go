@@ -0,0 +1,191 @@
+// Package llm sends prompts to a hosted language model, for features like
+// t2 summarize that work on transcripts after they're pasted
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Providers
+const (
+	ProviderAnthropic = "anthropic"
+	ProviderOpenAI    = "openai"
+)
+
+const (
+	anthropicURL     = "https://api.anthropic.com/v1/messages"
+	anthropicVersion = "2023-06-01"
+	openAIURL        = "https://api.openai.com/v1/chat/completions"
+
+	// maxTokens bounds the length of a response
+	maxTokens = 2048
+
+	requestTimeout = 2 * time.Minute
+)
+
+// defaultModels are used when llm_model isn't set
+var defaultModels = map[string]string{
+	ProviderAnthropic: "claude-sonnet-4-5",
+	ProviderOpenAI:    "gpt-4o-mini",
+}
+
+// keyEnvVars are read when llm_api_key isn't set
+var keyEnvVars = map[string]string{
+	ProviderAnthropic: "ANTHROPIC_API_KEY",
+	ProviderOpenAI:    "OPENAI_API_KEY",
+}
+
+// Client sends prompts to one provider
+type Client struct {
+	provider string
+	model    string
+	apiKey   string
+	http     *http.Client
+}
+
+// NewClient returns a client for provider ("anthropic" or "openai"). An empty
+// model uses the provider's default and an empty key is read from
+// ANTHROPIC_API_KEY or OPENAI_API_KEY.
+func NewClient(provider string, model string, apiKey string) (*Client, error) {
+	provider = strings.ToLower(provider)
+	if provider == "" {
+		return nil, fmt.Errorf("no LLM configured - set llm_provider to %q or %q", ProviderAnthropic, ProviderOpenAI)
+	}
+	if _, ok := defaultModels[provider]; !ok {
+		return nil, fmt.Errorf("unknown llm_provider %q (expected %q or %q)", provider, ProviderAnthropic, ProviderOpenAI)
+	}
+
+	if model == "" {
+		model = defaultModels[provider]
+	}
+	if apiKey == "" {
+		apiKey = os.Getenv(keyEnvVars[provider])
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("no API key for %s - set llm_api_key or %s", provider, keyEnvVars[provider])
+	}
+
+	return &Client{
+		provider: provider,
+		model:    model,
+		apiKey:   apiKey,
+		http:     &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// SetTimeout limits how long a request may take, e.g. while someone waits for a paste
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.http.Timeout = timeout
+}
+
+// Complete sends instructions and input and returns the model's reply
+func (c *Client) Complete(instructions string, input string) (string, error) {
+	if c.provider == ProviderAnthropic {
+		return c.completeAnthropic(instructions, input)
+	}
+	return c.completeOpenAI(instructions, input)
+}
+
+func (c *Client) completeAnthropic(instructions string, input string) (string, error) {
+	body := map[string]any{
+		"model":      c.model,
+		"max_tokens": maxTokens,
+		"system":     instructions,
+		"messages": []map[string]string{
+			{"role": "user", "content": input},
+		},
+	}
+	headers := map[string]string{
+		"x-api-key":         c.apiKey,
+		"anthropic-version": anthropicVersion,
+	}
+
+	var response struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := c.post(anthropicURL, headers, body, &response); err != nil {
+		return "", err
+	}
+
+	var text strings.Builder
+	for _, block := range response.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	return strings.TrimSpace(text.String()), nil
+}
+
+func (c *Client) completeOpenAI(instructions string, input string) (string, error) {
+	body := map[string]any{
+		"model":      c.model,
+		"max_tokens": maxTokens,
+		"messages": []map[string]string{
+			{"role": "system", "content": instructions},
+			{"role": "user", "content": input},
+		},
+	}
+	headers := map[string]string{
+		"Authorization": "Bearer " + c.apiKey,
+	}
+
+	var response struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := c.post(openAIURL, headers, body, &response); err != nil {
+		return "", err
+	}
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("%s returned no reply", c.provider)
+	}
+	return strings.TrimSpace(response.Choices[0].Message.Content), nil
+}
+
+// post sends body as JSON and decodes the JSON reply into response
+func (c *Client) post(url string, headers map[string]string, body any, response any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %v", c.provider, err)
+	}
+	defer resp.Body.Close()
+
+	reply, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s reply: %v", c.provider, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s: %s", c.provider, resp.Status, strings.TrimSpace(string(reply)))
+	}
+	if err := json.Unmarshal(reply, response); err != nil {
+		return fmt.Errorf("failed to parse %s reply: %v", c.provider, err)
+	}
+	return nil
+}
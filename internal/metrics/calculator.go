@@ -3,6 +3,8 @@ package metrics
 import (
 	"fmt"
 	"time"
+
+	"github.com/bezmoradi/t2/internal/i18n"
 )
 
 type TimeFormatter struct{}
@@ -121,23 +123,23 @@ func (pc *ProductivityCalculator) EstimateTypingTime(wordCount int, typingSpeed
 
 func (pc *ProductivityCalculator) GetProductivityInsight(totalSaved time.Duration, totalSessions int) string {
 	if totalSessions == 0 {
-		return "Start using T2 to see your productivity gains!"
+		return i18n.T("Start using T2 to see your productivity gains!")
 	}
 
 	avgSavedPerSession := totalSaved / time.Duration(totalSessions)
 	formatter := NewTimeFormatter()
 
 	if totalSaved < time.Minute {
-		return fmt.Sprintf("You're saving %s per session on average.", formatter.FormatDurationShort(avgSavedPerSession))
+		return fmt.Sprintf(i18n.T("You're saving %s per session on average."), formatter.FormatDurationShort(avgSavedPerSession))
 	}
 
 	if totalSaved < time.Hour {
-		return fmt.Sprintf("You've saved %s total, averaging %s per session!",
+		return fmt.Sprintf(i18n.T("You've saved %s total, averaging %s per session!"),
 			formatter.FormatDurationShort(totalSaved),
 			formatter.FormatDurationShort(avgSavedPerSession))
 	}
 
-	return fmt.Sprintf("Amazing! You've saved %s total - that's %s per session on average!",
+	return fmt.Sprintf(i18n.T("Amazing! You've saved %s total - that's %s per session on average!"),
 		formatter.FormatDuration(totalSaved),
 		formatter.FormatDurationShort(avgSavedPerSession))
 }
@@ -153,17 +155,17 @@ func NewStatsFormatter() *StatsFormatter {
 }
 
 func (sf *StatsFormatter) FormatSessionSummary(session *SessionMetrics, todayMetrics *DailyMetrics) string {
-	summary := fmt.Sprintf("✅ Pasted %d words (%s recording)\n",
+	summary := fmt.Sprintf(i18n.T("✅ Pasted %d words (%s recording)\n"),
 		session.WordCount,
 		sf.timeFormatter.FormatDurationShort(session.RecordingTime))
 
 	if session.SpeakingRate > 0 {
-		summary += fmt.Sprintf("📊 Session: %d WPM speaking rate\n", session.SpeakingRate)
+		summary += fmt.Sprintf(i18n.T("📊 Session: %d WPM speaking rate\n"), session.SpeakingRate)
 	}
 
 	if todayMetrics != nil && todayMetrics.SessionCount > 0 {
 		todayTimeSaved := sf.timeFormatter.FormatDurationShort(todayMetrics.TotalSaved)
-		summary += fmt.Sprintf("📈 Today: %d words, %s saved", todayMetrics.TotalWords, todayTimeSaved)
+		summary += fmt.Sprintf(i18n.T("📈 Today: %d words, %s saved"), todayMetrics.TotalWords, todayTimeSaved)
 	}
 
 	return summary
@@ -171,46 +173,96 @@ func (sf *StatsFormatter) FormatSessionSummary(session *SessionMetrics, todayMet
 
 func (sf *StatsFormatter) FormatSessionSummaryLines(session *SessionMetrics, todayMetrics *DailyMetrics) []string {
 	lines := []string{
-		fmt.Sprintf("✅ Pasted %d words (%s recording)",
+		fmt.Sprintf(i18n.T("✅ Pasted %d words (%s recording)"),
 			session.WordCount,
 			sf.timeFormatter.FormatDurationShort(session.RecordingTime)),
 	}
 
 	if session.SpeakingRate > 0 {
-		lines = append(lines, fmt.Sprintf("📊 Session: %d WPM speaking rate", session.SpeakingRate))
+		lines = append(lines, fmt.Sprintf(i18n.T("📊 Session: %d WPM speaking rate"), session.SpeakingRate))
 	}
 
 	if todayMetrics != nil && todayMetrics.SessionCount > 0 {
 		todayTimeSaved := sf.timeFormatter.FormatDurationShort(todayMetrics.TotalSaved)
-		lines = append(lines, fmt.Sprintf("📈 Today: %d words, %s saved", todayMetrics.TotalWords, todayTimeSaved))
+		lines = append(lines, fmt.Sprintf(i18n.T("📈 Today: %d words, %s saved"), todayMetrics.TotalWords, todayTimeSaved))
 	}
 
 	return lines
 }
 
+// FormatGoalProgress describes today's progress towards the daily goal and the current streak
+func (sf *StatsFormatter) FormatGoalProgress(target int, unit string, todayMetrics *DailyMetrics, streak int) string {
+	streakText := ""
+	if streak > 1 {
+		streakText = fmt.Sprintf(i18n.T(" · 🔥 %d-day streak"), streak)
+	}
+
+	if target <= 0 {
+		if streakText == "" {
+			return ""
+		}
+		return fmt.Sprintf(i18n.T("🔥 %d-day streak"), streak)
+	}
+
+	progress := 0
+	if todayMetrics != nil {
+		if unit == GoalSessions {
+			progress = todayMetrics.SessionCount
+		} else {
+			progress = todayMetrics.TotalWords
+		}
+	}
+
+	if progress >= target {
+		return fmt.Sprintf(i18n.T("🎯 Goal reached: %d/%d %s%s"), progress, target, unit, streakText)
+	}
+	return fmt.Sprintf(i18n.T("🎯 Goal: %d/%d %s (%d%%)%s"), progress, target, unit, progress*100/target, streakText)
+}
+
 func (sf *StatsFormatter) FormatTotalStats(totalMetrics *TotalMetrics) string {
 	if totalMetrics.TotalSessions == 0 {
-		return "📊 No usage statistics yet. Start using T2 to track your productivity!"
+		return i18n.T("📊 No usage statistics yet. Start using T2 to track your productivity!")
+	}
+
+	stats := i18n.T("📊 Total Statistics:\n")
+	stats += fmt.Sprintf(i18n.T("   Words transcribed: %d\n"), totalMetrics.TotalWords)
+	stats += fmt.Sprintf(i18n.T("   Sessions completed: %d\n"), totalMetrics.TotalSessions)
+	stats += fmt.Sprintf(i18n.T("   Time saved: %s\n"), sf.timeFormatter.FormatDuration(totalMetrics.TotalSaved))
+	stats += fmt.Sprintf(i18n.T("   Keystrokes avoided: %d\n"), totalMetrics.TotalKeystrokes)
+	stats += fmt.Sprintf(i18n.T("   Avg words/session: %d\n"), totalMetrics.AvgWordsPerSession)
+	stats += fmt.Sprintf(i18n.T("   Avg saved/session: %s"), sf.timeFormatter.FormatDurationShort(totalMetrics.AvgSavedPerSession))
+
+	return stats
+}
+
+func (sf *StatsFormatter) FormatLatencyStats(latency *LatencyStats) string {
+	if latency.Sessions == 0 {
+		return i18n.T("⏱️  No latency data yet.")
+	}
+
+	ms := func(d time.Duration) string {
+		return fmt.Sprintf("%dms", d.Milliseconds())
 	}
 
-	stats := "📊 Total Statistics:\n"
-	stats += fmt.Sprintf("   Words transcribed: %d\n", totalMetrics.TotalWords)
-	stats += fmt.Sprintf("   Sessions completed: %d\n", totalMetrics.TotalSessions)
-	stats += fmt.Sprintf("   Time saved: %s\n", sf.timeFormatter.FormatDuration(totalMetrics.TotalSaved))
-	stats += fmt.Sprintf("   Avg words/session: %d\n", totalMetrics.AvgWordsPerSession)
-	stats += fmt.Sprintf("   Avg saved/session: %s", sf.timeFormatter.FormatDurationShort(totalMetrics.AvgSavedPerSession))
+	stats := fmt.Sprintf(i18n.T("⏱️  Latency (p50 / p95 over %d sessions):\n"), latency.Sessions)
+	stats += fmt.Sprintf(i18n.T("   Recording:        %s / %s\n"), sf.timeFormatter.FormatDurationShort(latency.RecordingTime.P50), sf.timeFormatter.FormatDurationShort(latency.RecordingTime.P95))
+	stats += fmt.Sprintf(i18n.T("   Termination wait: %s / %s\n"), ms(latency.TerminationWait.P50), ms(latency.TerminationWait.P95))
+	stats += fmt.Sprintf(i18n.T("   Transcript wait:  %s / %s\n"), ms(latency.TranscriptWait.P50), ms(latency.TranscriptWait.P95))
+	stats += fmt.Sprintf(i18n.T("   Paste:            %s / %s\n"), ms(latency.PasteTime.P50), ms(latency.PasteTime.P95))
+	stats += fmt.Sprintf(i18n.T("   Release to paste: %s / %s"), ms(latency.Total.P50), ms(latency.Total.P95))
 
 	return stats
 }
 
 func (sf *StatsFormatter) FormatWeeklyStats(weeklyMetrics []*DailyMetrics) string {
 	if len(weeklyMetrics) == 0 {
-		return "📅 No weekly data available yet."
+		return i18n.T("📅 No weekly data available yet.")
 	}
 
 	totalWords := 0
 	totalSaved := time.Duration(0)
 	totalSessions := 0
+	totalKeystrokes := 0
 	activeDays := 0
 
 	for _, day := range weeklyMetrics {
@@ -219,18 +271,20 @@ func (sf *StatsFormatter) FormatWeeklyStats(weeklyMetrics []*DailyMetrics) strin
 			totalWords += day.TotalWords
 			totalSaved += day.TotalSaved
 			totalSessions += day.SessionCount
+			totalKeystrokes += day.TotalKeystrokes
 		}
 	}
 
 	if activeDays == 0 {
-		return "📅 No activity this week yet."
+		return i18n.T("📅 No activity this week yet.")
 	}
 
-	stats := "📅 This Week:\n"
-	stats += fmt.Sprintf("   Active days: %d/7\n", activeDays)
-	stats += fmt.Sprintf("   Total words: %d\n", totalWords)
-	stats += fmt.Sprintf("   Total sessions: %d\n", totalSessions)
-	stats += fmt.Sprintf("   Time saved: %s", sf.timeFormatter.FormatDuration(totalSaved))
+	stats := i18n.T("📅 This Week:\n")
+	stats += fmt.Sprintf(i18n.T("   Active days: %d/7\n"), activeDays)
+	stats += fmt.Sprintf(i18n.T("   Total words: %d\n"), totalWords)
+	stats += fmt.Sprintf(i18n.T("   Total sessions: %d\n"), totalSessions)
+	stats += fmt.Sprintf(i18n.T("   Time saved: %s\n"), sf.timeFormatter.FormatDuration(totalSaved))
+	stats += fmt.Sprintf(i18n.T("   Keystrokes avoided: %d"), totalKeystrokes)
 
 	return stats
 }
@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const barWidth = 30
+
+// bar renders value as a horizontal bar scaled against maxValue
+func bar(value, maxValue int) string {
+	if value <= 0 || maxValue <= 0 {
+		return ""
+	}
+
+	length := value * barWidth / maxValue
+	if length == 0 {
+		length = 1 // Always show some activity
+	}
+	return strings.Repeat("█", length)
+}
+
+// trend formats the percentage change from previous to current
+func trend(current, previous int) string {
+	if previous == 0 {
+		if current == 0 {
+			return "no change"
+		}
+		return "new"
+	}
+
+	change := float64(current-previous) / float64(previous) * 100
+	return fmt.Sprintf("%+.0f%%", change)
+}
+
+// periodTotals sums up words, sessions and time saved across days
+func periodTotals(days []*DailyMetrics) (int, int, time.Duration, int) {
+	totalWords, totalSessions, activeDays := 0, 0, 0
+	totalSaved := time.Duration(0)
+
+	for _, day := range days {
+		if day.SessionCount > 0 {
+			activeDays++
+			totalWords += day.TotalWords
+			totalSessions += day.SessionCount
+			totalSaved += day.TotalSaved
+		}
+	}
+
+	return totalWords, totalSessions, totalSaved, activeDays
+}
+
+// FormatPeriodStats renders a month or year view with a word-count bar chart,
+// a busiest hour-of-day histogram and trends versus the previous period
+func (sf *StatsFormatter) FormatPeriodStats(period string, current, previous []*DailyMetrics) string {
+	totalWords, totalSessions, totalSaved, activeDays := periodTotals(current)
+	prevWords, prevSessions, prevSaved, _ := periodTotals(previous)
+
+	label, previousLabel := "This Month", "last month"
+	if period == PeriodYear {
+		label, previousLabel = "This Year", "last year"
+	}
+
+	if totalSessions == 0 {
+		return fmt.Sprintf("📅 No activity in %s yet.", strings.ToLower(label))
+	}
+
+	stats := fmt.Sprintf("📅 %s:\n", label)
+	stats += fmt.Sprintf("   Active days: %d/%d\n", activeDays, len(current))
+	stats += fmt.Sprintf("   Total words: %d (%s)\n", totalWords, trend(totalWords, prevWords))
+	stats += fmt.Sprintf("   Total sessions: %d (%s)\n", totalSessions, trend(totalSessions, prevSessions))
+	stats += fmt.Sprintf("   Time saved: %s (%s)\n", sf.timeFormatter.FormatDuration(totalSaved), trend(int(totalSaved), int(prevSaved)))
+	stats += fmt.Sprintf("   Trends compare against the same point %s\n", previousLabel)
+	stats += "\n"
+
+	if period == PeriodYear {
+		stats += sf.formatMonthlyChart(current)
+	} else {
+		stats += sf.formatDailyChart(current)
+	}
+	stats += "\n"
+	stats += sf.formatHourHistogram(current)
+
+	return strings.TrimRight(stats, "\n")
+}
+
+// formatDailyChart draws one bar per day of words transcribed
+func (sf *StatsFormatter) formatDailyChart(days []*DailyMetrics) string {
+	maxWords := 0
+	for _, day := range days {
+		maxWords = max(maxWords, day.TotalWords)
+	}
+
+	chart := "📊 Words per day:\n"
+	for _, day := range days {
+		date, err := time.Parse("2006-01-02", day.Date)
+		if err != nil {
+			continue
+		}
+		chart += fmt.Sprintf("   %s │%-*s %d\n", date.Format("Jan 02"), barWidth, bar(day.TotalWords, maxWords), day.TotalWords)
+	}
+
+	return chart
+}
+
+// formatMonthlyChart draws one bar per month of words transcribed
+func (sf *StatsFormatter) formatMonthlyChart(days []*DailyMetrics) string {
+	var months []string
+	wordsByMonth := make(map[string]int)
+
+	for _, day := range days {
+		date, err := time.Parse("2006-01-02", day.Date)
+		if err != nil {
+			continue
+		}
+		month := date.Format("Jan")
+		if _, ok := wordsByMonth[month]; !ok {
+			months = append(months, month)
+		}
+		wordsByMonth[month] += day.TotalWords
+	}
+
+	maxWords := 0
+	for _, words := range wordsByMonth {
+		maxWords = max(maxWords, words)
+	}
+
+	chart := "📊 Words per month:\n"
+	for _, month := range months {
+		words := wordsByMonth[month]
+		chart += fmt.Sprintf("   %s │%-*s %d\n", month, barWidth, bar(words, maxWords), words)
+	}
+
+	return chart
+}
+
+// formatHourHistogram shows how many sessions started in each hour of the day
+func (sf *StatsFormatter) formatHourHistogram(days []*DailyMetrics) string {
+	var sessionsByHour [24]int
+	for _, day := range days {
+		for _, session := range day.Sessions {
+			sessionsByHour[session.Timestamp.In(Location()).Hour()]++
+		}
+	}
+
+	busiestHour, maxSessions := 0, 0
+	for hour, count := range sessionsByHour {
+		if count > maxSessions {
+			busiestHour, maxSessions = hour, count
+		}
+	}
+
+	histogram := fmt.Sprintf("🕐 Busiest hour: %02d:00-%02d:00\n", busiestHour, (busiestHour+1)%24)
+	for hour, count := range sessionsByHour {
+		if count == 0 {
+			continue
+		}
+		histogram += fmt.Sprintf("   %02d:00 │%-*s %d\n", hour, barWidth, bar(count, maxSessions), count)
+	}
+
+	return histogram
+}
@@ -0,0 +1,142 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Reasons a session can be skipped without pasting
+const (
+	SkipQuickPress = "quick_press"
+	SkipSilence    = "silence"            // Prolonged silence before any speech
+	SkipLowAudio   = "low_rms"            // Too quiet throughout to contain speech
+	SkipConnection = "connection_failure" // No connection to stream to
+)
+
+// SkipReasons lists every skip reason in display order
+var SkipReasons = []string{SkipQuickPress, SkipSilence, SkipLowAudio, SkipConnection}
+
+// latencyBuckets are the upper bounds (seconds) of the latency histogram
+var latencyBuckets = []float64{0.25, 0.5, 0.75, 1, 1.5, 2, 3, 5}
+
+// Counters are in-memory totals for the running daemon, exposed in the
+// Prometheus text format on /metrics. They reset when the daemon restarts.
+type Counters struct {
+	mutex      sync.Mutex
+	sessions   int
+	failures   int
+	skipped    map[string]int
+	words      int
+	reconnects int
+
+	droppedChunks int
+	queueMaxDepth int // Deepest the audio send queue got in the last session
+
+	latencyCounts []int // Per bucket, non-cumulative, plus +Inf at the end
+	latencySum    float64
+	latencyCount  int
+}
+
+// NewCounters creates empty counters
+func NewCounters() *Counters {
+	return &Counters{
+		skipped:       make(map[string]int),
+		latencyCounts: make([]int, len(latencyBuckets)+1),
+	}
+}
+
+// RecordSession counts a pasted session. A zero latency isn't observed.
+func (c *Counters) RecordSession(text string, latency time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.sessions++
+	c.words += countWords(text)
+
+	if latency == 0 {
+		return
+	}
+	seconds := latency.Seconds()
+	bucket := len(latencyBuckets)
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			bucket = i
+			break
+		}
+	}
+	c.latencyCounts[bucket]++
+	c.latencySum += seconds
+	c.latencyCount++
+}
+
+// RecordFailure counts a session that produced no transcript
+func (c *Counters) RecordFailure() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.failures++
+}
+
+// RecordSkip counts a session skipped for reason, one of SkipReasons
+func (c *Counters) RecordSkip(reason string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.skipped[reason]++
+}
+
+// RecordReconnect counts a new or swapped-in streaming connection
+func (c *Counters) RecordReconnect() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reconnects++
+}
+
+// RecordAudioQueue records a session's audio send queue statistics
+func (c *Counters) RecordAudioQueue(maxDepth int, dropped int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.queueMaxDepth = maxDepth
+	c.droppedChunks += dropped
+}
+
+// WritePrometheus writes the counters in the Prometheus text exposition format
+func (c *Counters) WritePrometheus(w io.Writer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	writeCounter(w, "t2_sessions_total", "Sessions transcribed and pasted.", c.sessions)
+	writeCounter(w, "t2_session_failures_total", "Sessions that produced no transcript.", c.failures)
+
+	fmt.Fprintln(w, "# HELP t2_sessions_skipped_total Sessions skipped before transcription.")
+	fmt.Fprintln(w, "# TYPE t2_sessions_skipped_total counter")
+	for _, reason := range SkipReasons {
+		fmt.Fprintf(w, "t2_sessions_skipped_total{reason=%q} %d\n", reason, c.skipped[reason])
+	}
+
+	writeCounter(w, "t2_words_total", "Words pasted.", c.words)
+	writeCounter(w, "t2_reconnects_total", "Streaming connections opened or swapped in.", c.reconnects)
+	writeCounter(w, "t2_audio_chunks_dropped_total", "Audio chunks dropped because the network fell behind.", c.droppedChunks)
+
+	fmt.Fprintln(w, "# HELP t2_audio_queue_max_depth Deepest the audio send queue got in the last session.")
+	fmt.Fprintln(w, "# TYPE t2_audio_queue_max_depth gauge")
+	fmt.Fprintf(w, "t2_audio_queue_max_depth %d\n", c.queueMaxDepth)
+
+	fmt.Fprintln(w, "# HELP t2_latency_seconds Time from key release to paste.")
+	fmt.Fprintln(w, "# TYPE t2_latency_seconds histogram")
+	cumulative := 0
+	for i, bound := range latencyBuckets {
+		cumulative += c.latencyCounts[i]
+		fmt.Fprintf(w, "t2_latency_seconds_bucket{le=\"%g\"} %d\n", bound, cumulative)
+	}
+	cumulative += c.latencyCounts[len(latencyBuckets)]
+	fmt.Fprintf(w, "t2_latency_seconds_bucket{le=\"+Inf\"} %d\n", cumulative)
+	fmt.Fprintf(w, "t2_latency_seconds_sum %g\n", c.latencySum)
+	fmt.Fprintf(w, "t2_latency_seconds_count %d\n", c.latencyCount)
+}
+
+func writeCounter(w io.Writer, name string, help string, value int) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s counter\n", name)
+	fmt.Fprintf(w, "%s %d\n", name, value)
+}
@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Export formats supported by WriteExport
+const (
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+)
+
+// defaultExportDays is how far back an export reaches without --all
+const defaultExportDays = 30
+
+// ExportRow is one session flattened with its settings for analytics tools.
+// Durations are exported as milliseconds so DuckDB/pandas don't need to know Go's units.
+type ExportRow struct {
+	Timestamp       string  `parquet:"timestamp"`
+	Date            string  `parquet:"date"`
+	WordCount       int64   `parquet:"word_count"`
+	RecordingTimeMs int64   `parquet:"recording_time_ms"`
+	TimeSavedMs     int64   `parquet:"time_saved_ms"`
+	SpeakingRate    int64   `parquet:"speaking_rate_wpm"`
+	App             string  `parquet:"app"`
+	Tag             string  `parquet:"tag"`
+	Provider        string  `parquet:"provider"`
+	LatencyMs       int64   `parquet:"latency_ms"`
+	Confidence      float64 `parquet:"confidence"`
+	Language        string  `parquet:"language"`
+	TypingSpeed     int64   `parquet:"typing_speed_wpm"`
+
+	TerminationWaitMs int64 `parquet:"termination_wait_ms"`
+	TranscriptWaitMs  int64 `parquet:"transcript_wait_ms"`
+	PasteTimeMs       int64 `parquet:"paste_time_ms"`
+}
+
+var exportHeader = []string{
+	"timestamp", "date", "word_count", "recording_time_ms", "time_saved_ms", "speaking_rate_wpm",
+	"app", "tag", "provider", "latency_ms", "confidence", "language", "typing_speed_wpm",
+	"termination_wait_ms", "transcript_wait_ms", "paste_time_ms",
+}
+
+// ExportSessions flattens stored sessions into rows. Without all, only the
+// last 30 days are included.
+func (mm *MetricsManager) ExportSessions(all bool) ([]ExportRow, error) {
+	var days []*DailyMetrics
+	var err error
+	if all {
+		days, err = mm.storage.GetAllDailyMetrics()
+	} else {
+		days, err = mm.storage.GetRecentDays(defaultExportDays)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []ExportRow
+	for _, day := range days {
+		for _, session := range day.Sessions {
+			// Sessions recorded before the speed was stored fall back to the current one
+			typingSpeed := session.TypingSpeed
+			if typingSpeed == 0 {
+				typingSpeed = mm.userSettings.TypingSpeed
+			}
+			rows = append(rows, ExportRow{
+				Timestamp:       session.Timestamp.In(Location()).Format(time.RFC3339),
+				Date:            day.Date,
+				WordCount:       int64(session.WordCount),
+				RecordingTimeMs: session.RecordingTime.Milliseconds(),
+				TimeSavedMs:     session.TimeSaved.Milliseconds(),
+				SpeakingRate:    int64(session.SpeakingRate),
+				App:             session.App,
+				Tag:             session.Tag,
+				Provider:        session.Provider,
+				LatencyMs:       session.Latency.Milliseconds(),
+				Confidence:      session.Confidence,
+				Language:        session.Language,
+				TypingSpeed:     int64(typingSpeed),
+
+				TerminationWaitMs: session.TerminationWait.Milliseconds(),
+				TranscriptWaitMs:  session.TranscriptWait.Milliseconds(),
+				PasteTimeMs:       session.PasteTime.Milliseconds(),
+			})
+		}
+	}
+
+	return rows, nil
+}
+
+// WriteExport writes rows to w in the given format
+func WriteExport(w io.Writer, format string, rows []ExportRow) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, rows)
+	case FormatParquet:
+		return parquet.Write(w, rows)
+	default:
+		return fmt.Errorf("unknown export format %q (expected csv or parquet)", format)
+	}
+}
+
+func writeCSV(w io.Writer, rows []ExportRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportHeader); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := []string{
+			row.Timestamp,
+			row.Date,
+			strconv.FormatInt(row.WordCount, 10),
+			strconv.FormatInt(row.RecordingTimeMs, 10),
+			strconv.FormatInt(row.TimeSavedMs, 10),
+			strconv.FormatInt(row.SpeakingRate, 10),
+			row.App,
+			row.Tag,
+			row.Provider,
+			strconv.FormatInt(row.LatencyMs, 10),
+			strconv.FormatFloat(row.Confidence, 'f', -1, 64),
+			row.Language,
+			strconv.FormatInt(row.TypingSpeed, 10),
+			strconv.FormatInt(row.TerminationWaitMs, 10),
+			strconv.FormatInt(row.TranscriptWaitMs, 10),
+			strconv.FormatInt(row.PasteTimeMs, 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
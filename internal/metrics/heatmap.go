@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// heatmapShades are the cells of a text heatmap, from no sessions to the busiest
+var heatmapShades = []rune(" ░▒▓█")
+
+// Heatmap counts sessions by weekday, Monday first, and hour of the day
+type Heatmap [7][24]int
+
+// NewHeatmap buckets every session of days by the weekday and hour it started in Location
+func NewHeatmap(days []*DailyMetrics) Heatmap {
+	var heatmap Heatmap
+	for _, day := range days {
+		for _, session := range day.Sessions {
+			started := session.Timestamp.In(Location())
+			heatmap[heatmapRow(started.Weekday())][started.Hour()]++
+		}
+	}
+	return heatmap
+}
+
+// heatmapRow is the row of a weekday, with the week starting on Monday
+func heatmapRow(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+// heatmapDay is the weekday of a heatmap row
+func heatmapDay(row int) time.Weekday {
+	return time.Weekday((row + 1) % 7)
+}
+
+// Max returns the session count of the busiest cell
+func (h Heatmap) Max() int {
+	peak := 0
+	for _, hours := range h {
+		for _, count := range hours {
+			peak = max(peak, count)
+		}
+	}
+	return peak
+}
+
+// Format draws the heatmap as one row of shaded cells per weekday, two
+// characters per hour, below a header marking every third hour
+func (h Heatmap) Format() string {
+	var b strings.Builder
+
+	header := "    "
+	for hour := 0; hour < 24; hour += 3 {
+		header += fmt.Sprintf("%-6s", fmt.Sprintf("%02d", hour))
+	}
+	b.WriteString(strings.TrimRight(header, " ") + "\n")
+
+	peak := h.Max()
+	for row, hours := range h {
+		b.WriteString(heatmapDay(row).String()[:3] + " ")
+		for _, count := range hours {
+			shade := heatmapShades[0]
+			if count > 0 {
+				// Round up so any activity gets at least the lightest shade
+				levels := len(heatmapShades) - 1
+				shade = heatmapShades[(count*levels+peak-1)/peak]
+			}
+			b.WriteString(strings.Repeat(string(shade), 2))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -1,8 +1,13 @@
 package metrics
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	sessionlog "github.com/bezmoradi/t2/internal/session"
 )
 
 type SessionMetrics struct {
@@ -10,21 +15,63 @@ type SessionMetrics struct {
 	WordCount     int           `json:"word_count"`
 	RecordingTime time.Duration `json:"recording_time"`
 	TimeSaved     time.Duration `json:"time_saved"`
-	SpeakingRate  int           `json:"speaking_rate"` // WPM
+	SpeakingRate  int           `json:"speaking_rate"`          // WPM
+	Keystrokes    int           `json:"keystrokes,omitempty"`   // Characters pasted, each one a keystroke not typed
+	App           string        `json:"app,omitempty"`          // Frontmost application the text was pasted into
+	Tag           string        `json:"tag,omitempty"`          // Optional user-defined label
+	Provider      string        `json:"provider,omitempty"`     // Transcription provider
+	Latency       time.Duration `json:"latency,omitempty"`      // Key release to paste
+	Confidence    float64       `json:"confidence,omitempty"`   // Provider-reported confidence
+	Language      string        `json:"language,omitempty"`     // Detected spoken language, e.g. "de"
+	SessionID     string        `json:"session_id,omitempty"`   // Matches the session's lines in the daemon log
+	TypingSpeed   int           `json:"typing_speed,omitempty"` // WPM the time saved was calculated with
+
+	TerminationWait time.Duration `json:"termination_wait,omitempty"` // Waiting for the provider to confirm termination
+	TranscriptWait  time.Duration `json:"transcript_wait,omitempty"`  // Key release to last transcript received
+	PasteTime       time.Duration `json:"paste_time,omitempty"`       // Copying and pasting into the active app
+}
+
+// SessionDetails carries optional metadata recorded alongside a session
+type SessionDetails struct {
+	App        string
+	Tag        string
+	Provider   string
+	Latency    time.Duration
+	Confidence float64
+	Language   string
+	SessionID  string
+
+	TerminationWait time.Duration
+	TranscriptWait  time.Duration
+	PasteTime       time.Duration
 }
 
 type DailyMetrics struct {
 	Date         string           `json:"date"`
 	Sessions     []SessionMetrics `json:"sessions"`
+	Skipped      []SkippedSession `json:"skipped,omitempty"`
 	TotalWords   int              `json:"total_words"`
 	TotalSaved   time.Duration    `json:"total_saved"`
 	SessionCount int              `json:"session_count"`
+
+	TotalKeystrokes int `json:"total_keystrokes"`
+	SchemaVersion   int `json:"schema_version,omitempty"`
 }
 
 type UserSettings struct {
-	TypingSpeed int `json:"typing_speed"` // User's actual WPM for personalized calculations
+	TypingSpeed int    `json:"typing_speed"`          // User's actual WPM for personalized calculations
+	GoalTarget  int    `json:"goal_target,omitempty"` // Daily goal, 0 when unset
+	GoalUnit    string `json:"goal_unit,omitempty"`   // GoalWords or GoalSessions
+
+	SchemaVersion int `json:"schema_version,omitempty"`
 }
 
+// Units a daily goal can be measured in
+const (
+	GoalWords    = "words"
+	GoalSessions = "sessions"
+)
+
 type MetricsManager struct {
 	storage      *Storage
 	userSettings *UserSettings
@@ -50,28 +97,42 @@ func NewMetricsManager(storagePath string) (*MetricsManager, error) {
 	}, nil
 }
 
-func (mm *MetricsManager) RecordSession(transcript string, recordingTime time.Duration) (*SessionMetrics, error) {
+func (mm *MetricsManager) RecordSession(transcript string, recordingTime time.Duration, details SessionDetails) (*SessionMetrics, error) {
 	wordCount := countWords(transcript)
 	speakingRate := calculateSpeakingRate(wordCount, recordingTime)
 	timeSaved := mm.calculateTimeSaved(wordCount, recordingTime)
 
 	session := &SessionMetrics{
-		Timestamp:     time.Now(),
+		Timestamp:     time.Now().UTC(),
 		WordCount:     wordCount,
 		RecordingTime: recordingTime,
 		TimeSaved:     timeSaved,
 		SpeakingRate:  speakingRate,
+		Keystrokes:    utf8.RuneCountInString(strings.TrimSpace(transcript)),
+		App:           details.App,
+		Tag:           details.Tag,
+		Provider:      details.Provider,
+		Latency:       details.Latency,
+		Confidence:    details.Confidence,
+		Language:      details.Language,
+		SessionID:     details.SessionID,
+		TypingSpeed:   mm.userSettings.TypingSpeed,
+
+		TerminationWait: details.TerminationWait,
+		TranscriptWait:  details.TranscriptWait,
+		PasteTime:       details.PasteTime,
 	}
 
 	if err := mm.storage.SaveSession(session); err != nil {
 		return session, err
 	}
+	sessionlog.Printf(details.SessionID, "[METRICS] Recorded %d words (%s saved, %d WPM)", wordCount, timeSaved.Round(time.Second), speakingRate)
 
 	return session, nil
 }
 
 func (mm *MetricsManager) GetTodayMetrics() (*DailyMetrics, error) {
-	today := time.Now().Format("2006-01-02")
+	today := localNow().Format("2006-01-02")
 	return mm.storage.GetDailyMetrics(today)
 }
 
@@ -84,18 +145,170 @@ func (mm *MetricsManager) SetTypingSpeed(wpm int) error {
 	return mm.storage.SaveUserSettings(mm.userSettings)
 }
 
+// ReloadSettings re-reads user settings changed by another t2 process
+func (mm *MetricsManager) ReloadSettings() error {
+	userSettings, err := mm.storage.LoadUserSettings()
+	if err != nil {
+		return err
+	}
+	mm.userSettings = userSettings
+	return nil
+}
+
 func (mm *MetricsManager) GetTypingSpeed() int {
 	return mm.userSettings.TypingSpeed
 }
 
+func (mm *MetricsManager) SetGoal(target int, unit string) error {
+	mm.userSettings.GoalTarget = target
+	mm.userSettings.GoalUnit = unit
+	return mm.storage.SaveUserSettings(mm.userSettings)
+}
+
+func (mm *MetricsManager) ClearGoal() error {
+	return mm.SetGoal(0, "")
+}
+
+func (mm *MetricsManager) GetGoal() (int, string) {
+	return mm.userSettings.GoalTarget, mm.userSettings.GoalUnit
+}
+
+// GetStreak counts consecutive active days ending today. A streak that ended
+// yesterday still counts so it doesn't look broken before the first session of the day.
+func (mm *MetricsManager) GetStreak() (int, error) {
+	day := localNow()
+
+	today, err := mm.storage.GetDailyMetrics(day.Format("2006-01-02"))
+	if err != nil {
+		return 0, err
+	}
+	if today.SessionCount == 0 {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	streak := 0
+	for {
+		dailyMetrics, err := mm.storage.GetDailyMetrics(day.Format("2006-01-02"))
+		if err != nil || dailyMetrics.SessionCount == 0 {
+			return streak, nil
+		}
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+}
+
 func (mm *MetricsManager) GetRecentDays(days int) ([]*DailyMetrics, error) {
 	return mm.storage.GetRecentDays(days)
 }
 
+// Stats periods supported by GetPeriodMetrics
+const (
+	PeriodMonth = "month"
+	PeriodYear  = "year"
+)
+
+// GetPeriodMetrics returns daily metrics for the current month or year so far,
+// plus the same span of the previous period for trend comparison
+func (mm *MetricsManager) GetPeriodMetrics(period string) ([]*DailyMetrics, []*DailyMetrics, error) {
+	now := localNow()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// Compare against the same number of days into the previous period so a
+	// half-finished month isn't measured against a full one
+	var start, prevStart, prevEnd time.Time
+	switch period {
+	case PeriodMonth:
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		prevStart = start.AddDate(0, -1, 0)
+		prevEnd = prevStart.AddDate(0, 0, now.Day()-1)
+	case PeriodYear:
+		start = time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+		prevStart = start.AddDate(-1, 0, 0)
+		prevEnd = prevStart.AddDate(0, 0, now.YearDay()-1)
+	default:
+		return nil, nil, fmt.Errorf("unknown period %q (expected month or year)", period)
+	}
+	if lastPrevDay := start.AddDate(0, 0, -1); prevEnd.After(lastPrevDay) {
+		prevEnd = lastPrevDay
+	}
+
+	current, err := mm.storage.GetDateRange(start, today)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previous, err := mm.storage.GetDateRange(prevStart, prevEnd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return current, previous, nil
+}
+
+// GetMonthMetrics returns daily metrics for every day of the month that
+// contains month, up to today if it's the current month
+func (mm *MetricsManager) GetMonthMetrics(month time.Time) ([]*DailyMetrics, error) {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, Location())
+	end := start.AddDate(0, 1, -1)
+
+	now := localNow()
+	if today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, Location()); end.After(today) {
+		end = today
+	}
+	return mm.storage.GetDateRange(start, end)
+}
+
+// GetRecoveries returns the damaged statistics files that were set aside
+func (mm *MetricsManager) GetRecoveries() ([]Recovery, error) {
+	return mm.storage.GetRecoveries()
+}
+
+// CheckFiles reads every statistics file, recovering damaged ones, and
+// describes the ones that still can't be read
+func (mm *MetricsManager) CheckFiles() []string {
+	return mm.storage.CheckFiles()
+}
+
+// GetLatencyStats computes latency percentiles across every session that recorded timings
+func (mm *MetricsManager) GetLatencyStats() (*LatencyStats, error) {
+	days, err := mm.storage.GetAllDailyMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	var recording, termination, transcript, paste, total []time.Duration
+	for _, day := range days {
+		for _, session := range day.Sessions {
+			if session.Latency == 0 {
+				continue // Recorded before latency tracking existed
+			}
+			recording = append(recording, session.RecordingTime)
+			termination = append(termination, session.TerminationWait)
+			transcript = append(transcript, session.TranscriptWait)
+			paste = append(paste, session.PasteTime)
+			total = append(total, session.Latency)
+		}
+	}
+
+	return &LatencyStats{
+		Sessions:        len(total),
+		RecordingTime:   newPercentiles(recording),
+		TerminationWait: newPercentiles(termination),
+		TranscriptWait:  newPercentiles(transcript),
+		PasteTime:       newPercentiles(paste),
+		Total:           newPercentiles(total),
+	}, nil
+}
+
 func (mm *MetricsManager) ClearAllMetrics() error {
 	return mm.storage.ClearAllMetrics()
 }
 
+// PurgeBefore deletes the metrics of days before cutoff's day
+func (mm *MetricsManager) PurgeBefore(cutoff time.Time) (int, error) {
+	return mm.storage.PurgeBefore(cutoff)
+}
+
 func (mm *MetricsManager) calculateTimeSaved(wordCount int, recordingTime time.Duration) time.Duration {
 	if wordCount == 0 {
 		return 0
@@ -110,6 +323,18 @@ func (mm *MetricsManager) calculateTimeSaved(wordCount int, recordingTime time.D
 	return max(timeSaved, 0)
 }
 
+// avgKeystrokesPerWord estimates keystrokes for sessions recorded before
+// Keystrokes was: an average English word plus the space after it
+const avgKeystrokesPerWord = 6
+
+// KeystrokesAvoided is how many keys the session saved typing
+func (s SessionMetrics) KeystrokesAvoided() int {
+	if s.Keystrokes > 0 {
+		return s.Keystrokes
+	}
+	return s.WordCount * avgKeystrokesPerWord
+}
+
 func countWords(text string) int {
 	if text == "" {
 		return 0
@@ -136,6 +361,41 @@ type TotalMetrics struct {
 	TotalWords         int           `json:"total_words"`
 	TotalSessions      int           `json:"total_sessions"`
 	TotalSaved         time.Duration `json:"total_saved"`
+	TotalKeystrokes    int           `json:"total_keystrokes"`
 	AvgWordsPerSession int           `json:"avg_words_per_session"`
 	AvgSavedPerSession time.Duration `json:"avg_saved_per_session"`
 }
+
+// Percentiles summarizes a latency distribution
+type Percentiles struct {
+	P50 time.Duration `json:"p50"`
+	P95 time.Duration `json:"p95"`
+}
+
+type LatencyStats struct {
+	Sessions        int         `json:"sessions"`
+	RecordingTime   Percentiles `json:"recording_time"`
+	TerminationWait Percentiles `json:"termination_wait"`
+	TranscriptWait  Percentiles `json:"transcript_wait"`
+	PasteTime       Percentiles `json:"paste_time"`
+	Total           Percentiles `json:"total"` // Key release to paste
+}
+
+func newPercentiles(values []time.Duration) Percentiles {
+	if len(values) == 0 {
+		return Percentiles{}
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return Percentiles{
+		P50: percentile(sorted, 50),
+		P95: percentile(sorted, 95),
+	}
+}
+
+// percentile uses the nearest-rank method on an already sorted slice
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank-1, 0)]
+}
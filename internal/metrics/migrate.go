@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// errNotObject is returned for a stored file holding JSON null, which
+// decodes without error but has no fields to read
+var errNotObject = errors.New("the file doesn't hold a JSON object")
+
+// migration upgrades a stored file's fields from one schema version to the
+// next, e.g. renaming a field or changing its unit
+type migration func(fields map[string]any) error
+
+// Migrations of each kind of file; migrations[i] upgrades version i to i+1,
+// so the current schema version is the number of migrations. Files written
+// before schema_version existed are version 0.
+var (
+	dailyMigrations = []migration{
+		stampVersion, // 1: Added schema_version
+	}
+	usageMigrations = []migration{
+		stampVersion, // 1: Added schema_version
+	}
+	settingsMigrations = []migration{
+		stampVersion, // 1: Added schema_version
+	}
+)
+
+// Current schema versions, written into every file saved
+var (
+	dailySchemaVersion    = len(dailyMigrations)
+	usageSchemaVersion    = len(usageMigrations)
+	settingsSchemaVersion = len(settingsMigrations)
+)
+
+// stampVersion is the migration of a version that only added schema_version
+func stampVersion(fields map[string]any) error {
+	return nil
+}
+
+// migrate upgrades a stored file to the current schema before it's
+// unmarshaled. A file from a newer T2, e.g. on another Mac sharing
+// metrics_dir, is an error rather than being misread.
+func migrate(data []byte, migrations []migration) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // Keep nanosecond durations exact
+
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, errNotObject
+	}
+	// Anything after the object is rejected like json.Unmarshal does, so
+	// files of every version fail the same way
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, json.Unmarshal(data, new(any))
+	}
+
+	version := 0
+	if number, ok := fields["schema_version"].(json.Number); ok {
+		v, err := number.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema_version %s", number)
+		}
+		version = int(v)
+	}
+
+	switch {
+	case version > len(migrations):
+		return nil, fmt.Errorf("written by a newer version of T2 (schema version %d, this version reads up to %d)", version, len(migrations))
+	case version == len(migrations):
+		return data, nil
+	}
+
+	for i := version; i < len(migrations); i++ {
+		if err := migrations[i](fields); err != nil {
+			return nil, fmt.Errorf("failed to migrate from schema version %d: %v", i, err)
+		}
+	}
+	fields["schema_version"] = len(migrations)
+	return json.Marshal(fields)
+}
@@ -0,0 +1,329 @@
+package metrics
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+// Month report formats supported by WriteMonthReport
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+)
+
+// SVG chart dimensions of the HTML report
+const (
+	chartWidth   = 720
+	chartHeight  = 220
+	chartPadding = 40
+)
+
+// monthDay is one day of a month report
+type monthDay struct {
+	Label        string // "Jun 03"
+	Words        int
+	Saved        time.Duration
+	SpeakingRate int // WPM over the day's recordings, 0 without sessions
+}
+
+// monthSummary is the totals of a month report
+type monthSummary struct {
+	Title        string
+	Days         []monthDay
+	ActiveDays   int
+	Words        int
+	Sessions     int
+	Saved        time.Duration
+	Keystrokes   int
+	SpeakingRate int
+	BusiestDay   string
+	BusiestWords int
+	Heatmap      Heatmap
+}
+
+// summarizeMonth works out the per-day series and totals of a month report
+func summarizeMonth(month time.Time, days []*DailyMetrics) monthSummary {
+	summary := monthSummary{Title: "T2 Report: " + month.Format("January 2006"), Heatmap: NewHeatmap(days)}
+
+	var totalRecording time.Duration
+	for _, day := range days {
+		date, err := time.Parse("2006-01-02", day.Date)
+		if err != nil {
+			continue
+		}
+
+		var recording time.Duration
+		for _, session := range day.Sessions {
+			recording += session.RecordingTime
+		}
+		reportDay := monthDay{Label: date.Format("Jan 02"), Words: day.TotalWords, Saved: day.TotalSaved}
+		if recording > 0 {
+			reportDay.SpeakingRate = int(float64(day.TotalWords) / recording.Minutes())
+		}
+		summary.Days = append(summary.Days, reportDay)
+
+		if day.SessionCount > 0 {
+			summary.ActiveDays++
+		}
+		summary.Words += day.TotalWords
+		summary.Sessions += day.SessionCount
+		summary.Saved += day.TotalSaved
+		summary.Keystrokes += day.TotalKeystrokes
+		totalRecording += recording
+		if day.TotalWords > summary.BusiestWords {
+			summary.BusiestDay, summary.BusiestWords = reportDay.Label, day.TotalWords
+		}
+	}
+	if totalRecording > 0 {
+		summary.SpeakingRate = int(float64(summary.Words) / totalRecording.Minutes())
+	}
+
+	return summary
+}
+
+// WriteMonthReport renders a month of statistics with charts of words per
+// day, time saved, the speaking rate trend and a weekday-by-hour heatmap, as
+// a standalone HTML page or a Markdown file
+func WriteMonthReport(w io.Writer, format string, month time.Time, days []*DailyMetrics) error {
+	summary := summarizeMonth(month, days)
+
+	var report string
+	switch format {
+	case FormatHTML:
+		report = renderHTMLReport(summary)
+	case FormatMarkdown:
+		report = renderMarkdownReport(summary)
+	default:
+		return fmt.Errorf("unknown report format %q (expected html or markdown)", format)
+	}
+
+	_, err := io.WriteString(w, report)
+	return err
+}
+
+// summaryRows are the label/value pairs at the top of both report formats
+func summaryRows(summary monthSummary) [][2]string {
+	formatter := NewTimeFormatter()
+	rows := [][2]string{
+		{"Active days", fmt.Sprintf("%d/%d", summary.ActiveDays, len(summary.Days))},
+		{"Total words", fmt.Sprintf("%d", summary.Words)},
+		{"Sessions", fmt.Sprintf("%d", summary.Sessions)},
+		{"Time saved", formatter.FormatDuration(summary.Saved)},
+		{"Keystrokes avoided", fmt.Sprintf("%d", summary.Keystrokes)},
+	}
+	if summary.SpeakingRate > 0 {
+		rows = append(rows, [2]string{"Speaking rate", fmt.Sprintf("%d WPM", summary.SpeakingRate)})
+	}
+	if summary.BusiestDay != "" {
+		rows = append(rows, [2]string{"Busiest day", fmt.Sprintf("%s (%d words)", summary.BusiestDay, summary.BusiestWords)})
+	}
+	return rows
+}
+
+// renderMarkdownReport draws the charts as text bars in code blocks
+func renderMarkdownReport(summary monthSummary) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", summary.Title)
+
+	b.WriteString("| | |\n|---|---|\n")
+	for _, row := range summaryRows(summary) {
+		fmt.Fprintf(&b, "| %s | %s |\n", row[0], row[1])
+	}
+
+	maxWords, maxSaved, maxRate := 0, 0, 0
+	for _, day := range summary.Days {
+		maxWords = max(maxWords, day.Words)
+		maxSaved = max(maxSaved, int(day.Saved.Seconds()))
+		maxRate = max(maxRate, day.SpeakingRate)
+	}
+
+	formatter := NewTimeFormatter()
+	chart := func(title string, value func(monthDay) (int, string), maxValue int) {
+		fmt.Fprintf(&b, "\n## %s\n\n```\n", title)
+		for _, day := range summary.Days {
+			v, label := value(day)
+			fmt.Fprintf(&b, "%s │%-*s %s\n", day.Label, barWidth, bar(v, maxValue), label)
+		}
+		b.WriteString("```\n")
+	}
+	chart("Words per day", func(day monthDay) (int, string) {
+		return day.Words, fmt.Sprintf("%d", day.Words)
+	}, maxWords)
+	chart("Time saved per day", func(day monthDay) (int, string) {
+		return int(day.Saved.Seconds()), formatter.FormatDurationShort(day.Saved)
+	}, maxSaved)
+	chart("Speaking rate (WPM)", func(day monthDay) (int, string) {
+		if day.SpeakingRate == 0 {
+			return 0, "-"
+		}
+		return day.SpeakingRate, fmt.Sprintf("%d", day.SpeakingRate)
+	}, maxRate)
+
+	fmt.Fprintf(&b, "\n## Sessions by weekday and hour\n\n```\n%s```\n", summary.Heatmap.Format())
+
+	return b.String()
+}
+
+// renderHTMLReport draws the charts as inline SVG, so the page needs nothing else to display
+func renderHTMLReport(summary monthSummary) string {
+	var b strings.Builder
+	title := html.EscapeString(summary.Title)
+	fmt.Fprintf(&b, `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Helvetica Neue", sans-serif; max-width: 800px; margin: 2em auto; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+td { padding: 4px 16px 4px 0; }
+td:first-child { color: #666; }
+svg { display: block; margin-bottom: 2em; }
+svg text { font-size: 10px; fill: #666; }
+</style>
+</head>
+<body>
+<h1>%s</h1>
+<table>
+`, title, title)
+	for _, row := range summaryRows(summary) {
+		fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td></tr>\n", html.EscapeString(row[0]), html.EscapeString(row[1]))
+	}
+	b.WriteString("</table>\n")
+
+	words := make([]float64, len(summary.Days))
+	saved := make([]float64, len(summary.Days))
+	rates := make([]float64, len(summary.Days))
+	for i, day := range summary.Days {
+		words[i] = float64(day.Words)
+		saved[i] = day.Saved.Minutes()
+		rates[i] = float64(day.SpeakingRate)
+	}
+
+	b.WriteString("<h2>Words per day</h2>\n")
+	b.WriteString(svgBarChart(summary.Days, words, "#4a90d9"))
+	b.WriteString("<h2>Time saved per day (minutes)</h2>\n")
+	b.WriteString(svgBarChart(summary.Days, saved, "#5cb85c"))
+	b.WriteString("<h2>Speaking rate (WPM)</h2>\n")
+	b.WriteString(svgLineChart(summary.Days, rates, "#d9534f"))
+	b.WriteString("<h2>Sessions by weekday and hour</h2>\n")
+	b.WriteString(svgHeatmap(summary.Heatmap, "#4a90d9"))
+
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// chartScale returns the largest value, so charts of empty months don't divide by zero
+func chartScale(values []float64) float64 {
+	maxValue := 0.0
+	for _, value := range values {
+		maxValue = max(maxValue, value)
+	}
+	if maxValue == 0 {
+		return 1
+	}
+	return maxValue
+}
+
+// chartAxes draws the frame, the maximum value and every few day labels
+func chartAxes(b *strings.Builder, days []monthDay, maxValue float64) {
+	plotHeight := chartHeight - 2*chartPadding
+	fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#ccc"/>`+"\n",
+		chartPadding, chartHeight-chartPadding, chartWidth-chartPadding, chartHeight-chartPadding)
+	fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="end">%.0f</text>`+"\n", chartPadding-4, chartPadding+4, maxValue)
+	fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="end">0</text>`+"\n", chartPadding-4, chartPadding+plotHeight)
+
+	step := float64(chartWidth-2*chartPadding) / float64(max(len(days), 1))
+	for i, day := range days {
+		if i%5 != 0 {
+			continue
+		}
+		x := float64(chartPadding) + step*(float64(i)+0.5)
+		fmt.Fprintf(b, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n", x, chartHeight-chartPadding+14, html.EscapeString(day.Label))
+	}
+}
+
+// svgBarChart draws one bar per day
+func svgBarChart(days []monthDay, values []float64, color string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg width="%d" height="%d" xmlns="http://www.w3.org/2000/svg">`+"\n", chartWidth, chartHeight)
+
+	maxValue := chartScale(values)
+	chartAxes(&b, days, maxValue)
+
+	plotHeight := float64(chartHeight - 2*chartPadding)
+	step := float64(chartWidth-2*chartPadding) / float64(max(len(days), 1))
+	for i, value := range values {
+		height := value / maxValue * plotHeight
+		x := float64(chartPadding) + step*float64(i) + step*0.15
+		y := float64(chartHeight-chartPadding) - height
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %.0f</title></rect>`+"\n",
+			x, y, step*0.7, height, color, html.EscapeString(days[i].Label), value)
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// svgLineChart connects the days that have a value, skipping days without sessions
+func svgLineChart(days []monthDay, values []float64, color string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg width="%d" height="%d" xmlns="http://www.w3.org/2000/svg">`+"\n", chartWidth, chartHeight)
+
+	maxValue := chartScale(values)
+	chartAxes(&b, days, maxValue)
+
+	plotHeight := float64(chartHeight - 2*chartPadding)
+	step := float64(chartWidth-2*chartPadding) / float64(max(len(days), 1))
+	var points []string
+	for i, value := range values {
+		if value == 0 {
+			continue
+		}
+		x := float64(chartPadding) + step*(float64(i)+0.5)
+		y := float64(chartHeight-chartPadding) - value/maxValue*plotHeight
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+		fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%s: %.0f WPM</title></circle>`+"\n",
+			x, y, color, html.EscapeString(days[i].Label), value)
+	}
+	if len(points) > 1 {
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n", strings.Join(points, " "), color)
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// svgHeatmap draws one cell per weekday and hour, darker the more sessions started then
+func svgHeatmap(heatmap Heatmap, color string) string {
+	const cellSize = 24
+
+	var b strings.Builder
+	width := chartPadding + 24*cellSize
+	height := 7*cellSize + 20
+	fmt.Fprintf(&b, `<svg width="%d" height="%d" xmlns="http://www.w3.org/2000/svg">`+"\n", width, height)
+
+	for hour := 0; hour < 24; hour += 3 {
+		fmt.Fprintf(&b, `<text x="%d" y="12">%02d</text>`+"\n", chartPadding+hour*cellSize, hour)
+	}
+
+	peak := float64(max(heatmap.Max(), 1))
+	for row, hours := range heatmap {
+		y := 20 + row*cellSize
+		fmt.Fprintf(&b, `<text x="0" y="%d">%s</text>`+"\n", y+cellSize/2+4, heatmapDay(row).String()[:3])
+		for hour, count := range hours {
+			opacity := 0.05
+			if count > 0 {
+				opacity = 0.2 + 0.8*float64(count)/peak
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" fill-opacity="%.2f"><title>%s %02d:00: %d sessions</title></rect>`+"\n",
+				chartPadding+hour*cellSize, y, cellSize-2, cellSize-2, color, opacity, heatmapDay(row), hour, count)
+		}
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
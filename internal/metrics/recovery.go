@@ -0,0 +1,254 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// recoveriesFile lists the damaged files this Mac set aside, per Mac like the daily files
+	recoveriesFile = "recoveries"
+
+	// backupExt is added to a damaged file's name when it's set aside
+	backupExt = ".bak"
+)
+
+// Recovery records a damaged file that was set aside and what was salvaged from it
+type Recovery struct {
+	Time      time.Time `json:"time"`
+	File      string    `json:"file"`   // Relative to the metrics directory
+	Backup    string    `json:"backup"` // Where the damaged original was kept
+	Error     string    `json:"error"`
+	Sessions  int       `json:"sessions"`   // Sessions salvaged
+	Skips     int       `json:"skips"`      // Skipped sessions salvaged
+	LostBytes int       `json:"lost_bytes"` // Bytes from the damage on that couldn't be read
+}
+
+// errOtherMacDamage is returned for a damaged file another Mac writes. Only
+// the Mac that owns a file sets it aside: the file may just be mid-sync, and
+// rewriting it here would turn into a sync conflict.
+var errOtherMacDamage = errors.New("written by another Mac, run t2 doctor there")
+
+func otherMacDamage(filePath string, cause error) error {
+	return fmt.Errorf("%s: damaged (%s), %w", filepath.Base(filePath), describeDamage(cause), errOtherMacDamage)
+}
+
+// isCorrupt reports whether err means a file's JSON is damaged, as opposed
+// to unreadable or from a newer version of T2
+func isCorrupt(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.Is(err, errNotObject) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// describeDamage explains a corruption error in words for t2 doctor
+func describeDamage(err error) string {
+	switch {
+	case errors.Is(err, io.EOF):
+		return "the file is empty"
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return "the file ends early"
+	default:
+		return err.Error()
+	}
+}
+
+// recoverDailyMetrics sets a damaged daily file aside, salvages the sessions
+// and skips before the damage into a new file in its place and records what
+// was lost for t2 doctor
+func (s *Storage) recoverDailyMetrics(filePath string, data []byte, cause error) (*DailyMetrics, error) {
+	salvaged, readBytes := salvageDailyMetrics(data)
+	if salvaged.Date == "" {
+		salvaged.Date, _ = fileDate(filepath.Base(filePath))
+	}
+	salvaged.updateTotals()
+
+	backup, err := s.setAside(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("%s is damaged (%v) and couldn't be set aside: %v", filepath.Base(filePath), cause, err)
+	}
+
+	salvaged.SchemaVersion = dailySchemaVersion
+	recovered, err := json.MarshalIndent(salvaged, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile(filePath, recovered); err != nil {
+		return nil, fmt.Errorf("failed to write recovered %s: %v", filepath.Base(filePath), err)
+	}
+
+	err = s.addRecovery(Recovery{
+		Time:      time.Now().UTC(),
+		File:      s.relative(filePath),
+		Backup:    s.relative(backup),
+		Error:     describeDamage(cause),
+		Sessions:  len(salvaged.Sessions),
+		Skips:     len(salvaged.Skipped),
+		LostBytes: len(data) - readBytes,
+	})
+	return salvaged, err
+}
+
+// recoverFile sets aside a damaged file nothing can be salvaged from, such as
+// a month's usage, and records it for t2 doctor
+func (s *Storage) recoverFile(filePath string, data []byte, cause error) error {
+	backup, err := s.setAside(filePath)
+	if err != nil {
+		return fmt.Errorf("%s is damaged (%v) and couldn't be set aside: %v", filepath.Base(filePath), cause, err)
+	}
+	return s.addRecovery(Recovery{
+		Time:      time.Now().UTC(),
+		File:      s.relative(filePath),
+		Backup:    s.relative(backup),
+		Error:     describeDamage(cause),
+		LostBytes: len(data),
+	})
+}
+
+// salvageDailyMetrics decodes a damaged daily file up to the damage, keeping
+// every whole session and skip before it. It returns how many bytes it read.
+func salvageDailyMetrics(data []byte) (*DailyMetrics, int) {
+	salvaged := &DailyMetrics{Sessions: []SessionMetrics{}}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return salvaged, 0
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		// Totals are recomputed from the sessions, anything else is skipped
+		var ok bool
+		switch token {
+		case "date":
+			ok = decoder.Decode(&salvaged.Date) == nil
+		case "sessions":
+			ok = salvageArray(decoder, &salvaged.Sessions)
+		case "skipped":
+			ok = salvageArray(decoder, &salvaged.Skipped)
+		default:
+			var skipped json.RawMessage
+			ok = decoder.Decode(&skipped) == nil
+		}
+		if !ok {
+			break
+		}
+	}
+
+	return salvaged, int(decoder.InputOffset())
+}
+
+// salvageArray appends the array's elements to items up to the first one that
+// doesn't decode, returning false if it stopped early
+func salvageArray[T any](decoder *json.Decoder, items *[]T) bool {
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return false
+	}
+	for decoder.More() {
+		var item T
+		if err := decoder.Decode(&item); err != nil {
+			return false
+		}
+		*items = append(*items, item)
+	}
+	_, err := decoder.Token()
+	return err == nil
+}
+
+// setAside renames a damaged file to <name>.bak, or <name>.2.bak and so on if
+// an earlier backup is in the way, so it's no longer read but can be inspected
+func (s *Storage) setAside(filePath string) (string, error) {
+	backup := filePath + backupExt
+	for i := 2; ; i++ {
+		if _, err := os.Stat(backup); os.IsNotExist(err) {
+			break
+		}
+		backup = fmt.Sprintf("%s.%d%s", filePath, i, backupExt)
+	}
+	return backup, os.Rename(filePath, backup)
+}
+
+func (s *Storage) relative(filePath string) string {
+	if relative, err := filepath.Rel(s.baseDir, filePath); err == nil {
+		return relative
+	}
+	return filePath
+}
+
+// addRecovery appends to this Mac's list of recoveries
+func (s *Storage) addRecovery(recovery Recovery) error {
+	filePath := s.ownFile("", recoveriesFile)
+
+	var recoveries []Recovery
+	if data, err := os.ReadFile(filePath); err == nil {
+		json.Unmarshal(data, &recoveries) // Start over if the list itself is damaged
+	}
+	recoveries = append(recoveries, recovery)
+
+	data, err := json.MarshalIndent(recoveries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(filePath, data)
+}
+
+// GetRecoveries returns the damaged files every Mac set aside, oldest first
+func (s *Storage) GetRecoveries() ([]Recovery, error) {
+	var all []Recovery
+	for _, filePath := range s.filesFor("", recoveriesFile) {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		var recoveries []Recovery
+		if err := json.Unmarshal(data, &recoveries); err != nil {
+			return nil, fmt.Errorf("%s: %v", filepath.Base(filePath), err)
+		}
+		all = append(all, recoveries...)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Time.Before(all[j].Time)
+	})
+	return all, nil
+}
+
+// CheckFiles reads every daily and usage file, recovering this Mac's damaged
+// ones, and returns the files that still can't be read, e.g. ones from a
+// newer T2 or damaged ones of another Mac
+func (s *Storage) CheckFiles() []string {
+	var problems []string
+	for _, dir := range []string{dailyMetricsDir, usageDir} {
+		entries, err := os.ReadDir(filepath.Join(s.baseDir, dir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+				continue
+			}
+
+			filePath := filepath.Join(s.baseDir, dir, entry.Name())
+			if dir == dailyMetricsDir {
+				_, err = s.readDailyMetrics(filePath)
+			} else {
+				_, err = s.readMonthlyUsage(filePath)
+			}
+			if err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+	return problems
+}
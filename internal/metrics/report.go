@@ -0,0 +1,64 @@
+package metrics
+
+// reportRecentDays is how many days of history a Report includes
+const reportRecentDays = 7
+
+// Report is a machine-readable summary of all statistics, shared by
+// `t2 --stats --json` and the daemon's /stats endpoint. Durations are in nanoseconds.
+type Report struct {
+	Total       *TotalMetrics   `json:"total"`
+	RecentDays  []*DailyMetrics `json:"recent_days"`
+	Latency     *LatencyStats   `json:"latency,omitempty"`
+	Skips       *SkipStats      `json:"skips,omitempty"`
+	Usage       *MonthlyUsage   `json:"usage,omitempty"`
+	Streak      int             `json:"streak"`
+	GoalTarget  int             `json:"goal_target,omitempty"`
+	GoalUnit    string          `json:"goal_unit,omitempty"`
+	TypingSpeed int             `json:"typing_speed"`
+}
+
+// GetReport gathers every statistic into a Report
+func (mm *MetricsManager) GetReport() (*Report, error) {
+	totalMetrics, err := mm.GetTotalMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	recentDays, err := mm.GetRecentDays(reportRecentDays)
+	if err != nil {
+		return nil, err
+	}
+
+	latencyStats, err := mm.GetLatencyStats()
+	if err != nil {
+		return nil, err
+	}
+
+	skipStats, err := mm.GetSkipStats()
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := mm.GetMonthlyUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	streak, err := mm.GetStreak()
+	if err != nil {
+		return nil, err
+	}
+
+	goalTarget, goalUnit := mm.GetGoal()
+	return &Report{
+		Total:       totalMetrics,
+		RecentDays:  recentDays,
+		Latency:     latencyStats,
+		Skips:       skipStats,
+		Usage:       usage,
+		Streak:      streak,
+		GoalTarget:  goalTarget,
+		GoalUnit:    goalUnit,
+		TypingSpeed: mm.GetTypingSpeed(),
+	}, nil
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/i18n"
+)
+
+// SkippedSession is a recording that ended without anything being transcribed
+type SkippedSession struct {
+	Timestamp     time.Time     `json:"timestamp"`
+	Reason        string        `json:"reason"`                   // One of SkipReasons
+	RecordingTime time.Duration `json:"recording_time,omitempty"` // Press to release
+	MaxRMS        float64       `json:"max_rms,omitempty"`        // Loudest chunk, compared against the silence threshold
+	Threshold     float64       `json:"threshold,omitempty"`      // Silence threshold in effect for the recording
+	SessionID     string        `json:"session_id,omitempty"`     // Matches the session's lines in the daemon log
+}
+
+// SkipReasonStats summarizes the skips for one reason
+type SkipReasonStats struct {
+	Count         int           `json:"count"`
+	RecordingTime time.Duration `json:"recording_time"` // Median
+	MaxRMS        float64       `json:"max_rms"`        // Median
+	Threshold     float64       `json:"threshold"`      // Median silence threshold, 0 if none was recorded
+}
+
+// SkipStats breaks skipped sessions down by reason
+type SkipStats struct {
+	Skipped   int                         `json:"skipped"`
+	Completed int                         `json:"completed"` // Sessions that were delivered, for the skip rate
+	Reasons   map[string]*SkipReasonStats `json:"reasons"`
+}
+
+// skipReasonLabels are the --stats labels for SkipReasons
+var skipReasonLabels = map[string]string{
+	SkipQuickPress: "Quick press",
+	SkipSilence:    "Silence",
+	SkipLowAudio:   "Low audio level",
+	SkipConnection: "Connection failure",
+}
+
+// RecordSkip saves a skipped session with its reason
+func (mm *MetricsManager) RecordSkip(reason string, recordingTime time.Duration, maxRMS, threshold float64, sessionID string) error {
+	return mm.storage.SaveSkip(&SkippedSession{
+		Timestamp:     time.Now().UTC(),
+		Reason:        reason,
+		RecordingTime: recordingTime,
+		MaxRMS:        maxRMS,
+		Threshold:     threshold,
+		SessionID:     sessionID,
+	})
+}
+
+// GetSkipStats counts skipped sessions by reason across all recorded days
+func (mm *MetricsManager) GetSkipStats() (*SkipStats, error) {
+	days, err := mm.storage.GetAllDailyMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &SkipStats{Reasons: make(map[string]*SkipReasonStats)}
+	recordingTimes := make(map[string][]time.Duration)
+	levels := make(map[string][]float64)
+	thresholds := make(map[string][]float64)
+	for _, day := range days {
+		stats.Completed += day.SessionCount
+		for _, skip := range day.Skipped {
+			stats.Skipped++
+			recordingTimes[skip.Reason] = append(recordingTimes[skip.Reason], skip.RecordingTime)
+			levels[skip.Reason] = append(levels[skip.Reason], skip.MaxRMS)
+			// Skips recorded before thresholds were stored don't have one
+			if skip.Threshold > 0 {
+				thresholds[skip.Reason] = append(thresholds[skip.Reason], skip.Threshold)
+			}
+		}
+	}
+
+	for reason, times := range recordingTimes {
+		stats.Reasons[reason] = &SkipReasonStats{
+			Count:         len(times),
+			RecordingTime: newPercentiles(times).P50,
+			MaxRMS:        median(levels[reason]),
+			Threshold:     median(thresholds[reason]),
+		}
+	}
+	return stats, nil
+}
+
+// median returns the nearest-rank median of values
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return sorted[(len(sorted)-1)/2]
+}
+
+func (sf *StatsFormatter) FormatSkipStats(skips *SkipStats) string {
+	if skips.Skipped == 0 {
+		return i18n.T("🚫 No skipped sessions.")
+	}
+
+	stats := fmt.Sprintf(i18n.T("🚫 Skipped Sessions: %d of %d (%.0f%%)\n"),
+		skips.Skipped, skips.Skipped+skips.Completed, float64(skips.Skipped)*100/float64(skips.Skipped+skips.Completed))
+	for _, reason := range SkipReasons {
+		reasonStats, ok := skips.Reasons[reason]
+		if !ok {
+			continue
+		}
+		label := fmt.Sprintf("%-20s", i18n.T(skipReasonLabels[reason])+":")
+		switch reason {
+		case SkipQuickPress:
+			stats += fmt.Sprintf(i18n.T("   %s %d (median press %s)\n"), label, reasonStats.Count, sf.timeFormatter.FormatDurationShort(reasonStats.RecordingTime))
+		case SkipSilence, SkipLowAudio:
+			if reasonStats.Threshold > 0 {
+				stats += fmt.Sprintf(i18n.T("   %s %d (median peak level %.0f, threshold %.0f)\n"), label, reasonStats.Count, reasonStats.MaxRMS, reasonStats.Threshold)
+			} else {
+				stats += fmt.Sprintf(i18n.T("   %s %d (median peak level %.0f)\n"), label, reasonStats.Count, reasonStats.MaxRMS)
+			}
+		default:
+			stats += fmt.Sprintf("   %s %d\n", label, reasonStats.Count)
+		}
+	}
+	stats += i18n.T("💡 Presses shorter than quick_press_threshold_ms are skipped, as are recordings peaking below the silence threshold")
+	return stats
+}
@@ -2,20 +2,27 @@ package metrics
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 )
 
+// Storage keeps metrics as JSON files. Each Mac writes only its own day and
+// usage files (named after its device), and reads merge every Mac's files, so a
+// metrics_dir in iCloud Drive or Dropbox combines stats instead of clobbering them.
 type Storage struct {
 	baseDir string
+	device  string // This Mac's part of file names
 }
 
 const (
 	userSettingsFile = "settings.json"
 	dailyMetricsDir  = "daily"
+	usageDir         = "usage"
 )
 
 func NewStorage(baseDir string) (*Storage, error) {
@@ -28,36 +35,144 @@ func NewStorage(baseDir string) (*Storage, error) {
 		return nil, fmt.Errorf("failed to create daily metrics directory: %v", err)
 	}
 
+	if err := os.MkdirAll(filepath.Join(baseDir, usageDir), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create usage directory: %v", err)
+	}
+
 	return &Storage{
 		baseDir: baseDir,
+		device:  deviceName(),
 	}, nil
 }
 
-func (s *Storage) SaveSession(session *SessionMetrics) error {
-	date := session.Timestamp.Format("2006-01-02")
+// deviceName names this Mac's files after its host name, e.g. "janes-macbook-pro"
+func deviceName() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return "unknown"
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, ".local"))
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return '-'
+	}, host)
+}
+
+// ownFile is where this Mac writes the file for key, a date or a month
+func (s *Storage) ownFile(dir string, key string) string {
+	return filepath.Join(s.baseDir, dir, key+"."+s.device+".json")
+}
+
+// isOwnFile reports whether this Mac writes filePath. Files named without a
+// device, like "2026-10-15.json", predate per-Mac files and belong to the Mac
+// that was upgraded, so it recovers them when they're damaged.
+func (s *Storage) isOwnFile(filePath string) bool {
+	name := filepath.Base(filePath)
+	return strings.HasSuffix(name, "."+s.device+".json") || strings.Count(name, ".") == 1
+}
 
-	// Load or create daily metrics
-	dailyMetrics, err := s.GetDailyMetrics(date)
+// filesFor returns every Mac's files for key in dir, including files written
+// before per-Mac files and copies a sync service made of conflicting edits
+func (s *Storage) filesFor(dir string, key string) []string {
+	entries, err := os.ReadDir(filepath.Join(s.baseDir, dir))
 	if err != nil {
-		dailyMetrics = &DailyMetrics{
-			Date:     date,
-			Sessions: []SessionMetrics{},
+		return nil
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && filepath.Ext(name) == ".json" && strings.HasPrefix(name, key) {
+			paths = append(paths, filepath.Join(s.baseDir, dir, name))
 		}
 	}
+	return paths
+}
+
+// writeFile replaces path in one step, so a sync service never uploads half a file
+func writeFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *Storage) SaveSession(session *SessionMetrics) error {
+	date := dayOf(session.Timestamp)
+
+	// Load this Mac's metrics for the day. A recovered day comes with an error
+	// when the recovery couldn't be recorded, but its sessions are still kept.
+	dailyMetrics, err := s.ownDailyMetrics(date)
+	if dailyMetrics == nil {
+		return err
+	}
 
 	// Add session to daily metrics
 	dailyMetrics.Sessions = append(dailyMetrics.Sessions, *session)
+	dailyMetrics.updateTotals()
+
+	if err := s.saveDailyMetrics(dailyMetrics); err != nil {
+		return err
+	}
 
-	// Update daily totals
-	dailyMetrics.TotalWords += session.WordCount
-	dailyMetrics.TotalSaved += session.TimeSaved
-	dailyMetrics.SessionCount = len(dailyMetrics.Sessions)
+	// The cache is rebuilt from the daily files if this fails
+	s.cacheDay(date)
+	return nil
+}
+
+// SaveSkip adds a skipped session to its day
+func (s *Storage) SaveSkip(skip *SkippedSession) error {
+	date := dayOf(skip.Timestamp)
+	dailyMetrics, err := s.ownDailyMetrics(date)
+	if dailyMetrics == nil {
+		return err
+	}
 
-	return s.saveDailyMetrics(dailyMetrics)
+	dailyMetrics.Skipped = append(dailyMetrics.Skipped, *skip)
+	if err := s.saveDailyMetrics(dailyMetrics); err != nil {
+		return err
+	}
+
+	s.cacheDay(date)
+	return nil
 }
 
+// GetDailyMetrics returns the day's sessions from every Mac
 func (s *Storage) GetDailyMetrics(date string) (*DailyMetrics, error) {
-	filePath := filepath.Join(s.baseDir, dailyMetricsDir, fmt.Sprintf("%s.json", date))
+	merged := &DailyMetrics{
+		Date:     date,
+		Sessions: []SessionMetrics{},
+	}
+
+	paths := s.filesFor(dailyMetricsDir, date)
+	for _, path := range paths {
+		dailyMetrics, err := s.readDailyMetrics(path)
+		if dailyMetrics == nil {
+			if len(paths) == 1 && !errors.Is(err, errOtherMacDamage) {
+				return nil, err
+			}
+			continue // Skip problematic files, the other Macs' days still count
+		}
+		merged.merge(dailyMetrics)
+	}
+
+	sort.Slice(merged.Sessions, func(i, j int) bool {
+		return merged.Sessions[i].Timestamp.Before(merged.Sessions[j].Timestamp)
+	})
+	sort.Slice(merged.Skipped, func(i, j int) bool {
+		return merged.Skipped[i].Timestamp.Before(merged.Skipped[j].Timestamp)
+	})
+	merged.updateTotals()
+	return merged, nil
+}
+
+// ownDailyMetrics returns only this Mac's sessions for the day, the file saves
+// rewrite. A new day is started only when the file doesn't exist yet.
+func (s *Storage) ownDailyMetrics(date string) (*DailyMetrics, error) {
+	filePath := s.ownFile(dailyMetricsDir, date)
 
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return &DailyMetrics{
@@ -65,59 +180,104 @@ func (s *Storage) GetDailyMetrics(date string) (*DailyMetrics, error) {
 			Sessions: []SessionMetrics{},
 		}, nil
 	}
+	return s.readDailyMetrics(filePath)
+}
 
+// readDailyMetrics reads a daily file, recovering what it can from a damaged
+// one. The salvaged day is returned even if recording the recovery fails.
+func (s *Storage) readDailyMetrics(filePath string) (*DailyMetrics, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 
 	var dailyMetrics DailyMetrics
-	if err := json.Unmarshal(data, &dailyMetrics); err != nil {
-		return nil, err
+	migrated, err := migrate(data, dailyMigrations)
+	if err == nil {
+		err = json.Unmarshal(migrated, &dailyMetrics)
+	}
+	if isCorrupt(err) {
+		if !s.isOwnFile(filePath) {
+			return nil, otherMacDamage(filePath, err)
+		}
+		return s.recoverDailyMetrics(filePath, data, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filepath.Base(filePath), err)
 	}
 
 	return &dailyMetrics, nil
 }
 
 func (s *Storage) saveDailyMetrics(metrics *DailyMetrics) error {
-	filePath := filepath.Join(s.baseDir, dailyMetricsDir, fmt.Sprintf("%s.json", metrics.Date))
-
+	metrics.SchemaVersion = dailySchemaVersion
 	data, err := json.MarshalIndent(metrics, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(filePath, data, 0644)
+	return writeFile(s.ownFile(dailyMetricsDir, metrics.Date), data)
 }
 
-func (s *Storage) GetTotalMetrics() (*TotalMetrics, error) {
-	dailyDir := filepath.Join(s.baseDir, dailyMetricsDir)
-
-	files, err := os.ReadDir(dailyDir)
-	if err != nil {
-		return &TotalMetrics{}, nil // Return empty metrics if directory doesn't exist
+// merge adds other's sessions and skips, leaving out ones already there
+// because a sync service kept two copies of the same file
+func (d *DailyMetrics) merge(other *DailyMetrics) {
+	sessions := make(map[string]bool, len(d.Sessions))
+	for _, session := range d.Sessions {
+		sessions[recordKey(session.SessionID, session.Timestamp)] = true
+	}
+	for _, session := range other.Sessions {
+		if key := recordKey(session.SessionID, session.Timestamp); !sessions[key] {
+			sessions[key] = true
+			d.Sessions = append(d.Sessions, session)
+		}
 	}
 
-	totalMetrics := &TotalMetrics{}
+	skips := make(map[string]bool, len(d.Skipped))
+	for _, skip := range d.Skipped {
+		skips[recordKey(skip.SessionID, skip.Timestamp)] = true
+	}
+	for _, skip := range other.Skipped {
+		if key := recordKey(skip.SessionID, skip.Timestamp); !skips[key] {
+			skips[key] = true
+			d.Skipped = append(d.Skipped, skip)
+		}
+	}
+}
 
-	for _, file := range files {
-		if !file.IsDir() && filepath.Ext(file.Name()) == ".json" {
-			filePath := filepath.Join(dailyDir, file.Name())
+// recordKey identifies a session by its ID, or its time if it was recorded without one
+func recordKey(sessionID string, timestamp time.Time) string {
+	if sessionID != "" {
+		return sessionID
+	}
+	return timestamp.UTC().Format(time.RFC3339Nano)
+}
 
-			data, err := os.ReadFile(filePath)
-			if err != nil {
-				continue // Skip problematic files
-			}
+// updateTotals recomputes the day's totals from its sessions
+func (d *DailyMetrics) updateTotals() {
+	d.TotalWords, d.TotalSaved, d.TotalKeystrokes = 0, 0, 0
+	for _, session := range d.Sessions {
+		d.TotalWords += session.WordCount
+		d.TotalSaved += session.TimeSaved
+		d.TotalKeystrokes += session.KeystrokesAvoided()
+	}
+	d.SessionCount = len(d.Sessions)
+}
 
-			var dailyMetrics DailyMetrics
-			if err := json.Unmarshal(data, &dailyMetrics); err != nil {
-				continue // Skip problematic files
-			}
+// GetTotalMetrics sums every day's totals from the totals cache, which reads
+// only the days whose files changed since the last call
+func (s *Storage) GetTotalMetrics() (*TotalMetrics, error) {
+	days, err := s.cachedTotals()
+	if err != nil && days == nil {
+		return &TotalMetrics{}, nil
+	}
 
-			totalMetrics.TotalWords += dailyMetrics.TotalWords
-			totalMetrics.TotalSessions += dailyMetrics.SessionCount
-			totalMetrics.TotalSaved += dailyMetrics.TotalSaved
-		}
+	totalMetrics := &TotalMetrics{}
+	for _, day := range days {
+		totalMetrics.TotalWords += day.Words
+		totalMetrics.TotalSessions += day.Sessions
+		totalMetrics.TotalSaved += day.Saved
+		totalMetrics.TotalKeystrokes += day.Keystrokes
 	}
 
 	// Calculate averages
@@ -148,7 +308,7 @@ func (s *Storage) GetRecentDays(days int) ([]*DailyMetrics, error) {
 	var recentMetrics []*DailyMetrics
 
 	for i := days - 1; i >= 0; i-- {
-		date := time.Now().AddDate(0, 0, -i).Format("2006-01-02")
+		date := localNow().AddDate(0, 0, -i).Format("2006-01-02")
 		dailyMetrics, err := s.GetDailyMetrics(date)
 		if err != nil {
 			continue // Skip problematic days
@@ -159,9 +319,92 @@ func (s *Storage) GetRecentDays(days int) ([]*DailyMetrics, error) {
 	return recentMetrics, nil
 }
 
+// GetDateRange returns daily metrics for every day from start to end inclusive
+func (s *Storage) GetDateRange(start, end time.Time) ([]*DailyMetrics, error) {
+	var rangeMetrics []*DailyMetrics
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		dailyMetrics, err := s.GetDailyMetrics(day.Format("2006-01-02"))
+		if err != nil {
+			continue // Skip problematic days
+		}
+		rangeMetrics = append(rangeMetrics, dailyMetrics)
+	}
+
+	return rangeMetrics, nil
+}
+
+// GetMonthlyUsage returns the month's streamed audio from every Mac, since
+// they share one AssemblyAI account
+func (s *Storage) GetMonthlyUsage(month string) (*MonthlyUsage, error) {
+	total := &MonthlyUsage{Month: month}
+	for _, path := range s.filesFor(usageDir, month) {
+		usage, err := s.readMonthlyUsage(path)
+		if errors.Is(err, errOtherMacDamage) {
+			continue // Left for the Mac that wrote it, see t2 doctor
+		}
+		if err != nil {
+			return nil, err
+		}
+		total.AudioStreamed += usage.AudioStreamed
+		total.Sessions += usage.Sessions
+	}
+	return total, nil
+}
+
+// readMonthlyUsage reads a usage file, setting a damaged one aside and
+// starting the month over
+func (s *Storage) readMonthlyUsage(filePath string) (*MonthlyUsage, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var usage MonthlyUsage
+	migrated, err := migrate(data, usageMigrations)
+	if err == nil {
+		err = json.Unmarshal(migrated, &usage)
+	}
+	if isCorrupt(err) {
+		if !s.isOwnFile(filePath) {
+			return nil, otherMacDamage(filePath, err)
+		}
+		month := strings.SplitN(filepath.Base(filePath), ".", 2)[0]
+		return &MonthlyUsage{Month: month}, s.recoverFile(filePath, data, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filepath.Base(filePath), err)
+	}
+
+	return &usage, nil
+}
+
+func (s *Storage) AddAudioUsage(month string, streamed time.Duration) error {
+	filePath := s.ownFile(usageDir, month)
+
+	usage := &MonthlyUsage{Month: month}
+	if _, err := os.Stat(filePath); err == nil {
+		if usage, err = s.readMonthlyUsage(filePath); usage == nil {
+			return err
+		}
+	}
+
+	usage.AudioStreamed += streamed
+	usage.Sessions++
+	usage.SchemaVersion = usageSchemaVersion
+
+	data, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFile(filePath, data)
+}
+
 func (s *Storage) SaveUserSettings(settings *UserSettings) error {
 	filePath := filepath.Join(s.baseDir, userSettingsFile)
 
+	settings.SchemaVersion = settingsSchemaVersion
 	data, err := json.MarshalIndent(settings, "", "  ")
 	if err != nil {
 		return err
@@ -181,6 +424,9 @@ func (s *Storage) LoadUserSettings() (*UserSettings, error) {
 	if err != nil {
 		return nil, err
 	}
+	if data, err = migrate(data, settingsMigrations); err != nil {
+		return nil, fmt.Errorf("%s: %v", userSettingsFile, err)
+	}
 
 	var settings UserSettings
 	if err := json.Unmarshal(data, &settings); err != nil {
@@ -207,43 +453,79 @@ func (s *Storage) ClearAllMetrics() error {
 		}
 	}
 
+	if err := os.Remove(s.totalsCachePath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove totals cache: %v", err)
+	}
 	return nil
 }
 
-func (s *Storage) GetAllDailyMetrics() ([]*DailyMetrics, error) {
+// PurgeBefore deletes the daily metrics of days before cutoff's day and
+// returns how many days were removed
+func (s *Storage) PurgeBefore(cutoff time.Time) (int, error) {
 	dailyDir := filepath.Join(s.baseDir, dailyMetricsDir)
 
 	files, err := os.ReadDir(dailyDir)
 	if err != nil {
-		return []*DailyMetrics{}, nil
+		return 0, nil // Directory doesn't exist, nothing to purge
 	}
 
-	var allMetrics []*DailyMetrics
-	var fileNames []string
-
+	last := dayOf(cutoff)
+	removed := make(map[string]bool)
 	for _, file := range files {
-		if !file.IsDir() && filepath.Ext(file.Name()) == ".json" {
-			fileNames = append(fileNames, file.Name())
+		date, ok := fileDate(file.Name())
+		ext := filepath.Ext(file.Name())
+		if file.IsDir() || (ext != ".json" && ext != backupExt) || !ok || date >= last {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dailyDir, file.Name())); err != nil {
+			return len(removed), fmt.Errorf("failed to remove %s: %v", file.Name(), err)
 		}
+		if ext == ".json" {
+			removed[date] = true // Damaged originals set aside go too, but don't count as days
+		}
+	}
+	return len(removed), nil
+}
+
+// fileDate returns the date a daily metrics file is for
+func fileDate(name string) (string, bool) {
+	if len(name) < len("2006-01-02") {
+		return "", false
 	}
+	date := name[:len("2006-01-02")]
+	_, err := time.Parse("2006-01-02", date)
+	return date, err == nil
+}
 
-	// Sort file names to get chronological order
-	sort.Strings(fileNames)
+func (s *Storage) GetAllDailyMetrics() ([]*DailyMetrics, error) {
+	dailyDir := filepath.Join(s.baseDir, dailyMetricsDir)
 
-	for _, fileName := range fileNames {
-		filePath := filepath.Join(dailyDir, fileName)
+	files, err := os.ReadDir(dailyDir)
+	if err != nil {
+		return []*DailyMetrics{}, nil
+	}
 
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			continue
+	// One entry per day, however many Macs have files for it
+	var dates []string
+	seen := make(map[string]bool)
+	for _, file := range files {
+		date, ok := fileDate(file.Name())
+		if !file.IsDir() && filepath.Ext(file.Name()) == ".json" && ok && !seen[date] {
+			seen[date] = true
+			dates = append(dates, date)
 		}
+	}
+
+	// Sort dates to get chronological order
+	sort.Strings(dates)
 
-		var dailyMetrics DailyMetrics
-		if err := json.Unmarshal(data, &dailyMetrics); err != nil {
+	var allMetrics []*DailyMetrics
+	for _, date := range dates {
+		dailyMetrics, err := s.GetDailyMetrics(date)
+		if err != nil {
 			continue
 		}
-
-		allMetrics = append(allMetrics, &dailyMetrics)
+		allMetrics = append(allMetrics, dailyMetrics)
 	}
 
 	return allMetrics, nil
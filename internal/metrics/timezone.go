@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// Sessions are stored with UTC timestamps and bucketed into days, hours and
+// months in one time zone, so travelling doesn't split or shift a day
+var (
+	locationMutex sync.RWMutex
+	location      = time.Local
+)
+
+// SetTimezone counts days and shows times in an IANA time zone such as
+// "Europe/Berlin"; "" follows the system time zone
+func SetTimezone(name string) error {
+	loc := time.Local
+	if name != "" {
+		var err error
+		if loc, err = time.LoadLocation(name); err != nil {
+			return err
+		}
+	}
+
+	locationMutex.Lock()
+	location = loc
+	locationMutex.Unlock()
+	return nil
+}
+
+// Location returns the time zone days are counted in
+func Location() *time.Location {
+	locationMutex.RLock()
+	defer locationMutex.RUnlock()
+	return location
+}
+
+// localNow returns the current time in Location
+func localNow() time.Time {
+	return time.Now().In(Location())
+}
+
+// dayOf returns the date t falls on in Location
+func dayOf(t time.Time) string {
+	return t.In(Location()).Format("2006-01-02")
+}
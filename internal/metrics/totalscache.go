@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// totalsCacheFile keeps every day's totals so GetTotalMetrics doesn't have to
+// read years of daily files. Like the daily files it's per Mac, so a synced
+// metrics_dir never has two Macs writing it.
+const totalsCacheFile = "totals-cache"
+
+// totalsCache maps each date to the totals of its files
+type totalsCache struct {
+	Version int                  `json:"version"` // Daily schema version the totals were computed with
+	Days    map[string]cachedDay `json:"days"`
+}
+
+// cachedDay is one day's totals, valid as long as its files' signature matches
+type cachedDay struct {
+	Signature  string        `json:"signature"`
+	Words      int           `json:"words"`
+	Sessions   int           `json:"sessions"`
+	Saved      time.Duration `json:"saved"`
+	Keystrokes int           `json:"keystrokes"`
+}
+
+func (s *Storage) totalsCachePath() string {
+	return filepath.Join(s.baseDir, totalsCacheFile+"."+s.device+".json")
+}
+
+// loadTotalsCache reads the cache, starting over if it's missing, unreadable
+// or from before a daily schema migration that may have changed the totals
+func (s *Storage) loadTotalsCache() *totalsCache {
+	cache := &totalsCache{Version: dailySchemaVersion, Days: make(map[string]cachedDay)}
+	data, err := os.ReadFile(s.totalsCachePath())
+	if err != nil {
+		return cache
+	}
+
+	var stored totalsCache
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Days == nil || stored.Version != dailySchemaVersion {
+		return cache
+	}
+	return &stored
+}
+
+func (s *Storage) saveTotalsCache(cache *totalsCache) error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("failed to marshal totals cache: %v", err)
+	}
+	return writeFile(s.totalsCachePath(), data)
+}
+
+// daySignatures describes every day's files by name, size and modification
+// time, so a day is re-read only when a session was added, a sync service
+// brought in another Mac's file or the day was purged
+func (s *Storage) daySignatures() (map[string]string, error) {
+	files, err := os.ReadDir(filepath.Join(s.baseDir, dailyMetricsDir))
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make(map[string][]string)
+	for _, file := range files {
+		date, ok := fileDate(file.Name())
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" || !ok {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue // Removed while we were listing
+		}
+		parts[date] = append(parts[date], fileSignature(info))
+	}
+
+	signatures := make(map[string]string, len(parts))
+	for date, files := range parts {
+		signatures[date] = joinSignatures(files)
+	}
+	return signatures, nil
+}
+
+// daySignature is daySignatures for a single day
+func (s *Storage) daySignature(date string) string {
+	var files []string
+	for _, path := range s.filesFor(dailyMetricsDir, date) {
+		if info, err := os.Stat(path); err == nil {
+			files = append(files, fileSignature(info))
+		}
+	}
+	return joinSignatures(files)
+}
+
+func fileSignature(info os.FileInfo) string {
+	return fmt.Sprintf("%s:%d:%d", info.Name(), info.Size(), info.ModTime().UnixNano())
+}
+
+func joinSignatures(files []string) string {
+	sort.Strings(files)
+	return strings.Join(files, "|")
+}
+
+// cachedTotals returns every day's totals, reading only the days whose files
+// changed since they were cached and saving the cache if any did
+func (s *Storage) cachedTotals() ([]cachedDay, error) {
+	signatures, err := s.daySignatures()
+	if err != nil {
+		return nil, err
+	}
+
+	cache := s.loadTotalsCache()
+	changed := false
+	for date := range cache.Days {
+		if _, ok := signatures[date]; !ok {
+			delete(cache.Days, date)
+			changed = true
+		}
+	}
+
+	days := make([]cachedDay, 0, len(signatures))
+	for date, signature := range signatures {
+		day, ok := cache.Days[date]
+		if !ok || day.Signature != signature {
+			dailyMetrics, err := s.GetDailyMetrics(date)
+			if err != nil {
+				continue // Skip problematic days, as GetAllDailyMetrics does
+			}
+			day = newCachedDay(signature, dailyMetrics)
+			cache.Days[date] = day
+			changed = true
+		}
+		days = append(days, day)
+	}
+
+	if changed {
+		if err := s.saveTotalsCache(cache); err != nil {
+			return days, err
+		}
+	}
+	return days, nil
+}
+
+// cacheDay refreshes one day's entry after a save wrote to it, so the
+// next GetTotalMetrics finds the cache current
+func (s *Storage) cacheDay(date string) error {
+	signature := s.daySignature(date)
+	dailyMetrics, err := s.GetDailyMetrics(date)
+	if err != nil {
+		return err
+	}
+
+	cache := s.loadTotalsCache()
+	cache.Days[date] = newCachedDay(signature, dailyMetrics)
+	return s.saveTotalsCache(cache)
+}
+
+func newCachedDay(signature string, dailyMetrics *DailyMetrics) cachedDay {
+	return cachedDay{
+		Signature:  signature,
+		Words:      dailyMetrics.TotalWords,
+		Sessions:   dailyMetrics.SessionCount,
+		Saved:      dailyMetrics.TotalSaved,
+		Keystrokes: dailyMetrics.TotalKeystrokes,
+	}
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+)
+
+// AssemblyAI streaming pricing used for estimates
+const (
+	freeTierHoursPerMonth = 5.0
+	costPerHourUSD        = 0.15
+	usageWarningFraction  = 0.8
+)
+
+// MonthlyUsage tracks how much audio was streamed to the provider in a month.
+// Unlike daily metrics it also counts sessions that were skipped or failed,
+// since the provider bills for them all the same.
+type MonthlyUsage struct {
+	Month         string        `json:"month"`
+	AudioStreamed time.Duration `json:"audio_streamed"`
+	Sessions      int           `json:"sessions"`
+	SchemaVersion int           `json:"schema_version,omitempty"`
+}
+
+// FreeTierUsed returns the fraction of the monthly free tier consumed
+func (u *MonthlyUsage) FreeTierUsed() float64 {
+	return u.AudioStreamed.Hours() / freeTierHoursPerMonth
+}
+
+// FreeTierRemaining returns how much free streaming is left this month
+func (u *MonthlyUsage) FreeTierRemaining() time.Duration {
+	remaining := time.Duration(freeTierHoursPerMonth*float64(time.Hour)) - u.AudioStreamed
+	return max(remaining, 0)
+}
+
+// EstimatedCost returns the cost of this month's audio beyond the free tier
+func (u *MonthlyUsage) EstimatedCost() float64 {
+	billable := u.AudioStreamed.Hours() - freeTierHoursPerMonth
+	if billable <= 0 {
+		return 0
+	}
+	return billable * costPerHourUSD
+}
+
+func (mm *MetricsManager) RecordAudioUsage(streamed time.Duration) error {
+	if streamed <= 0 {
+		return nil
+	}
+	return mm.storage.AddAudioUsage(localNow().Format("2006-01"), streamed)
+}
+
+func (mm *MetricsManager) GetMonthlyUsage() (*MonthlyUsage, error) {
+	return mm.storage.GetMonthlyUsage(localNow().Format("2006-01"))
+}
+
+func (sf *StatsFormatter) FormatUsage(usage *MonthlyUsage) string {
+	stats := "💳 API Usage This Month:\n"
+	stats += fmt.Sprintf("   Audio streamed: %s (%d sessions)\n", sf.timeFormatter.FormatDuration(usage.AudioStreamed), usage.Sessions)
+	stats += fmt.Sprintf("   Free tier left: %s of %.0f hours\n", sf.timeFormatter.FormatDuration(usage.FreeTierRemaining()), freeTierHoursPerMonth)
+	stats += fmt.Sprintf("   Estimated cost: $%.2f", usage.EstimatedCost())
+
+	if used := usage.FreeTierUsed(); used >= 1 {
+		stats += "\n⚠️  Free tier exhausted - further streaming is billed"
+	} else if used >= usageWarningFraction {
+		stats += fmt.Sprintf("\n⚠️  %.0f%% of the free tier used this month", used*100)
+	}
+
+	return stats
+}
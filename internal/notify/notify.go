@@ -0,0 +1,14 @@
+package notify
+
+import (
+	"log"
+
+	"github.com/gen2brain/beeep"
+)
+
+// Send shows a desktop notification, logging instead of failing if it can't
+func Send(message string) {
+	if err := beeep.Notify("T2", message, ""); err != nil {
+		log.Printf("[NOTIFY] Failed to show notification: %v", err)
+	}
+}
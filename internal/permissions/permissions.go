@@ -0,0 +1,152 @@
+package permissions
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework ApplicationServices -framework AVFoundation -framework Foundation
+#import <ApplicationServices/ApplicationServices.h>
+#import <AVFoundation/AVFoundation.h>
+
+int microphoneStatus() {
+    return (int)[AVCaptureDevice authorizationStatusForMediaType:AVMediaTypeAudio];
+}
+
+int requestMicrophoneAccess() {
+    __block int granted = 0;
+    dispatch_semaphore_t sema = dispatch_semaphore_create(0);
+    [AVCaptureDevice requestAccessForMediaType:AVMediaTypeAudio completionHandler:^(BOOL ok) {
+        granted = ok ? 1 : 0;
+        dispatch_semaphore_signal(sema);
+    }];
+    dispatch_semaphore_wait(sema, DISPATCH_TIME_FOREVER);
+    return granted;
+}
+
+int accessibilityTrusted(int prompt) {
+    const void *keys[] = { kAXTrustedCheckOptionPrompt };
+    const void *values[] = { prompt ? kCFBooleanTrue : kCFBooleanFalse };
+    CFDictionaryRef options = CFDictionaryCreate(kCFAllocatorDefault, keys, values, 1,
+        &kCFCopyStringDictionaryKeyCallBacks, &kCFTypeDictionaryValueCallBacks);
+    int trusted = AXIsProcessTrustedWithOptions(options) ? 1 : 0;
+    CFRelease(options);
+    return trusted;
+}
+*/
+import "C"
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+// Status mirrors AVAuthorizationStatus
+type Status int
+
+const (
+	NotDetermined Status = iota
+	Restricted
+	Denied
+	Granted
+)
+
+const (
+	microphonePane    = "x-apple.systempreferences:com.apple.preference.security?Privacy_Microphone"
+	accessibilityPane = "x-apple.systempreferences:com.apple.preference.security?Privacy_Accessibility"
+)
+
+// MicrophoneStatus returns the current microphone authorization for this process
+func MicrophoneStatus() Status {
+	if runtime.GOOS != "darwin" {
+		return Granted
+	}
+	return Status(C.microphoneStatus())
+}
+
+// RequestMicrophone shows the system microphone prompt and blocks until answered
+func RequestMicrophone() bool {
+	if runtime.GOOS != "darwin" {
+		return true
+	}
+	return int(C.requestMicrophoneAccess()) == 1
+}
+
+// AccessibilityGranted reports whether this process may send synthetic keystrokes.
+// If prompt is true, macOS shows its own dialog pointing at System Settings.
+func AccessibilityGranted(prompt bool) bool {
+	if runtime.GOOS != "darwin" {
+		return true
+	}
+	p := 0
+	if prompt {
+		p = 1
+	}
+	return int(C.accessibilityTrusted(C.int(p))) == 1
+}
+
+// OpenSettingsPane opens a System Settings privacy pane via its deep link
+func OpenSettingsPane(link string) error {
+	return exec.Command("open", link).Run()
+}
+
+// EnsureGranted walks the user through granting any missing permissions.
+// It returns true when both microphone and accessibility access are available.
+func EnsureGranted() bool {
+	micOK := ensureMicrophone()
+	axOK := ensureAccessibility()
+	return micOK && axOK
+}
+
+func ensureMicrophone() bool {
+	switch MicrophoneStatus() {
+	case Granted:
+		return true
+	case NotDetermined:
+		terminal.Println("🎙️  T2 needs microphone access to record your voice.")
+		if RequestMicrophone() {
+			terminal.Println("✅ Microphone access granted")
+			return true
+		}
+	}
+
+	terminal.Println("🎙️  Microphone access is blocked for your terminal.")
+	terminal.Println("📋 To fix this:")
+	terminal.Println("   1. In System Settings → Privacy & Security → Microphone")
+	terminal.Println("   2. Enable the terminal app you run t2 from")
+	terminal.Println("   3. Restart the terminal app")
+	if err := OpenSettingsPane(microphonePane); err != nil {
+		terminal.Printf("⚠️  Warning: Failed to open System Settings: %v\n", err)
+	}
+	waitForEnter()
+
+	return MicrophoneStatus() == Granted
+}
+
+func ensureAccessibility() bool {
+	if AccessibilityGranted(false) {
+		return true
+	}
+
+	terminal.Println("⌨️  T2 needs accessibility access to paste text into other apps.")
+	terminal.Println("📋 To fix this:")
+	terminal.Println("   1. In System Settings → Privacy & Security → Accessibility")
+	terminal.Println("   2. Enable the terminal app you run t2 from")
+	if err := OpenSettingsPane(accessibilityPane); err != nil {
+		terminal.Printf("⚠️  Warning: Failed to open System Settings: %v\n", err)
+	}
+	waitForEnter()
+
+	if AccessibilityGranted(false) {
+		terminal.Println("✅ Accessibility access granted")
+		return true
+	}
+	return false
+}
+
+func waitForEnter() {
+	terminal.Print("⏎  Press Enter once you've granted access... ")
+	bufio.NewScanner(os.Stdin).Scan()
+	terminal.Println()
+}
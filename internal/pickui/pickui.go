@@ -0,0 +1,125 @@
+// Package pickui is the transcript picker behind t2 pick
+package pickui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bezmoradi/t2/internal/history"
+)
+
+var (
+	headingStyle  = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Bold(true)
+	previewStyle  = lipgloss.NewStyle().Faint(true)
+	helpStyle     = lipgloss.NewStyle().Faint(true)
+)
+
+// reservedLines is the height of everything around the transcript list
+const reservedLines = 10
+
+type model struct {
+	entries []history.Entry // Newest first
+	cursor  int
+	offset  int // First entry shown in the list
+	height  int
+	width   int
+	picked  bool
+}
+
+// Run shows entries (oldest first, as stored) newest first and returns the
+// one the user picked, or false if they quit without picking
+func Run(entries []history.Entry) (history.Entry, bool, error) {
+	m := &model{height: 24, width: 80}
+	for i := len(entries) - 1; i >= 0; i-- {
+		m.entries = append(m.entries, entries[i])
+	}
+
+	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	if err != nil || !m.picked {
+		return history.Entry{}, false, err
+	}
+	return m.entries[m.cursor], true, nil
+}
+
+func (m *model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height, m.width = msg.Height, msg.Width
+		m.scrollToCursor()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "enter":
+			m.picked = len(m.entries) > 0
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			m.scrollToCursor()
+		case "down", "j":
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+			m.scrollToCursor()
+		}
+	}
+	return m, nil
+}
+
+// listHeight is how many transcripts fit on screen
+func (m *model) listHeight() int {
+	return max(m.height-reservedLines, 3)
+}
+
+func (m *model) scrollToCursor() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.listHeight() {
+		m.offset = m.cursor - m.listHeight() + 1
+	}
+}
+
+func (m *model) View() string {
+	var b strings.Builder
+
+	b.WriteString(headingStyle.Render("Recent transcripts") + "\n\n")
+
+	end := min(m.offset+m.listHeight(), len(m.entries))
+	for i := m.offset; i < end; i++ {
+		line := m.entryLine(m.entries[i])
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render("> "+line) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	// The whole selected transcript, since list lines are cut to one line
+	b.WriteString("\n")
+	b.WriteString(previewStyle.Render(lipgloss.NewStyle().Width(max(m.width-2, 20)).Render(m.entries[m.cursor].Text)))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("↑/↓ select • enter copy • q quit"))
+	return b.String()
+}
+
+// entryLine shows when a transcript was pasted and its first line, cut to the screen width
+func (m *model) entryLine(entry history.Entry) string {
+	text := strings.Join(strings.Fields(entry.Text), " ")
+	width := max(m.width-22, 20)
+	if runes := []rune(text); len(runes) > width {
+		text = string(runes[:width-1]) + "…"
+	}
+	return fmt.Sprintf("%-16s %s", entry.Time.Format("Jan 2 15:04"), text)
+}
@@ -0,0 +1,13 @@
+package power
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+// goPowerEvent is called from the IOKit power callback
+//
+//export goPowerEvent
+func goPowerEvent(event int32) {
+	handleEvent(int(event))
+}
@@ -0,0 +1,106 @@
+package power
+
+/*
+#cgo LDFLAGS: -framework IOKit -framework CoreFoundation
+#include <IOKit/pwr_mgt/IOPMLib.h>
+#include <IOKit/IOMessage.h>
+
+extern void goPowerEvent(int event);
+
+static io_connect_t rootPort;
+
+static void powerCallback(void *refCon, io_service_t service, natural_t messageType, void *messageArgument) {
+    switch (messageType) {
+    case kIOMessageCanSystemSleep:
+        IOAllowPowerChange(rootPort, (long)messageArgument);
+        break;
+    case kIOMessageSystemWillSleep:
+        goPowerEvent(1);
+        IOAllowPowerChange(rootPort, (long)messageArgument);
+        break;
+    case kIOMessageSystemHasPoweredOn:
+        goPowerEvent(2);
+        break;
+    }
+}
+
+// registerPower subscribes to sleep/wake messages on the current thread's run loop
+static int registerPower() {
+    IONotificationPortRef port;
+    io_object_t notifier;
+    rootPort = IORegisterForSystemPower(NULL, &port, powerCallback, &notifier);
+    if (rootPort == 0) {
+        return 0;
+    }
+    CFRunLoopAddSource(CFRunLoopGetCurrent(), IONotificationPortGetRunLoopSource(port), kCFRunLoopCommonModes);
+    return 1;
+}
+
+static void runLoop() {
+    CFRunLoopRun();
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+const (
+	eventSleep = 1
+	eventWake  = 2
+)
+
+var (
+	mu      sync.Mutex
+	onSleep func()
+	onWake  func()
+)
+
+// Watch calls sleep just before the system sleeps and wake after it wakes up.
+// The sleep callback should be quick, since sleep waits for it to return.
+func Watch(sleep, wake func()) error {
+	if runtime.GOOS != "darwin" {
+		return errors.New("sleep/wake notifications are only supported on macOS")
+	}
+
+	mu.Lock()
+	onSleep, onWake = sleep, wake
+	mu.Unlock()
+
+	registered := make(chan bool, 1)
+	go func() {
+		// The notifications are delivered on this thread's run loop
+		runtime.LockOSThread()
+		if int(C.registerPower()) == 0 {
+			registered <- false
+			return
+		}
+		registered <- true
+		C.runLoop()
+	}()
+
+	if !<-registered {
+		return errors.New("failed to register for sleep/wake notifications")
+	}
+	return nil
+}
+
+func handleEvent(event int) {
+	mu.Lock()
+	sleep, wake := onSleep, onWake
+	mu.Unlock()
+
+	switch event {
+	case eventSleep:
+		if sleep != nil {
+			sleep()
+		}
+	case eventWake:
+		if wake != nil {
+			wake()
+		}
+	}
+}
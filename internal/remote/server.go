@@ -0,0 +1,265 @@
+package remote
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/hotkeys"
+	"github.com/bezmoradi/t2/internal/metrics"
+)
+
+// Status is the daemon state reported by GET /status
+type Status struct {
+	Recording     bool      `json:"recording"`
+	Connected     bool      `json:"connected"`
+	Connection    string    `json:"connection"` // Connection state, e.g. "ready" or "streaming"
+	Provider      string    `json:"provider"`
+	Profile       string    `json:"profile,omitempty"`
+	UptimeSeconds float64   `json:"uptime_seconds"`
+	SessionsToday int       `json:"sessions_today"`
+	WordsToday    int       `json:"words_today"`
+	LastError     string    `json:"last_error,omitempty"` // Latest failure, e.g. a failed paste
+	LastErrorAt   time.Time `json:"last_error_at,omitzero"`
+}
+
+// StatusReporter is optionally implemented by handlers that can report their state
+type StatusReporter interface {
+	Status() Status
+}
+
+// MetricsWriter is optionally implemented by handlers that expose Prometheus metrics
+type MetricsWriter interface {
+	WriteMetrics(w io.Writer)
+}
+
+// StatsReporter is optionally implemented by handlers that can report usage statistics
+type StatsReporter interface {
+	Stats() (*metrics.Report, error)
+}
+
+// TranscriptSource is optionally implemented by handlers that can report the
+// last transcript
+type TranscriptSource interface {
+	LastTranscript() string
+}
+
+// Repaster is optionally implemented by handlers that can paste the last
+// transcript again
+type Repaster interface {
+	Repaste() error
+}
+
+// Server lets a phone shortcut or companion app start and stop recording
+// over the local network. Every request must carry the shared token.
+type Server struct {
+	addr    string
+	token   string
+	handler hotkeys.EventHandler
+	server  *http.Server
+	mutex   sync.Mutex // Serializes the check and the press or release of /toggle
+}
+
+// NewServer creates a remote trigger listening on addr (e.g. ":7766")
+func NewServer(addr string, token string, handler hotkeys.EventHandler) *Server {
+	return &Server{
+		addr:    addr,
+		token:   token,
+		handler: handler,
+	}
+}
+
+// GenerateToken returns a random token suitable for authenticating remote triggers
+func GenerateToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Start begins listening in the background, until ctx is cancelled or Stop is called
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", s.addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/start", s.authorized(http.MethodPost, s.handleStart))
+	mux.HandleFunc("/record", s.authorized(http.MethodPost, s.handleStart))
+	mux.HandleFunc("/stop", s.authorized(http.MethodPost, s.handleStop))
+	mux.HandleFunc("/toggle", s.authorized(http.MethodPost, s.handleToggle))
+	mux.HandleFunc("/repaste", s.authorized(http.MethodPost, s.handleRepaste))
+	mux.HandleFunc("/last-transcript", s.authorized(http.MethodGet, s.handleLastTranscript))
+	mux.HandleFunc("/status", s.authorized(http.MethodGet, s.handleStatus))
+	mux.HandleFunc("/stats", s.authorized(http.MethodGet, s.handleStats))
+	mux.HandleFunc("/metrics", s.authorized(http.MethodGet, s.handleMetrics))
+	// Liveness only, so monitors don't need the token
+	mux.HandleFunc("/healthz", s.handleHealthz)
+
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("Remote trigger server error: %v", err)
+		}
+	}()
+	context.AfterFunc(ctx, s.Stop)
+
+	return nil
+}
+
+// Stop shuts the listener down
+func (s *Server) Stop() {
+	if s.server != nil {
+		s.server.Close()
+	}
+}
+
+// authorized rejects requests with the wrong method or without a valid bearer token
+func (s *Server) authorized(method string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// recording asks the handler whether it's recording, so recordings started
+// or stopped with the hotkey are seen too
+func (s *Server) recording() bool {
+	if reporter, ok := s.handler.(StatusReporter); ok {
+		return reporter.Status().Recording
+	}
+	return false
+}
+
+// The handler serializes presses and releases with the hotkey's own and
+// ignores a press while recording and a release while not
+func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.handler.OnPress()
+	fmt.Fprintln(w, "recording")
+}
+
+func (s *Server) handleStop(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.handler.OnRelease()
+	fmt.Fprintln(w, "stopped")
+}
+
+func (s *Server) handleToggle(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.recording() {
+		s.handler.OnRelease()
+		fmt.Fprintln(w, "stopped")
+	} else {
+		s.handler.OnPress()
+		fmt.Fprintln(w, "recording")
+	}
+}
+
+func (s *Server) handleRepaste(w http.ResponseWriter, r *http.Request) {
+	repaster, ok := s.handler.(Repaster)
+	if !ok {
+		http.Error(w, "repaste not supported", http.StatusNotImplemented)
+		return
+	}
+
+	if err := repaster.Repaste(); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	fmt.Fprintln(w, "pasted")
+}
+
+func (s *Server) handleLastTranscript(w http.ResponseWriter, r *http.Request) {
+	source, ok := s.handler.(TranscriptSource)
+	if !ok {
+		http.Error(w, "last transcript not supported", http.StatusNotImplemented)
+		return
+	}
+
+	fmt.Fprint(w, source.LastTranscript())
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	reporter, ok := s.handler.(StatusReporter)
+	if !ok {
+		http.Error(w, "status not supported", http.StatusNotImplemented)
+		return
+	}
+
+	writeJSON(w, reporter.Status())
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	reporter, ok := s.handler.(StatsReporter)
+	if !ok {
+		http.Error(w, "stats not supported", http.StatusNotImplemented)
+		return
+	}
+
+	report, err := reporter.Stats()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, report)
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	writer, ok := s.handler.(MetricsWriter)
+	if !ok {
+		http.Error(w, "metrics not supported", http.StatusNotImplemented)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	writer.WriteMetrics(w)
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
@@ -0,0 +1,36 @@
+// Package session identifies dictation sessions so their log lines can be
+// pulled back out of the daemon log as a timeline
+package session
+
+import (
+	"log"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// idPattern matches the session tag Printf puts at the start of a log message
+var idPattern = regexp.MustCompile(`\[session ([0-9a-f-]{36})\]`)
+
+// NewID returns a random identifier for a new session
+func NewID() string {
+	return uuid.NewString()
+}
+
+// Printf logs like log.Printf, tagging the line with the session id when there is one
+func Printf(id string, format string, args ...any) {
+	if id == "" {
+		log.Printf(format, args...)
+		return
+	}
+	log.Printf("[session "+id+"] "+format, args...)
+}
+
+// FromLine returns the session id a log line was tagged with, or "" if it has none
+func FromLine(line string) string {
+	match := idPattern.FindStringSubmatch(line)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
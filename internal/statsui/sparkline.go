@@ -0,0 +1,43 @@
+package statsui
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values as a row of block characters scaled to the largest,
+// at most width characters wide
+func sparkline(values []float64, width int) string {
+	values = bucket(values, width)
+	peak := maxOf(values)
+
+	line := make([]rune, len(values))
+	for i, value := range values {
+		if peak <= 0 || value <= 0 {
+			line[i] = ' '
+			continue
+		}
+		level := int(value / peak * float64(len(sparkBlocks)-1))
+		line[i] = sparkBlocks[level]
+	}
+	return string(line)
+}
+
+// bucket shrinks values to at most width by keeping the largest value of
+// each run, so spikes stay visible
+func bucket(values []float64, width int) []float64 {
+	if width <= 0 || len(values) <= width {
+		return values
+	}
+	buckets := make([]float64, width)
+	for i, value := range values {
+		j := i * width / len(values)
+		buckets[j] = max(buckets[j], value)
+	}
+	return buckets
+}
+
+func maxOf(values []float64) float64 {
+	peak := 0.0
+	for _, value := range values {
+		peak = max(peak, value)
+	}
+	return peak
+}
@@ -0,0 +1,298 @@
+// Package statsui is the interactive terminal dashboard behind t2 --stats --interactive
+package statsui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bezmoradi/t2/internal/metrics"
+)
+
+// tab is one of the periods the dashboard can show
+type tab struct {
+	name string
+	days int // Days of history, counting today
+}
+
+var tabs = []tab{
+	{name: "Today", days: 1},
+	{name: "Week", days: 7},
+	{name: "Month", days: 30},
+}
+
+var (
+	activeTabStyle   = lipgloss.NewStyle().Bold(true).Reverse(true).Padding(0, 1)
+	inactiveTabStyle = lipgloss.NewStyle().Faint(true).Padding(0, 1)
+	headingStyle     = lipgloss.NewStyle().Bold(true)
+	selectedStyle    = lipgloss.NewStyle().Bold(true)
+	helpStyle        = lipgloss.NewStyle().Faint(true)
+)
+
+const (
+	// reservedLines is the height of everything above and below the session list
+	reservedLines = 14
+
+	// sparklineMargin is the width of the label and note around a sparkline
+	sparklineMargin = 40
+)
+
+type model struct {
+	manager  *metrics.MetricsManager
+	tab      int
+	days     []*metrics.DailyMetrics
+	sessions []metrics.SessionMetrics // Newest first
+	cursor   int
+	offset   int  // First session shown in the list
+	detail   bool // Showing the selected session
+	heatmap  bool // Showing when sessions happen instead of the list
+	height   int
+	width    int
+	err      error
+}
+
+// Run shows the dashboard until the user quits
+func Run(manager *metrics.MetricsManager) error {
+	m := &model{manager: manager, height: 24, width: 80}
+	m.load()
+
+	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+// load reads the days and sessions for the current tab
+func (m *model) load() {
+	m.days, m.err = m.manager.GetRecentDays(tabs[m.tab].days)
+	m.sessions = nil
+	for i := len(m.days) - 1; i >= 0; i-- {
+		sessions := m.days[i].Sessions
+		for j := len(sessions) - 1; j >= 0; j-- {
+			m.sessions = append(m.sessions, sessions[j])
+		}
+	}
+	m.cursor, m.offset, m.detail = 0, 0, false
+}
+
+func (m *model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		m.width = msg.Width
+		m.scrollToCursor()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "esc", "backspace":
+			m.detail = false
+		case "enter":
+			m.detail = !m.heatmap && len(m.sessions) > 0
+		case "m":
+			m.heatmap = !m.heatmap
+		case "tab", "right", "l":
+			m.tab = (m.tab + 1) % len(tabs)
+			m.load()
+		case "shift+tab", "left", "h":
+			m.tab = (m.tab + len(tabs) - 1) % len(tabs)
+			m.load()
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			m.scrollToCursor()
+		case "down", "j":
+			if m.cursor < len(m.sessions)-1 {
+				m.cursor++
+			}
+			m.scrollToCursor()
+		}
+	}
+	return m, nil
+}
+
+// listHeight is how many sessions fit on screen
+func (m *model) listHeight() int {
+	return max(m.height-reservedLines, 3)
+}
+
+func (m *model) scrollToCursor() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.listHeight() {
+		m.offset = m.cursor - m.listHeight() + 1
+	}
+}
+
+func (m *model) View() string {
+	var b strings.Builder
+
+	for i, t := range tabs {
+		if i == m.tab {
+			b.WriteString(activeTabStyle.Render(t.name))
+		} else {
+			b.WriteString(inactiveTabStyle.Render(t.name))
+		}
+	}
+	b.WriteString("\n\n")
+
+	switch {
+	case m.err != nil:
+		fmt.Fprintf(&b, "Error loading statistics: %v\n", m.err)
+	case m.detail:
+		m.viewDetail(&b)
+	case m.heatmap:
+		m.viewHeatmap(&b)
+	default:
+		m.viewSummary(&b)
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.detail:
+		b.WriteString(helpStyle.Render("esc back • q quit"))
+	case m.heatmap:
+		b.WriteString(helpStyle.Render("←/→ period • m sessions • q quit"))
+	default:
+		b.WriteString(helpStyle.Render("←/→ period • ↑/↓ select • enter details • m heatmap • q quit"))
+	}
+	return b.String()
+}
+
+func (m *model) viewSummary(b *strings.Builder) {
+	formatter := metrics.NewTimeFormatter()
+
+	words, saved := 0, time.Duration(0)
+	var latencies []time.Duration
+	for _, session := range m.sessions {
+		words += session.WordCount
+		saved += session.TimeSaved
+		if session.Latency > 0 {
+			latencies = append(latencies, session.Latency)
+		}
+	}
+
+	fmt.Fprintf(b, "%s  %d sessions • %d words • %s saved\n\n",
+		headingStyle.Render("Summary"), len(m.sessions), words, formatter.FormatDurationShort(saved))
+
+	// Words per hour for a single day, per day otherwise
+	width := max(m.width-sparklineMargin, 10)
+	if tabs[m.tab].days == 1 {
+		fmt.Fprintf(b, "%-10s %s  (per hour)\n", "Words", sparkline(wordsPerHour(m.sessions), width))
+	} else {
+		fmt.Fprintf(b, "%-10s %s  (per day)\n", "Words", sparkline(wordsPerDay(m.days), width))
+	}
+
+	// Latency in the order sessions happened
+	values := make([]float64, len(latencies))
+	for i, latency := range latencies {
+		values[len(latencies)-1-i] = latency.Seconds()
+	}
+	if len(values) > 0 {
+		fmt.Fprintf(b, "%-10s %s  (per session, max %.1fs)\n", "Latency", sparkline(values, width), maxOf(values))
+	} else {
+		fmt.Fprintf(b, "%-10s %s\n", "Latency", "no timings recorded")
+	}
+	b.WriteString("\n")
+
+	if len(m.sessions) == 0 {
+		b.WriteString("No sessions in this period\n")
+		return
+	}
+
+	b.WriteString(headingStyle.Render(fmt.Sprintf("  %-16s %-18s %6s %8s", "Time", "App", "Words", "Latency")) + "\n")
+	end := min(m.offset+m.listHeight(), len(m.sessions))
+	for i := m.offset; i < end; i++ {
+		line := sessionLine(m.sessions[i])
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render("> "+line) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+}
+
+// viewHeatmap shows which weekdays and hours of the period sessions started in
+func (m *model) viewHeatmap(b *strings.Builder) {
+	heatmap := metrics.NewHeatmap(m.days)
+	fmt.Fprintf(b, "%s  %d sessions\n\n", headingStyle.Render("Sessions by weekday and hour"), len(m.sessions))
+	b.WriteString(heatmap.Format())
+}
+
+func (m *model) viewDetail(b *strings.Builder) {
+	session := m.sessions[m.cursor]
+	formatter := metrics.NewTimeFormatter()
+
+	b.WriteString(headingStyle.Render("Session "+session.Timestamp.In(metrics.Location()).Format("Mon Jan 2 15:04:05")) + "\n\n")
+	rows := [][2]string{
+		{"App", orDash(session.App)},
+		{"Words", fmt.Sprintf("%d", session.WordCount)},
+		{"Recording", formatter.FormatDurationShort(session.RecordingTime)},
+		{"Speaking rate", fmt.Sprintf("%d WPM", session.SpeakingRate)},
+		{"Time saved", formatter.FormatDurationShort(session.TimeSaved)},
+		{"Provider", orDash(session.Provider)},
+		{"Tag", orDash(session.Tag)},
+	}
+	if session.Confidence > 0 {
+		rows = append(rows, [2]string{"Confidence", fmt.Sprintf("%.0f%%", session.Confidence*100)})
+	}
+	if session.Latency > 0 {
+		rows = append(rows,
+			[2]string{"Latency", session.Latency.Round(time.Millisecond).String()},
+			[2]string{"  Termination", session.TerminationWait.Round(time.Millisecond).String()},
+			[2]string{"  Transcript", session.TranscriptWait.Round(time.Millisecond).String()},
+			[2]string{"  Paste", session.PasteTime.Round(time.Millisecond).String()},
+		)
+	}
+	for _, row := range rows {
+		fmt.Fprintf(b, "%-15s %s\n", row[0], row[1])
+	}
+}
+
+func sessionLine(session metrics.SessionMetrics) string {
+	latency := "-"
+	if session.Latency > 0 {
+		latency = fmt.Sprintf("%.2fs", session.Latency.Seconds())
+	}
+	return fmt.Sprintf("%-16s %-18s %6d %8s",
+		session.Timestamp.In(metrics.Location()).Format("Jan 2 15:04"), truncate(orDash(session.App), 18), session.WordCount, latency)
+}
+
+func wordsPerHour(sessions []metrics.SessionMetrics) []float64 {
+	hours := make([]float64, 24)
+	for _, session := range sessions {
+		hours[session.Timestamp.In(metrics.Location()).Hour()] += float64(session.WordCount)
+	}
+	return hours
+}
+
+func wordsPerDay(days []*metrics.DailyMetrics) []float64 {
+	values := make([]float64, len(days))
+	for i, day := range days {
+		values[i] = float64(day.TotalWords)
+	}
+	return values
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func truncate(value string, width int) string {
+	runes := []rune(value)
+	if len(runes) <= width {
+		return value
+	}
+	return string(runes[:width-1]) + "…"
+}
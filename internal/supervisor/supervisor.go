@@ -0,0 +1,72 @@
+package supervisor
+
+import (
+	"log"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/terminal"
+)
+
+const (
+	// restartDelay keeps a crashing component from spinning
+	restartDelay = 100 * time.Millisecond
+
+	// A component that panics maxRestarts times within restartWindow is given up on
+	maxRestarts   = 5
+	restartWindow = time.Minute
+)
+
+// Run calls fn and restarts it whenever it panics, until it returns normally.
+// Each panic is logged with its stack trace. A component that keeps crashing
+// is given up on rather than restarted in a tight loop. Only use it for
+// components that hold no locks, or a restart could wait on one forever.
+func Run(name string, fn func()) {
+	var restarts []time.Time
+
+	for runOnce(name, fn) {
+		// Only count recent restarts towards the limit
+		now := time.Now()
+		recent := restarts[:0]
+		for _, restart := range restarts {
+			if now.Sub(restart) < restartWindow {
+				recent = append(recent, restart)
+			}
+		}
+		restarts = recent
+
+		if len(restarts) >= maxRestarts {
+			log.Printf("[SUPERVISOR] %s panicked %d times in %v, giving up", name, len(restarts)+1, restartWindow)
+			terminal.Printf("❌ %s keeps crashing - please restart T2 and report the log\n", name)
+			return
+		}
+		restarts = append(restarts, now)
+
+		time.Sleep(restartDelay)
+		log.Printf("[SUPERVISOR] Restarting %s", name)
+	}
+}
+
+// RunOrExit calls fn and exits the process if it panics. It's for components
+// that hold locks, which a panic can leave locked for good.
+func RunOrExit(name string, fn func()) {
+	if runOnce(name, fn) {
+		terminal.Printf("❌ %s crashed and T2 can't safely continue - please restart it and report the log\n", name)
+		os.Exit(1)
+	}
+}
+
+// runOnce calls fn and reports whether it panicked
+func runOnce(name string, fn func()) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			log.Printf("[SUPERVISOR] %s panicked: %v\n%s", name, r, debug.Stack())
+			terminal.Printf("⚠️  Warning: %s crashed (details in the log)\n", name)
+		}
+	}()
+
+	fn()
+	return false
+}
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"time"
 )
 
+// flashDuration is how long one Flash keeps the colors inverted
+const flashDuration = 80 * time.Millisecond
+
 // Control provides terminal control functionality
 type Control struct {
 	isWindows bool
@@ -87,10 +91,10 @@ func (c *Control) IsTerminal() bool {
 // UpdateInPlace updates multiple lines in place
 // This is the main function for dynamically updating session output
 func (c *Control) UpdateInPlace(lines []string, isFirstUpdate bool) {
-	if !c.IsTerminal() {
-		// If not in a terminal (e.g., piped output), just print normally
+	if !c.IsTerminal() || Plain() {
+		// If not in a terminal (e.g., piped output) or in plain mode, just print normally
 		for _, line := range lines {
-			fmt.Println(line)
+			fmt.Println(Text(line))
 		}
 		return
 	}
@@ -126,3 +130,20 @@ func (c *Control) HideCursor() {
 func (c *Control) ShowCursor() {
 	fmt.Print("\033[?25h")
 }
+
+// Flash briefly inverts the terminal's colors times times, a silent
+// alternative to a beep; it does nothing in plain mode or when not a terminal
+func (c *Control) Flash(times int) {
+	if !c.IsTerminal() || Plain() {
+		return
+	}
+
+	for i := 0; i < times; i++ {
+		if i > 0 {
+			time.Sleep(flashDuration)
+		}
+		fmt.Print("\033[?5h")
+		time.Sleep(flashDuration)
+		fmt.Print("\033[?5l")
+	}
+}
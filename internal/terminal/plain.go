@@ -0,0 +1,118 @@
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/bezmoradi/t2/internal/i18n"
+)
+
+// NoColorEnvVar disables emoji and in-place updates when set to any value (https://no-color.org)
+const NoColorEnvVar = "NO_COLOR"
+
+var plain atomic.Bool
+
+// SetPlain switches plain output on or off for the whole process
+func SetPlain(enabled bool) {
+	plain.Store(enabled)
+}
+
+// Plain reports whether output should avoid emoji, block characters and cursor control
+func Plain() bool {
+	return plain.Load()
+}
+
+// PlainFromEnv reports whether NO_COLOR asks for plain output
+func PlainFromEnv() bool {
+	return os.Getenv(NoColorEnvVar) != ""
+}
+
+// Text returns s unchanged, or with emoji removed and bar characters replaced in plain mode
+func Text(s string) string {
+	if !Plain() {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	skipSpaces := false
+	for _, r := range s {
+		switch {
+		case isEmoji(r):
+			// Drop the emoji along with the padding that separated it from the text
+			skipSpaces = true
+			continue
+		case r == ' ' && skipSpaces:
+			continue
+		case r == '█':
+			r = '#'
+		}
+		skipSpaces = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, // pictographs, emoticons, transport, flags
+		r >= 0x2600 && r <= 0x27BF, // misc symbols and dingbats (✅ ❌ ⚠ ✨)
+		r >= 0x2300 && r <= 0x23FF, // technical symbols (⌨ ⏱ ⏸)
+		r >= 0x2B00 && r <= 0x2BFF, // arrows and stars (⭐)
+		r >= 0x25A0 && r <= 0x25FF, // geometric shapes (▶ ●)
+		r == 0x2139,                // ℹ
+		r == 0xFE0F, r == 0x200D:   // variation selector and zero-width joiner
+		return true
+	}
+	return unicode.Is(unicode.Variation_Selector, r)
+}
+
+// Message is text written into the program, like "✅ Saved". Only messages
+// are translated and lose their emoji in plain mode; data such as transcripts
+// and log lines is printed as it is. String literals convert to Message on
+// their own, so passing a string variable to Println doesn't compile.
+type Message string
+
+// Printf translates format, strips it through Text and formats like fmt.Printf.
+// The arguments are data and are printed unchanged.
+func Printf(format Message, a ...any) {
+	fmt.Printf(message(format), a...)
+}
+
+// Println translates messages and prints them like fmt.Println through Text
+func Println(messages ...Message) {
+	fmt.Println(translate(messages)...)
+}
+
+// Print translates messages and prints them like fmt.Print through Text
+func Print(messages ...Message) {
+	fmt.Print(translate(messages)...)
+}
+
+// Fprintf translates format, strips it through Text and formats like
+// fmt.Fprintf to w. The arguments are data and are written unchanged.
+func Fprintf(w io.Writer, format Message, a ...any) {
+	fmt.Fprintf(w, message(format), a...)
+}
+
+// Fprintln translates messages and prints them like fmt.Fprintln to w through Text
+func Fprintln(w io.Writer, messages ...Message) {
+	fmt.Fprintln(w, translate(messages)...)
+}
+
+// message returns m translated and stripped for plain mode
+func message(m Message) string {
+	return Text(i18n.T(string(m)))
+}
+
+func translate(messages []Message) []any {
+	translated := make([]any, len(messages))
+	for i, m := range messages {
+		translated[i] = message(m)
+	}
+	return translated
+}
@@ -0,0 +1,231 @@
+// Package tracing records each dictation session as an OpenTelemetry trace,
+// with a span per pipeline stage, and sends it to a local collector over
+// OTLP/HTTP JSON
+package tracing
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultEndpoint is where a local collector receives OTLP/HTTP traces
+const DefaultEndpoint = "http://localhost:4318/v1/traces"
+
+// exportTimeout bounds sending one trace, so a missing collector only costs a
+// log line
+const exportTimeout = 5 * time.Second
+
+var (
+	endpointMutex sync.RWMutex
+	endpoint      string // "" while tracing is off
+	client        = &http.Client{Timeout: exportTimeout}
+)
+
+// Enable starts sending traces to the collector named by
+// OTEL_EXPORTER_OTLP_ENDPOINT, or to DefaultEndpoint, and returns where
+func Enable() string {
+	target := DefaultEndpoint
+	if base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); base != "" {
+		target = strings.TrimRight(base, "/") + "/v1/traces"
+	}
+
+	endpointMutex.Lock()
+	endpoint = target
+	endpointMutex.Unlock()
+	return target
+}
+
+func currentEndpoint() string {
+	endpointMutex.RLock()
+	defer endpointMutex.RUnlock()
+	return endpoint
+}
+
+// span is one finished or running span of a trace
+type span struct {
+	id     string
+	parent string
+	name   string
+	start  time.Time
+	end    time.Time
+	attrs  map[string]any
+}
+
+// Trace is one session: a root span with a child span per stage, one stage
+// at a time. A nil Trace, returned while tracing is off, ignores every call.
+type Trace struct {
+	mutex sync.Mutex
+	id    string
+	root  *span
+	stage *span
+	spans []*span
+	ended bool
+}
+
+// NewTrace starts a trace whose root span is called name, or returns nil if
+// tracing is off
+func NewTrace(name string) *Trace {
+	if currentEndpoint() == "" {
+		return nil
+	}
+	root := &span{id: randomID(8), name: name, start: time.Now(), attrs: map[string]any{}}
+	return &Trace{id: randomID(16), root: root, spans: []*span{root}}
+}
+
+// Stage ends the running stage and starts the next one
+func (t *Trace) Stage(name string) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.ended {
+		return
+	}
+
+	now := time.Now()
+	if t.stage != nil {
+		t.stage.end = now
+	}
+	t.stage = &span{id: randomID(8), parent: t.root.id, name: name, start: now, attrs: map[string]any{}}
+	t.spans = append(t.spans, t.stage)
+}
+
+// SetAttr sets an attribute on the running stage, or on the root span before
+// the first stage
+func (t *Trace) SetAttr(key string, value any) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.stage != nil {
+		t.stage.attrs[key] = value
+	} else {
+		t.root.attrs[key] = value
+	}
+}
+
+// SetRootAttr sets an attribute on the root span
+func (t *Trace) SetRootAttr(key string, value any) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.root.attrs[key] = value
+}
+
+// End ends the running stage and the root span and sends the trace in the
+// background. Only the first call counts.
+func (t *Trace) End() {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	if t.ended {
+		t.mutex.Unlock()
+		return
+	}
+	t.ended = true
+	now := time.Now()
+	if t.stage != nil {
+		t.stage.end = now
+	}
+	t.root.end = now
+	data, err := t.encode()
+	t.mutex.Unlock()
+
+	if err != nil {
+		log.Printf("[TRACING] Failed to encode trace: %v", err)
+		return
+	}
+	go send(data)
+}
+
+// encode returns the trace as an OTLP/HTTP JSON request. Called with mutex held.
+func (t *Trace) encode() ([]byte, error) {
+	spans := make([]map[string]any, 0, len(t.spans))
+	for _, s := range t.spans {
+		encoded := map[string]any{
+			"traceId":           t.id,
+			"spanId":            s.id,
+			"name":              s.name,
+			"kind":              1, // SPAN_KIND_INTERNAL
+			"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+			"attributes":        encodeAttrs(s.attrs),
+		}
+		if s.parent != "" {
+			encoded["parentSpanId"] = s.parent
+		}
+		spans = append(spans, encoded)
+	}
+
+	return json.Marshal(map[string]any{
+		"resourceSpans": []any{map[string]any{
+			"resource": map[string]any{
+				"attributes": encodeAttrs(map[string]any{"service.name": "t2"}),
+			},
+			"scopeSpans": []any{map[string]any{
+				"scope": map[string]any{"name": "github.com/bezmoradi/t2"},
+				"spans": spans,
+			}},
+		}},
+	})
+}
+
+// encodeAttrs converts attributes to OTLP key/value pairs
+func encodeAttrs(attrs map[string]any) []map[string]any {
+	encoded := make([]map[string]any, 0, len(attrs))
+	for key, value := range attrs {
+		var v map[string]any
+		switch value := value.(type) {
+		case bool:
+			v = map[string]any{"boolValue": value}
+		case int:
+			v = map[string]any{"intValue": strconv.Itoa(value)}
+		case int64:
+			v = map[string]any{"intValue": strconv.FormatInt(value, 10)}
+		case float64:
+			v = map[string]any{"doubleValue": value}
+		default:
+			v = map[string]any{"stringValue": fmt.Sprint(value)}
+		}
+		encoded = append(encoded, map[string]any{"key": key, "value": v})
+	}
+	return encoded
+}
+
+func send(data []byte) {
+	target := currentEndpoint()
+	if target == "" {
+		return
+	}
+	resp, err := client.Post(target, "application/json", bytes.NewReader(data))
+	if err != nil {
+		log.Printf("[TRACING] Failed to send trace: %v", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Printf("[TRACING] %s returned %s", target, resp.Status)
+	}
+}
+
+// randomID returns n random bytes in hex, as OTLP JSON expects trace and span IDs
+func randomID(n int) string {
+	id := make([]byte, n)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -1,18 +1,44 @@
 package transcription
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/bezmoradi/t2/internal/session"
+	"github.com/bezmoradi/t2/internal/supervisor"
 	"github.com/gorilla/websocket"
 )
 
 const (
 	assemblyAIStreamURL = "wss://streaming.assemblyai.com/v3/ws"
+
+	// multilingualModel is the streaming model that can detect the spoken language
+	multilingualModel = "universal-streaming-multilingual"
+
+	// ProviderName identifies this client in recorded session metrics
+	ProviderName = "assemblyai"
+
+	// streamBytesPerSecond is 16kHz mono PCM16
+	streamBytesPerSecond = 16000 * 2
+
+	// writeTimeout bounds every write so a stalled network can't hold wsMutex
+	writeTimeout = 2 * time.Second
+	// pongWait is how long a connection may go without hearing from the server
+	pongWait = 30 * time.Second
+	// pingInterval keeps idle connections alive and proves they still work
+	pingInterval = 10 * time.Second
+
+	// idleSilenceBytes is 100ms of silence sent every pingInterval in persistent sessions
+	idleSilenceBytes = streamBytesPerSecond / 10
 )
 
 // AssemblyAI Streaming Message Types
@@ -29,6 +55,8 @@ type TurnMessage struct {
 	EndOfTurn           bool    `json:"end_of_turn"`
 	Transcript          string  `json:"transcript"`
 	EndOfTurnConfidence float64 `json:"end_of_turn_confidence"`
+	LanguageCode        string  `json:"language_code,omitempty"`       // Only with language detection
+	LanguageConfidence  float64 `json:"language_confidence,omitempty"` // Only with language detection
 }
 
 type StreamingConfig struct {
@@ -36,6 +64,28 @@ type StreamingConfig struct {
 	FormatTurns bool `json:"format_turns"`
 }
 
+// TurnDetection tunes when the provider decides a turn has ended. Zero values
+// keep the provider's defaults.
+type TurnDetection struct {
+	ConfidenceThreshold float64 // End-of-turn confidence (0-1) needed to end a turn after a short silence
+	MinSilenceMs        int     // Silence before a confident turn ends
+	MaxSilenceMs        int     // Silence that ends a turn regardless of confidence
+}
+
+// Validate reports settings the provider would reject
+func (t TurnDetection) Validate() error {
+	if t.ConfidenceThreshold < 0 || t.ConfidenceThreshold > 1 {
+		return fmt.Errorf("end-of-turn confidence must be between 0 and 1, got %g", t.ConfidenceThreshold)
+	}
+	if t.MinSilenceMs < 0 || t.MaxSilenceMs < 0 {
+		return fmt.Errorf("turn silences can't be negative")
+	}
+	if t.MinSilenceMs > 0 && t.MaxSilenceMs > 0 && t.MinSilenceMs > t.MaxSilenceMs {
+		return fmt.Errorf("minimum turn silence (%dms) is longer than the maximum (%dms)", t.MinSilenceMs, t.MaxSilenceMs)
+	}
+	return nil
+}
+
 type AudioMessage struct {
 	AudioData string `json:"audio_data"`
 }
@@ -43,22 +93,117 @@ type AudioMessage struct {
 type Client struct {
 	wsConn              *websocket.Conn
 	wsMutex             sync.Mutex
-	transcriptCallback  func(string, bool, bool, float64) // transcript, isComplete, endOfTurn, confidence
+	stopConn            context.CancelFunc                // stops the response handler and keepAlive of wsConn
+	ctx                 context.Context                   // From Connect; cancelling it closes the client
+	stopClosing         func() bool                       // stops closing the client when a replaced ctx is cancelled
+	transcriptCallback  func(string, int, bool, bool, float64) // transcript, turnOrder, isComplete, endOfTurn, confidence
 	connectionCallback  func(bool)                        // connected
 	terminationCallback func()                            // called when session terminates
 	chunkCount          int                               // for audio logging
 	lastChunkSize       int                               // for audio logging
-	connectionHealth    int                               // tracks connection quality (0-100)
-	lastConnectionTime  time.Time                         // when connection was established
-	sessionCount        int                               // number of sessions since connection
+	sessionChunks       int                               // chunks successfully sent this recording
+	sessionBytes        int                               // audio bytes successfully sent this recording
 	failedSessions      int                               // consecutive failed sessions
+	standbyConn         *websocket.Conn                   // pre-established spare connection
+	standbyAPIKey       string                            // non-empty when standby is enabled
+	standbyDialing      bool                              // a standby dial is in flight
+	formatTurns         bool                              // request punctuated, cased turns
+	detectLanguage      bool                              // use the multilingual model and report each turn's language
+	sessionLanguage     string                            // language of the last turn this recording, when detected
+	turnDetection       TurnDetection                     // end-of-turn tuning sent when connecting
+	lastActivity        time.Time                         // last message or pong read from the active connection
+	state               ConnectionState                   // see state.go
+	backoff             time.Duration                     // current retry delay while in Backoff
+	retryAt             time.Time                         // automatic dials wait until then
+	events              chan StateEvent
+	subscribers         []func(StateEvent)
+	subscribersMutex    sync.Mutex
+	persistent          bool                              // keep the session open between recordings
+	endTurnPending      bool                              // EndTurn was sent, the next final turn ends the recording
+	streamURL           string                            // WebSocket endpoint, overridable for gateways and mock servers
+	proxyURL            *url.URL                          // Explicit proxy; nil uses the environment
+	sessionID           string                            // Tags log lines with the current recording
 }
 
-func NewClient(transcriptCallback func(string, bool, bool, float64), connectionCallback func(bool)) *Client {
-	return &Client{
+func NewClient(transcriptCallback func(string, int, bool, bool, float64), connectionCallback func(bool)) *Client {
+	c := &Client{
 		transcriptCallback: transcriptCallback,
 		connectionCallback: connectionCallback,
-		connectionHealth:   100, // Start with perfect health
+		formatTurns:        true,
+		streamURL:          assemblyAIStreamURL,
+		events:             make(chan StateEvent, stateEventBuffer),
+		ctx:                context.Background(),
+	}
+	go c.dispatchEvents()
+	return c
+}
+
+// SetStreamURL points the client at a different streaming endpoint, e.g. a
+// regional endpoint, a self-hosted gateway or a mock server in tests; "" uses
+// AssemblyAI's. It takes effect on the next connection; returns true if the
+// setting changed.
+func (c *Client) SetStreamURL(streamURL string) bool {
+	if streamURL == "" {
+		streamURL = assemblyAIStreamURL
+	}
+
+	c.wsMutex.Lock()
+	changed := c.streamURL != streamURL
+	c.streamURL = streamURL
+	c.wsMutex.Unlock()
+
+	if changed {
+		c.refreshStandby()
+	}
+	return changed
+}
+
+// SetProxy routes connections through an http:// or socks5:// proxy; ""
+// falls back to HTTPS_PROXY/HTTP_PROXY from the environment. It takes effect
+// on the next connection; returns true if the setting changed.
+func (c *Client) SetProxy(proxyURL string) (bool, error) {
+	var parsed *url.URL
+	if proxyURL != "" {
+		var err error
+		parsed, err = url.Parse(proxyURL)
+		if err != nil {
+			return false, fmt.Errorf("invalid proxy URL: %v", err)
+		}
+		if parsed.Scheme != "http" && parsed.Scheme != "socks5" {
+			return false, fmt.Errorf("unsupported proxy scheme %q (must be http or socks5)", parsed.Scheme)
+		}
+	}
+
+	c.wsMutex.Lock()
+	changed := proxyString(c.proxyURL) != proxyString(parsed)
+	c.proxyURL = parsed
+	c.wsMutex.Unlock()
+
+	if changed {
+		c.refreshStandby()
+	}
+	return changed, nil
+}
+
+func proxyString(proxyURL *url.URL) string {
+	if proxyURL == nil {
+		return ""
+	}
+	return proxyURL.String()
+}
+
+// refreshStandby replaces a standby dialed with old connection settings
+func (c *Client) refreshStandby() {
+	c.wsMutex.Lock()
+	stale := c.standbyConn != nil
+	if stale {
+		c.standbyConn.Close()
+		c.standbyConn = nil
+	}
+	c.wsMutex.Unlock()
+
+	if stale {
+		go c.fillStandby()
 	}
 }
 
@@ -66,50 +211,290 @@ func (c *Client) SetTerminationCallback(callback func()) {
 	c.terminationCallback = callback
 }
 
-func (c *Client) Connect(apiKey string) error {
+// SetFormatTurns controls automatic punctuation and casing. It takes effect on
+// the next connection; returns true if the setting changed.
+func (c *Client) SetFormatTurns(enabled bool) bool {
+	c.wsMutex.Lock()
+	changed := c.formatTurns != enabled
+	c.formatTurns = enabled
+	c.wsMutex.Unlock()
+
+	// A standby dialed with the old setting is no longer usable
+	if changed {
+		c.refreshStandby()
+	}
+	return changed
+}
+
+// SetLanguageDetection switches to the multilingual model, which detects the
+// spoken language of each turn instead of assuming English. It takes effect on
+// the next connection; returns true if the setting changed.
+func (c *Client) SetLanguageDetection(enabled bool) bool {
+	c.wsMutex.Lock()
+	changed := c.detectLanguage != enabled
+	c.detectLanguage = enabled
+	c.wsMutex.Unlock()
+
+	if changed {
+		c.refreshStandby()
+	}
+	return changed
+}
+
+// SetTurnDetection tunes end-of-turn detection, e.g. to stop slow speech from
+// being split into several turns. It takes effect on the next connection;
+// returns true if the setting changed.
+func (c *Client) SetTurnDetection(turnDetection TurnDetection) (bool, error) {
+	if err := turnDetection.Validate(); err != nil {
+		return false, err
+	}
+
+	c.wsMutex.Lock()
+	changed := c.turnDetection != turnDetection
+	c.turnDetection = turnDetection
+	c.wsMutex.Unlock()
+
+	if changed {
+		c.refreshStandby()
+	}
+	return changed, nil
+}
+
+// Connect opens the streaming connection. Cancelling ctx closes it, along
+// with its keep-alive and any standby connection.
+func (c *Client) Connect(ctx context.Context, apiKey string) error {
+	c.wsMutex.Lock()
+	c.setState(Connecting, nil)
+	if c.ctx != ctx {
+		if c.stopClosing != nil {
+			c.stopClosing()
+		}
+		c.ctx = ctx
+		c.stopClosing = context.AfterFunc(ctx, func() {
+			c.DisableStandby()
+			c.Close()
+		})
+	}
+	c.wsMutex.Unlock()
+
+	conn, err := c.dial(ctx, apiKey)
+	if err != nil {
+		c.wsMutex.Lock()
+		c.setState(Backoff, err)
+		c.wsMutex.Unlock()
+		return err
+	}
+
+	c.wsMutex.Lock()
+	old := c.wsConn
+	c.wsConn = conn
+	c.endTurnPending = false // The old connection's final turn is gone with it
+	c.stopHandlers()
+	c.wsMutex.Unlock()
+
+	// A connection that went quiet is replaced rather than reused
+	if old != nil {
+		old.Close()
+	}
+
+	c.activate(conn)
+	return nil
+}
+
+// dial opens a new streaming WebSocket without installing it as the active connection
+func (c *Client) dial(ctx context.Context, apiKey string) (*websocket.Conn, error) {
 
 	// Create WebSocket URL with query parameters (matching JS example)
-	u, err := url.Parse(assemblyAIStreamURL)
+	c.wsMutex.Lock()
+	streamURL := c.streamURL
+	proxyURL := c.proxyURL
+	c.wsMutex.Unlock()
+
+	u, err := url.Parse(streamURL)
 	if err != nil {
-		return fmt.Errorf("error parsing WebSocket URL: %v", err)
+		return nil, fmt.Errorf("error parsing WebSocket URL: %v", err)
 	}
 
 	// Add required query parameters (matching Python example exactly)
 	query := u.Query()
 	query.Set("sample_rate", "16000") // Use underscore format like Python
-	query.Set("format_turns", "true") // Use underscore format like Python
+	c.wsMutex.Lock()
+	formatTurns := c.formatTurns
+	turnDetection := c.turnDetection
+	detectLanguage := c.detectLanguage
+	c.wsMutex.Unlock()
+	query.Set("format_turns", strconv.FormatBool(formatTurns)) // Use underscore format like Python
+	if detectLanguage {
+		query.Set("speech_model", multilingualModel)
+		query.Set("language_detection", "true")
+	}
+	if turnDetection.ConfidenceThreshold > 0 {
+		query.Set("end_of_turn_confidence_threshold", strconv.FormatFloat(turnDetection.ConfidenceThreshold, 'f', -1, 64))
+	}
+	if turnDetection.MinSilenceMs > 0 {
+		query.Set("min_end_of_turn_silence_when_confident", strconv.Itoa(turnDetection.MinSilenceMs))
+	}
+	if turnDetection.MaxSilenceMs > 0 {
+		query.Set("max_turn_silence", strconv.Itoa(turnDetection.MaxSilenceMs))
+	}
 	u.RawQuery = query.Encode()
 
 	// Create headers with authorization (just API key, no "Bearer")
 	headers := make(map[string][]string)
 	headers["Authorization"] = []string{apiKey}
 
-
-	// Establish WebSocket connection
-	c.wsMutex.Lock()
-	c.wsConn, _, err = websocket.DefaultDialer.Dial(u.String(), headers)
-	c.wsMutex.Unlock()
-
+	// Establish WebSocket connection, through the configured proxy or the
+	// one from HTTPS_PROXY/HTTP_PROXY
+	dialer := *websocket.DefaultDialer
+	if proxyURL != nil {
+		dialer.Proxy = http.ProxyURL(proxyURL)
+	}
+	conn, _, err := dialer.DialContext(ctx, u.String(), headers)
 	if err != nil {
-		return fmt.Errorf("error connecting to AssemblyAI: %v", err)
+		if proxyURL != nil {
+			return nil, fmt.Errorf("error connecting to AssemblyAI via proxy %s: %v", proxyURL.Redacted(), err)
+		}
+		return nil, fmt.Errorf("error connecting to AssemblyAI: %v", err)
 	}
 
+	return conn, nil
+}
 
+// activate resets health tracking and starts reading from a freshly installed
+// connection. Its response handler and keepAlive run until stopHandlers is
+// called, when the connection is closed, replaced or dropped, or until
+// Connect's context is cancelled.
+func (c *Client) activate(conn *websocket.Conn) {
 	// Update connection health tracking
-	c.lastConnectionTime = time.Now()
-	c.connectionHealth = 100
-	c.sessionCount = 0
+	c.wsMutex.Lock()
+	ctx, cancel := context.WithCancel(c.ctx)
+	if c.wsConn != conn {
+		// Closed or replaced before it got going
+		c.wsMutex.Unlock()
+		cancel()
+		return
+	}
+	c.stopHandlers()
+	c.stopConn = cancel
 	c.failedSessions = 0
+	c.lastActivity = time.Now()
+	c.setState(Ready, nil)
+	c.wsMutex.Unlock()
+
+	// A dead connection shows up as a read timeout instead of a failed write
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		c.markActivity(conn)
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	conn.SetPingHandler(func(data string) error {
+		c.markActivity(conn)
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(writeTimeout))
+		if err == websocket.ErrCloseSent {
+			return nil
+		}
+		return err
+	})
 
 	// Start listening for responses in a goroutine
-	go c.handleResponses()
+	go supervisor.RunOrExit("response handler", func() {
+		c.handleResponses(ctx, conn)
+	})
+	go c.keepAlive(ctx, conn)
 
 	// Notify connection callback
 	if c.connectionCallback != nil {
 		c.connectionCallback(true)
 	}
+}
 
-	return nil
+// stopHandlers stops the response handler and keepAlive of the active
+// connection. Must be called with wsMutex held whenever wsConn changes.
+func (c *Client) stopHandlers() {
+	if c.stopConn != nil {
+		c.stopConn()
+		c.stopConn = nil
+	}
+}
+
+// EnableStandby keeps one spare connection open so a refresh doesn't have to
+// wait for a new handshake at the start of a dictation
+func (c *Client) EnableStandby(apiKey string) {
+	c.wsMutex.Lock()
+	c.standbyAPIKey = apiKey
+	c.wsMutex.Unlock()
+
+	go c.fillStandby()
+}
+
+// fillStandby dials a standby connection if standby is enabled and none is ready
+func (c *Client) fillStandby() {
+	c.wsMutex.Lock()
+	apiKey, ctx := c.standbyAPIKey, c.ctx
+	needed := apiKey != "" && c.standbyConn == nil && !c.standbyDialing && time.Now().After(c.retryAt) && ctx.Err() == nil
+	if needed {
+		c.standbyDialing = true
+	}
+	c.wsMutex.Unlock()
+
+	if !needed {
+		return
+	}
+
+	conn, err := c.dial(ctx, apiKey)
+
+	c.wsMutex.Lock()
+	defer c.wsMutex.Unlock()
+	c.standbyDialing = false
+	if err != nil {
+		return
+	}
+	if c.standbyAPIKey == "" {
+		// Standby was disabled (or the client closed) while we were dialing
+		conn.Close()
+		return
+	}
+	c.standbyConn = conn
+}
+
+// SwapToStandby replaces the active connection with the standby one.
+// Returns false if no live standby connection was available.
+func (c *Client) SwapToStandby() bool {
+	c.wsMutex.Lock()
+	standby := c.standbyConn
+	c.standbyConn = nil
+	if standby == nil {
+		c.wsMutex.Unlock()
+		return false
+	}
+
+	// The server may have dropped an idle standby - make sure it's still usable
+	if err := standby.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(writeTimeout)); err != nil {
+		standby.Close()
+		c.wsMutex.Unlock()
+		go c.fillStandby()
+		return false
+	}
+
+	old := c.wsConn
+	c.wsConn = standby
+	c.endTurnPending = false
+	c.stopHandlers()
+	c.chunkCount = 0
+	c.lastChunkSize = 0
+	c.wsMutex.Unlock()
+
+	if old != nil {
+		old.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeTimeout))
+		old.Close()
+	}
+
+	c.activate(standby)
+
+	// Immediately start preparing the next standby
+	go c.fillStandby()
+	return true
 }
 
 func (c *Client) SendAudio(audioData []byte) error {
@@ -123,15 +508,32 @@ func (c *Client) SendAudio(audioData []byte) error {
 	c.chunkCount++
 
 	// Send raw audio bytes directly (not JSON, not base64)
+	c.wsConn.SetWriteDeadline(time.Now().Add(writeTimeout))
 	err := c.wsConn.WriteMessage(websocket.BinaryMessage, audioData)
+	if err == nil {
+		c.sessionChunks++
+		c.sessionBytes += len(audioData)
+		if c.sessionChunks == 1 {
+			session.Printf(c.sessionID, "[CLIENT] First audio chunk sent")
+		}
+		if c.state == Ready {
+			// A new recording; the final turn of the last one may still be on its way
+			c.setState(Streaming, nil)
+		}
+	}
 
 
-	// If we get a close error, the connection is no longer usable
+	// If we get a close error or a timed-out write, the connection is no longer usable
 	if err != nil && (websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) ||
+		isTimeout(err) ||
 		strings.Contains(err.Error(), "websocket: close sent") ||
 		strings.Contains(err.Error(), "use of closed network connection")) {
 		// Clean up the connection since it's no longer usable
+		session.Printf(c.sessionID, "[CLIENT] Connection lost sending audio: %v", err)
+		c.stopHandlers()
+		c.wsConn.Close()
 		c.wsConn = nil
+		c.setState(Disconnected, nil)
 	}
 
 	return err
@@ -143,28 +545,79 @@ func (c *Client) Terminate() error {
 
 
 	if c.wsConn != nil {
+		c.setState(Terminating, nil)
+
 		// Send termination message to AssemblyAI (like Python example)
 		terminateMessage := map[string]string{"type": "Terminate"}
 		if jsonData, err := json.Marshal(terminateMessage); err == nil {
-			err = c.wsConn.WriteMessage(websocket.TextMessage, jsonData)
+			c.wsConn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err = c.wsConn.WriteMessage(websocket.TextMessage, jsonData); err != nil {
+				session.Printf(c.sessionID, "[CLIENT] Failed to send Terminate: %v", err)
+			} else {
+				session.Printf(c.sessionID, "[CLIENT] Terminate sent after %d audio chunks", c.sessionChunks)
+			}
 		} else {
 			}
 	} else {
-		}
+		session.Printf(c.sessionID, "[CLIENT] Not connected, nothing to terminate")
+	}
 	return nil
 }
 
+// SetPersistentSession keeps the streaming session open between recordings,
+// sending silence while idle so the server doesn't time it out
+func (c *Client) SetPersistentSession(enabled bool) {
+	c.wsMutex.Lock()
+	defer c.wsMutex.Unlock()
+	c.persistent = enabled
+}
+
+// EndTurn asks the server to finalize the current turn without ending the
+// session, so the connection can be reused for the next recording. The final
+// turn is reported through the termination callback in place of Termination.
+func (c *Client) EndTurn() error {
+	c.wsMutex.Lock()
+	defer c.wsMutex.Unlock()
+
+	if c.wsConn == nil {
+		return nil
+	}
+
+	c.endTurnPending = true
+	c.wsConn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err := c.wsConn.WriteMessage(websocket.TextMessage, []byte(`{"type":"ForceEndpoint"}`)); err != nil {
+		return err
+	}
+	session.Printf(c.sessionID, "[CLIENT] End of turn requested after %d audio chunks", c.sessionChunks)
+	c.setState(Ready, nil)
+	return nil
+}
+
+// DisableStandby stops maintaining a standby connection and closes any open one
+func (c *Client) DisableStandby() {
+	c.wsMutex.Lock()
+	defer c.wsMutex.Unlock()
+
+	c.standbyAPIKey = ""
+	if c.standbyConn != nil {
+		c.standbyConn.Close()
+		c.standbyConn = nil
+	}
+}
+
 func (c *Client) Close() {
 	c.wsMutex.Lock()
 	defer c.wsMutex.Unlock()
 
 
+	c.stopHandlers()
 	if c.wsConn != nil {
 		// Send close frame to AssemblyAI before closing
-		c.wsConn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		c.wsConn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeTimeout))
 		c.wsConn.Close()
 		c.wsConn = nil
 	}
+	c.setState(Disconnected, nil)
 
 	// Reset chunk counters for next session
 	c.chunkCount = 0
@@ -176,50 +629,45 @@ func (c *Client) Close() {
 	}
 }
 
+// IsConnected reports whether the active connection has been heard from
+// recently. keepAlive pings keep a healthy connection's activity fresh, and
+// handleResponses drops dead ones, so this never has to touch the socket.
 func (c *Client) IsConnected() bool {
 	c.wsMutex.Lock()
 	defer c.wsMutex.Unlock()
 
-	if c.wsConn == nil {
-		return false
-	}
-
-	// Test if connection is still alive with a simple ping
-	// If this fails, the connection was closed by the server
-	err := c.wsConn.WriteMessage(websocket.PingMessage, []byte{})
-	if err != nil {
-		// Connection is dead, clean it up
-		c.wsConn.Close()
-		c.wsConn = nil
-		c.connectionHealth = 0
-		return false
-	}
-	return true
+	return c.wsConn != nil && time.Since(c.lastActivity) < pongWait
 }
 
-func (c *Client) handleResponses() {
-	for {
-		c.wsMutex.Lock()
-		conn := c.wsConn
-		c.wsMutex.Unlock()
+// markActivity records that the server was heard from on conn
+func (c *Client) markActivity(conn *websocket.Conn) {
+	c.wsMutex.Lock()
+	defer c.wsMutex.Unlock()
 
-		if conn == nil {
-			break
-		}
+	if c.wsConn == conn {
+		c.lastActivity = time.Now()
+	}
+}
 
+// handleResponses reads conn until ctx is cancelled, which happens when conn
+// is closed, replaced or dropped, or until the read fails
+func (c *Client) handleResponses(ctx context.Context, conn *websocket.Conn) {
+	// Stop once this connection has been closed or swapped out
+	for ctx.Err() == nil {
 		_, message, err := conn.ReadMessage()
 		if err != nil {
-			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
-				return
-			}
-			// Suppress network connection closed errors during shutdown
-			if strings.Contains(err.Error(), "use of closed network connection") ||
-				strings.Contains(err.Error(), "connection reset by peer") {
+			// A read interrupted by closing the connection ourselves isn't news
+			if ctx.Err() != nil {
 				return
 			}
+			// Closed by the server, timed out or reset - either way it's gone
+			session.Printf(c.currentSessionID(), "[CLIENT] Connection closed: %v", err)
+			c.dropConnection(conn)
 			return
 		}
 
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		c.markActivity(conn)
 
 		// Parse the message
 		var baseMsg map[string]any
@@ -233,7 +681,7 @@ func (c *Client) handleResponses() {
 			case "Begin":
 
 			case "Turn":
-				if transcript, ok := baseMsg["transcript"].(string); ok && transcript != "" {
+				if transcript, ok := baseMsg["transcript"].(string); ok {
 					// Check if this is a formatted turn (final) or partial
 					isComplete := false
 					if formatted, ok := baseMsg["turn_is_formatted"].(bool); ok && formatted {
@@ -246,19 +694,52 @@ func (c *Client) handleResponses() {
 						endOfTurn = eot
 					}
 
+					// Without formatting no turn is ever marked formatted, so end of turn is final
+					c.wsMutex.Lock()
+					if !c.formatTurns && endOfTurn {
+						isComplete = true
+					}
+					// The language is only reported on final turns; the latest one wins
+					if language, ok := baseMsg["language_code"].(string); ok && language != "" {
+						c.sessionLanguage = language
+					}
+					sessionID := c.sessionID
+					c.wsMutex.Unlock()
+
 					confidence := 0.0
 					if conf, ok := baseMsg["end_of_turn_confidence"].(float64); ok {
 						confidence = conf
 					}
 
+					// Turns are numbered across the whole streaming session
+					turnOrder := 0
+					if order, ok := baseMsg["turn_order"].(float64); ok {
+						turnOrder = int(order)
+					}
+					session.Printf(sessionID, "[CLIENT] Turn %d received: %d words (formatted: %v, end of turn: %v, confidence %.2f)",
+						turnOrder, len(strings.Fields(transcript)), isComplete, endOfTurn, confidence)
+
 
 					// Send transcript to callback with completion indicators
-					if c.transcriptCallback != nil {
-						c.transcriptCallback(transcript, isComplete, endOfTurn, confidence)
+					if c.transcriptCallback != nil && transcript != "" {
+						c.transcriptCallback(transcript, turnOrder, isComplete, endOfTurn, confidence)
+					}
+
+					// In a persistent session the forced final turn is the end of the
+					// recording, even an empty one when nothing was said
+					c.wsMutex.Lock()
+					turnEnded := c.endTurnPending && (isComplete || endOfTurn && transcript == "")
+					if turnEnded {
+						c.endTurnPending = false
+					}
+					c.wsMutex.Unlock()
+					if turnEnded && c.terminationCallback != nil {
+						c.terminationCallback()
 					}
 				}
 
 			case "Termination":
+				session.Printf(c.currentSessionID(), "[CLIENT] Termination received")
 				if c.terminationCallback != nil {
 					c.terminationCallback()
 				}
@@ -270,57 +751,136 @@ func (c *Client) handleResponses() {
 	}
 }
 
-// ConnectionNeedsRefresh returns true if connection should be refreshed due to degradation
-func (c *Client) ConnectionNeedsRefresh() bool {
+// keepAlive pings the server until ctx is cancelled, as conn is closed or
+// replaced or by Connect's context, so an idle connection is kept open and
+// a dead one times out its read deadline
+func (c *Client) keepAlive(ctx context.Context, conn *websocket.Conn) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// WriteControl is safe alongside the audio writer and has its own deadline
+		if err := conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(writeTimeout)); err != nil {
+			c.dropConnection(conn)
+			return
+		}
+
+		if err := c.sendIdleSilence(conn); err != nil {
+			c.dropConnection(conn)
+			return
+		}
+	}
+}
+
+// sendIdleSilence feeds a persistent session a little silence between
+// recordings, so the server sees audio and keeps the session open
+func (c *Client) sendIdleSilence(conn *websocket.Conn) error {
 	c.wsMutex.Lock()
 	defer c.wsMutex.Unlock()
 
-	// Force refresh if health is very low
-	if c.connectionHealth < 20 {
-		return true
+	if !c.persistent || c.wsConn != conn || c.state != Ready {
+		return nil
 	}
 
-	// Force refresh if too many consecutive failed sessions
-	if c.failedSessions >= 3 {
-		return true
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return conn.WriteMessage(websocket.BinaryMessage, make([]byte, idleSilenceBytes))
+}
+
+// dropConnection forgets conn if it's still the active connection, so the
+// next recording reconnects instead of writing into a dead socket
+func (c *Client) dropConnection(conn *websocket.Conn) {
+	c.wsMutex.Lock()
+	dropped := c.wsConn == conn
+	if dropped {
+		c.wsConn = nil
+		c.stopHandlers()
+		c.setState(Disconnected, nil)
 	}
+	c.wsMutex.Unlock()
 
-	// Force refresh if connection is very old and showing signs of degradation
-	connectionAge := time.Since(c.lastConnectionTime)
-	if connectionAge > 10*time.Minute && c.connectionHealth < 60 {
-		return true
+	if !dropped {
+		return
+	}
+	conn.Close()
+	if c.connectionCallback != nil {
+		c.connectionCallback(false)
 	}
+}
 
-	return false
+// isTimeout reports whether err is a network timeout, e.g. from a write deadline
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
-// ReportSessionSuccess improves connection health
-func (c *Client) ReportSessionSuccess() {
+// SetSessionID tags the client's log lines with the recording it is streaming
+func (c *Client) SetSessionID(id string) {
 	c.wsMutex.Lock()
 	defer c.wsMutex.Unlock()
+	c.sessionID = id
+}
 
-	c.sessionCount++
-	c.failedSessions = 0 // Reset failed count on success
+func (c *Client) currentSessionID() string {
+	c.wsMutex.Lock()
+	defer c.wsMutex.Unlock()
+	return c.sessionID
+}
 
-	// Improve health but cap at 100
-	if c.connectionHealth < 100 {
-		c.connectionHealth += 10
-		if c.connectionHealth > 100 {
-			c.connectionHealth = 100
-		}
-	}
+// ResetSessionStats clears per-recording counters used for diagnostics
+func (c *Client) ResetSessionStats() {
+	c.wsMutex.Lock()
+	defer c.wsMutex.Unlock()
+	c.sessionChunks = 0
+	c.sessionBytes = 0
+	c.sessionLanguage = ""
 }
 
-// ReportSessionFailure degrades connection health
-func (c *Client) ReportSessionFailure() {
+// SessionChunks returns how many audio chunks were sent since ResetSessionStats
+func (c *Client) SessionChunks() int {
 	c.wsMutex.Lock()
 	defer c.wsMutex.Unlock()
+	return c.sessionChunks
+}
 
+// SessionLanguage returns the language detected since ResetSessionStats, e.g.
+// "de", or "" when language detection is off or nothing was transcribed
+func (c *Client) SessionLanguage() string {
+	c.wsMutex.Lock()
+	defer c.wsMutex.Unlock()
+	return c.sessionLanguage
+}
+
+// SessionAudioDuration returns how much audio was streamed since ResetSessionStats
+func (c *Client) SessionAudioDuration() time.Duration {
+	c.wsMutex.Lock()
+	defer c.wsMutex.Unlock()
+	return time.Duration(c.sessionBytes) * time.Second / streamBytesPerSecond
+}
+
+// ReportSessionSuccess resets the failure streak
+func (c *Client) ReportSessionSuccess() {
+	c.wsMutex.Lock()
+	defer c.wsMutex.Unlock()
+	c.failedSessions = 0
+}
+
+// ReportSessionFailure counts a session that produced no transcript. After
+// maxFailedSessions in a row the connection is dropped so the next recording
+// starts on a fresh one.
+func (c *Client) ReportSessionFailure() {
+	c.wsMutex.Lock()
 	c.failedSessions++
+	conn := c.wsConn
+	broken := c.failedSessions >= maxFailedSessions && conn != nil
+	c.wsMutex.Unlock()
 
-	// Degrade health
-	c.connectionHealth -= 15
-	if c.connectionHealth < 0 {
-		c.connectionHealth = 0
+	if broken {
+		c.dropConnection(conn)
 	}
 }
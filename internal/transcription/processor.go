@@ -1,28 +1,42 @@
 package transcription
 
 import (
+	"slices"
+	"strings"
 	"sync"
+	"time"
+
+	"github.com/bezmoradi/t2/internal/session"
 )
 
+// Processor collects the transcripts of one recording; every recording gets
+// a new one, so nothing can carry over from the previous session
 type Processor struct {
-	currentTranscript     string
-	lastTurnOrder         int
-	turnTranscripts       map[int]string
-	finalTranscripts      []string  // Accumulate multiple final transcripts
+	finalTurns            map[int]string // Final transcript per turn order
+	partialTurn           int            // Turn order of partialTranscript
+	partialTranscript     string         // Latest partial of a turn that isn't final yet
 	transcriptMutex       sync.Mutex
 	sessionTerminated     chan bool
-	sessionActive         bool      // Track if session is actively processing
-	resetCount            int       // Track number of resets (for debugging degradation)
 	bestPartialTranscript string    // Track best partial transcript as fallback
 	bestPartialConfidence float64   // Track confidence of best partial
+	lastConfidence        float64   // Confidence of the most recent transcript
+	transcriptsReceived   int       // Partial and final transcripts received
+	terminationReceived   bool      // Provider confirmed termination
+	lastTranscriptAt      time.Time // When the most recent transcript arrived
+	trailingSuffix        string    // Appended to every consumed transcript
+	turnPending           bool      // The latest transcript was a partial, a final one is still due
+	consumed              bool      // The session is done, later transcripts are dropped
+	sessionID             string    // Tags log lines with the session
 }
 
-func NewProcessor() *Processor {
+// NewProcessor returns a processor for the recording of session sessionID,
+// appending trailingSuffix ("", " " or "\n") to the consumed transcript
+func NewProcessor(sessionID, trailingSuffix string) *Processor {
 	return &Processor{
-		lastTurnOrder:    -1,
-		turnTranscripts:  make(map[int]string),
-		finalTranscripts: make([]string, 0),
+		finalTurns:        make(map[int]string),
 		sessionTerminated: make(chan bool, 1),
+		trailingSuffix:    trailingSuffix,
+		sessionID:         sessionID,
 	}
 }
 
@@ -30,29 +44,24 @@ func (p *Processor) ProcessTranscript(transcript string, turnOrder int, isComple
 	p.transcriptMutex.Lock()
 	defer p.transcriptMutex.Unlock()
 
+	// A turn arriving after the session was pasted would belong to nothing
+	if p.consumed {
+		session.Printf(p.sessionID, "[PROCESSOR] Dropped turn %d after the session was done", turnOrder)
+		return
+	}
 
 	// For streaming transcription, AssemblyAI sends progressive updates
-	// where each partial transcript contains the complete accumulated text
+	// where each partial transcript contains the complete text of its turn
 	if isComplete {
-		// Add final transcripts to our collection to handle multiple sessions
-		p.finalTranscripts = append(p.finalTranscripts, transcript)
-
-
-		// Build complete transcript from all final transcripts
-		completeText := ""
-		for i, finalText := range p.finalTranscripts {
-			if i > 0 {
-				completeText += " "
-			}
-			completeText += finalText
+		// A turn can be finalized twice (unformatted, then formatted) - the last one wins
+		p.finalTurns[turnOrder] = transcript
+		if p.partialTurn == turnOrder {
+			p.partialTranscript = ""
 		}
-		p.currentTranscript = completeText
-
-
-		// No completion signaling - rely on termination protocol instead
-	} else {
-		// For partial transcripts, just update current (will be overwritten by final)
-		p.currentTranscript = transcript
+		session.Printf(p.sessionID, "[PROCESSOR] Turn %d final: %d words", turnOrder, len(strings.Fields(transcript)))
+	} else if _, final := p.finalTurns[turnOrder]; !final {
+		p.partialTurn = turnOrder
+		p.partialTranscript = transcript
 
 		// Track best partial transcript as fallback
 		if confidence > p.bestPartialConfidence || len(transcript) > len(p.bestPartialTranscript) {
@@ -61,38 +70,53 @@ func (p *Processor) ProcessTranscript(transcript string, turnOrder int, isComple
 		}
 	}
 
-	// Store for turn tracking compatibility
-	p.turnTranscripts[turnOrder] = transcript
-
-	if turnOrder > p.lastTurnOrder {
-		p.lastTurnOrder = turnOrder
-	}
+	p.turnPending = p.partialTranscript != ""
+	p.lastConfidence = confidence
+	p.transcriptsReceived++
+	p.lastTranscriptAt = time.Now()
 }
 
-func (p *Processor) GetCurrentTranscript() string {
+// LiveTranscript returns everything said so far: the completed turns followed
+// by the turn still in progress
+func (p *Processor) LiveTranscript() string {
 	p.transcriptMutex.Lock()
 	defer p.transcriptMutex.Unlock()
-	return p.currentTranscript
+	return p.transcript()
 }
 
-func (p *Processor) Reset() {
+func (p *Processor) GetCurrentTranscript() string {
 	p.transcriptMutex.Lock()
 	defer p.transcriptMutex.Unlock()
+	return p.transcript()
+}
 
-	p.currentTranscript = ""
-	p.lastTurnOrder = -1
-	p.turnTranscripts = make(map[int]string)
-	p.finalTranscripts = make([]string, 0)
-	p.bestPartialTranscript = ""
-	p.bestPartialConfidence = 0.0
-	p.sessionActive = true
-	p.resetCount++
+// transcript joins the final turns in turn order, followed by the partial of a
+// later turn still in progress. Must be called with transcriptMutex held.
+func (p *Processor) transcript() string {
+	turns := make([]int, 0, len(p.finalTurns))
+	for turnOrder := range p.finalTurns {
+		turns = append(turns, turnOrder)
+	}
+	slices.Sort(turns)
 
-	// Drain any pending termination signals to prevent state contamination
-	select {
-	case <-p.sessionTerminated:
-	default:
+	texts := make([]string, 0, len(turns)+1)
+	for _, turnOrder := range turns {
+		texts = append(texts, p.finalTurns[turnOrder])
+	}
+	if p.partialTranscript != "" && (len(turns) == 0 || p.partialTurn > turns[len(turns)-1]) {
+		texts = append(texts, p.partialTranscript)
 	}
+	return joinTurns(texts)
+}
+
+// clearTurns forgets every transcript of the recording, so it's only consumed
+// once. Must be called with transcriptMutex held.
+func (p *Processor) clearTurns() {
+	p.finalTurns = make(map[int]string)
+	p.partialTurn = 0
+	p.partialTranscript = ""
+	p.bestPartialTranscript = ""
+	p.bestPartialConfidence = 0.0
 }
 
 func (p *Processor) WaitForTermination() chan bool {
@@ -100,6 +124,11 @@ func (p *Processor) WaitForTermination() chan bool {
 }
 
 func (p *Processor) SignalTermination() {
+	p.transcriptMutex.Lock()
+	p.terminationReceived = true
+	session.Printf(p.sessionID, "[PROCESSOR] Termination received after %d transcripts", p.transcriptsReceived)
+	p.transcriptMutex.Unlock()
+
 	select {
 	case p.sessionTerminated <- true:
 	default:
@@ -110,14 +139,43 @@ func (p *Processor) SignalTermination() {
 func (p *Processor) GetCurrentTranscriptImmediate() string {
 	p.transcriptMutex.Lock()
 	defer p.transcriptMutex.Unlock()
-	return p.currentTranscript
+	return p.transcript()
 }
 
 // HasAnyTranscript returns true if we have any transcript content (partial or final)
 func (p *Processor) HasAnyTranscript() bool {
 	p.transcriptMutex.Lock()
 	defer p.transcriptMutex.Unlock()
-	return len(p.currentTranscript) > 0
+	return p.transcript() != ""
+}
+
+// SessionStats reports how many transcripts arrived and whether termination
+// was confirmed
+func (p *Processor) SessionStats() (int, bool) {
+	p.transcriptMutex.Lock()
+	defer p.transcriptMutex.Unlock()
+	return p.transcriptsReceived, p.terminationReceived
+}
+
+// HasPendingTurn reports whether a partial transcript is still waiting for its final version
+func (p *Processor) HasPendingTurn() bool {
+	p.transcriptMutex.Lock()
+	defer p.transcriptMutex.Unlock()
+	return p.turnPending
+}
+
+// LastTranscriptTime returns when the most recent transcript arrived (zero if none)
+func (p *Processor) LastTranscriptTime() time.Time {
+	p.transcriptMutex.Lock()
+	defer p.transcriptMutex.Unlock()
+	return p.lastTranscriptAt
+}
+
+// GetConfidence returns the confidence reported with the most recent transcript
+func (p *Processor) GetConfidence() float64 {
+	p.transcriptMutex.Lock()
+	defer p.transcriptMutex.Unlock()
+	return p.lastConfidence
 }
 
 // GetBestPartialTranscript returns the best partial transcript as fallback
@@ -131,17 +189,8 @@ func (p *Processor) ConsumeTranscript() string {
 	p.transcriptMutex.Lock()
 	defer p.transcriptMutex.Unlock()
 
-	text := p.currentTranscript
-
-	// Mark session as inactive to prevent contamination
-	p.sessionActive = false
-
-	p.currentTranscript = "" // Reset for next recording
-	p.lastTurnOrder = -1
-	p.turnTranscripts = make(map[int]string)
-	p.finalTranscripts = make([]string, 0)
-	p.bestPartialTranscript = ""
-	p.bestPartialConfidence = 0.0
+	text := p.transcript()
+	p.clearTurns()
 	return text
 }
 
@@ -151,29 +200,24 @@ func (p *Processor) ConsumeTranscriptWithFallback() (string, bool) {
 	defer p.transcriptMutex.Unlock()
 
 	var text string
-	isFinal := len(p.finalTranscripts) > 0
+	isFinal := len(p.finalTurns) > 0
 
 	if isFinal {
-		// Use final transcript and add trailing space
-		text = p.currentTranscript + " "
+		// Use the final turns, plus a last turn that never got finalized, and
+		// add the configured trailing whitespace
+		text = p.transcript() + p.trailingSuffix
 	} else if len(p.bestPartialTranscript) > 0 {
 		// Use best partial as fallback
-		text = p.bestPartialTranscript + " " // Add space for consistency
+		text = p.bestPartialTranscript + p.trailingSuffix // Same suffix for consistency
 	} else {
 		// No transcript available
 		text = ""
 	}
 
-	// Mark session as inactive to prevent contamination
-	p.sessionActive = false
+	session.Printf(p.sessionID, "[PROCESSOR] Consumed %d words (final: %v, %d transcripts received)", len(strings.Fields(text)), isFinal, p.transcriptsReceived)
 
-	// Reset state for next recording
-	p.currentTranscript = ""
-	p.lastTurnOrder = -1
-	p.turnTranscripts = make(map[int]string)
-	p.finalTranscripts = make([]string, 0)
-	p.bestPartialTranscript = ""
-	p.bestPartialConfidence = 0.0
+	p.clearTurns()
+	p.consumed = true
 
 	return text, isFinal
 }
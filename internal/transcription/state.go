@@ -0,0 +1,121 @@
+package transcription
+
+import (
+	"log"
+	"time"
+)
+
+// ConnectionState is where the streaming connection is in its lifecycle
+type ConnectionState int
+
+const (
+	Disconnected ConnectionState = iota // No usable connection
+	Connecting                          // Dialing AssemblyAI
+	Ready                               // Connected and idle, ready for a recording
+	Streaming                           // Sending audio for a recording
+	Terminating                         // Waiting for the session to wrap up after Terminate
+	Backoff                             // The last connect failed, automatic retries are paused
+)
+
+const (
+	// maxFailedSessions is how many sessions in a row may come back empty
+	// before the connection is assumed broken and dropped
+	maxFailedSessions = 3
+
+	minBackoff = 1 * time.Second
+	maxBackoff = 30 * time.Second
+
+	// stateEventBuffer bounds queued events; slow subscribers miss events rather than block the client
+	stateEventBuffer = 32
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case Disconnected:
+		return "disconnected"
+	case Connecting:
+		return "connecting"
+	case Ready:
+		return "ready"
+	case Streaming:
+		return "streaming"
+	case Terminating:
+		return "terminating"
+	case Backoff:
+		return "backoff"
+	default:
+		return "unknown"
+	}
+}
+
+// StateEvent describes a connection state transition
+type StateEvent struct {
+	From ConnectionState
+	To   ConnectionState
+	Err  error // Why a connect failed, for transitions into Backoff
+	At   time.Time
+}
+
+// Subscribe registers fn to be called, in order, for every state transition.
+// Events are delivered from a separate goroutine, so fn may call back into the client.
+func (c *Client) Subscribe(fn func(StateEvent)) {
+	c.subscribersMutex.Lock()
+	defer c.subscribersMutex.Unlock()
+	c.subscribers = append(c.subscribers, fn)
+}
+
+// State returns the current connection state
+func (c *Client) State() ConnectionState {
+	c.wsMutex.Lock()
+	defer c.wsMutex.Unlock()
+	return c.state
+}
+
+// CanStream reports whether a recording can start on the current connection.
+// After Terminate, a dropped connection or a streak of failed sessions it can't.
+func (c *Client) CanStream() bool {
+	c.wsMutex.Lock()
+	defer c.wsMutex.Unlock()
+
+	usable := c.state == Ready || c.state == Streaming
+	return usable && c.wsConn != nil && time.Since(c.lastActivity) < pongWait
+}
+
+// setState moves the state machine to `to`. Must be called with wsMutex held.
+func (c *Client) setState(to ConnectionState, err error) {
+	if c.state == to {
+		return
+	}
+
+	event := StateEvent{From: c.state, To: to, Err: err, At: time.Now()}
+	c.state = to
+
+	switch to {
+	case Backoff:
+		// Double the delay after every failed connect, up to maxBackoff
+		c.backoff = min(max(c.backoff*2, minBackoff), maxBackoff)
+		c.retryAt = event.At.Add(c.backoff)
+	case Ready:
+		c.backoff = 0
+		c.retryAt = time.Time{}
+	}
+
+	select {
+	case c.events <- event:
+	default:
+		log.Printf("Dropped connection state event %s -> %s", event.From, event.To)
+	}
+}
+
+// dispatchEvents delivers state events to subscribers
+func (c *Client) dispatchEvents() {
+	for event := range c.events {
+		c.subscribersMutex.Lock()
+		subscribers := append([]func(StateEvent){}, c.subscribers...)
+		c.subscribersMutex.Unlock()
+
+		for _, fn := range subscribers {
+			fn(event)
+		}
+	}
+}
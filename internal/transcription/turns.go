@@ -0,0 +1,22 @@
+package transcription
+
+import "strings"
+
+// joinTurns joins consecutive turns with a space. Turns never overlap, so a
+// word repeated across them was said twice and is kept.
+func joinTurns(turns []string) string {
+	text := ""
+	for _, turn := range turns {
+		text = appendTurn(text, turn)
+	}
+	return text
+}
+
+// appendTurn appends next to text, separated by a space
+func appendTurn(text, next string) string {
+	next = strings.TrimSpace(next)
+	if text == "" || next == "" {
+		return text + next
+	}
+	return text + " " + next
+}
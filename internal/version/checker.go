@@ -1,33 +1,114 @@
 package version
 
 import (
+	"context"
+	"encoding/json"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"regexp"
+	"time"
 )
 
 const VERSION_URL = "https://raw.githubusercontent.com/bezmoradi/t2/main/internal/version/version.go"
 
-func CheckVersion() (bool, string) {
-	res, err := http.Get(VERSION_URL)
+const (
+	checkTimeout = 3 * time.Second
+	cacheTTL     = 24 * time.Hour
+)
+
+// cachedCheck is the on-disk record of the last successful version lookup
+type cachedCheck struct {
+	CheckedAt        time.Time `json:"checked_at"`
+	InstalledVersion string    `json:"installed_version"`
+	LatestVersion    string    `json:"latest_version"`
+}
+
+// CheckVersion reports whether the installed version is the latest one.
+// Lookups are cached at cachePath for 24h; pass "" to skip the cache.
+func CheckVersion(cachePath string) (bool, string) {
+	newVersion := loadCachedVersion(cachePath)
+	if newVersion == "" {
+		newVersion = fetchLatestVersion()
+		if newVersion == "" {
+			return true, ""
+		}
+		saveCachedVersion(cachePath, newVersion)
+	}
+
+	if VERSION != newVersion {
+		return false, newVersion
+	}
+
+	return true, ""
+}
+
+func fetchLatestVersion() string {
+	ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, VERSION_URL, nil)
 	if err != nil {
-		return true, ""
+		return ""
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return ""
 	}
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
-		return true, ""
+		return ""
 	}
 	bytes, err := io.ReadAll(res.Body)
 	if err != nil {
-		return true, ""
+		return ""
 	}
 
-	newVersion := extractVersion(string(bytes))
-	if VERSION != newVersion {
-		return false, newVersion
+	return extractVersion(string(bytes))
+}
+
+func loadCachedVersion(cachePath string) string {
+	if cachePath == "" {
+		return ""
 	}
 
-	return true, ""
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		return ""
+	}
+
+	var cached cachedCheck
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return ""
+	}
+
+	// An upgrade since the last check makes the cached answer meaningless
+	if cached.InstalledVersion != VERSION || time.Since(cached.CheckedAt) > cacheTTL {
+		return ""
+	}
+	return cached.LatestVersion
+}
+
+func saveCachedVersion(cachePath string, latestVersion string) {
+	if cachePath == "" {
+		return
+	}
+
+	data, err := json.MarshalIndent(cachedCheck{
+		CheckedAt:        time.Now(),
+		InstalledVersion: VERSION,
+		LatestVersion:    latestVersion,
+	}, "", "  ")
+	if err != nil {
+		return
+	}
+
+	// Cache failures are not worth surfacing - we'll just check again next time
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return
+	}
+	os.WriteFile(cachePath, data, 0644)
 }
 
 func extractVersion(input string) string {